@@ -35,8 +35,23 @@ func main() {
 	flag.BoolVar(
 		&options.WithPresence, "with_presence", false, "Generate presence methods.",
 	)
+	flag.BoolVar(
+		&options.SinglePassDecode, "single_pass_decode", false,
+		"Decode repeated fields in one pass without counting their elements first.",
+	)
+	marshalStream := flag.String(
+		"marshal_stream", generator.MoleculeStream.String(),
+		"Stream that the generated Marshal methods write to: molecule, sized or backward.",
+	)
 	flag.Parse()
 
+	var err error
+	options.MarshalStream, err = generator.ParseMarshalStream(*marshalStream)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(-1)
+	}
+
 	files := flag.Args()
 
 	if len(files) == 0 {
@@ -0,0 +1,165 @@
+// protoc-gen-lazyproto is a protoc plugin that generates lazyproto Go code.
+//
+// Install it in your PATH and run protoc with --lazyproto_out option, e.g.:
+//
+//	protoc --lazyproto_out=with_presence:./gen logs.proto
+//
+// The marshal_stream=sized or marshal_stream=backward parameter generates the Marshal
+// methods for one of the experimental streams instead of molecule.ProtoStream.
+// The single_pass_decode parameter generates the decoding of the repeated fields
+// without the pass that counts their elements.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/tigrannajaryan/exp-lazyproto/generator"
+)
+
+func main() {
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(-1)
+	}
+}
+
+// run reads a CodeGeneratorRequest from in and writes a CodeGeneratorResponse to out.
+func run(in io.Reader, out io.Writer) error {
+	reqBytes, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	var req pluginpb.CodeGeneratorRequest
+	if err := proto.Unmarshal(reqBytes, &req); err != nil {
+		return err
+	}
+
+	resp := generate(&req)
+
+	respBytes, err := proto.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(respBytes)
+	return err
+}
+
+// generate performs the code generation for the request. Generation errors are
+// reported via the Error field of the response, as required by protoc.
+func generate(req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	resp := &pluginpb.CodeGeneratorResponse{
+		// protoc refuses to pass proto3 "optional" fields to plugins that don't
+		// declare the support.
+		SupportedFeatures: proto.Uint64(
+			uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL),
+		),
+	}
+
+	files, err := generateFiles(req)
+	if err != nil {
+		resp.Error = proto.String(err.Error())
+		return resp
+	}
+
+	resp.File = files
+	return resp
+}
+
+// params are the plugin parameters passed via --lazyproto_out or --lazyproto_opt.
+type params struct {
+	options generator.Options
+
+	// sourceRelative places the output files in the same relative directory
+	// as the input files instead of the directory named after the Go import path.
+	sourceRelative bool
+}
+
+func parseParams(parameter string) (params, error) {
+	var p params
+	if parameter == "" {
+		return p, nil
+	}
+
+	for _, param := range strings.Split(parameter, ",") {
+		name, value, _ := strings.Cut(param, "=")
+		switch name {
+		case "with_presence":
+			p.options.WithPresence = value == "" || value == "true"
+		case "single_pass_decode":
+			p.options.SinglePassDecode = value == "" || value == "true"
+		case "marshal_stream":
+			stream, err := generator.ParseMarshalStream(value)
+			if err != nil {
+				return p, err
+			}
+			p.options.MarshalStream = stream
+		case "paths":
+			switch value {
+			case "import":
+				p.sourceRelative = false
+			case "source_relative":
+				p.sourceRelative = true
+			default:
+				return p, fmt.Errorf("invalid value %q for parameter %q", value, name)
+			}
+		default:
+			return p, fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+	return p, nil
+}
+
+func generateFiles(req *pluginpb.CodeGeneratorRequest) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	p, err := parseParams(req.GetParameter())
+	if err != nil {
+		return nil, err
+	}
+
+	// The request contains all files to generate and all of their dependencies,
+	// in topological order.
+	fileDescrsByName, err := desc.CreateFileDescriptors(req.GetProtoFile())
+	if err != nil {
+		return nil, err
+	}
+
+	var fileDescrs []*desc.FileDescriptor
+	for _, name := range req.GetFileToGenerate() {
+		fileDescr, ok := fileDescrsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("file %s is not found in the request", name)
+		}
+		fileDescrs = append(fileDescrs, fileDescr)
+	}
+
+	files, err := generator.GenerateFiles(fileDescrs, p.options)
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*pluginpb.CodeGeneratorResponse_File
+	for _, file := range files {
+		name := file.Name
+		if p.sourceRelative {
+			name = path.Join(path.Dir(file.ProtoFile), name)
+		} else if file.GoImportPath != "" {
+			name = path.Join(file.GoImportPath, name)
+		}
+		r = append(
+			r, &pluginpb.CodeGeneratorResponse_File{
+				Name:    proto.String(name),
+				Content: proto.String(string(file.Content)),
+			},
+		)
+	}
+	return r, nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const simpleExampleDir = "../../internal/examples/simple"
+const typesExampleDir = "../../internal/examples/types"
+
+// createRequest creates a serialized CodeGeneratorRequest for the specified proto
+// file of the simple example, the same way protoc would.
+func createRequest(t *testing.T, fileName string, parameter string) []byte {
+	return createRequestFromDir(t, simpleExampleDir, fileName, parameter)
+}
+
+// createRequestFromDir creates a serialized CodeGeneratorRequest for the specified
+// proto file that is found in dir.
+func createRequestFromDir(t *testing.T, dir string, fileName string, parameter string) []byte {
+	p := protoparse.Parser{
+		ImportPaths:           []string{dir},
+		IncludeSourceCodeInfo: true,
+	}
+	fileDescrs, err := p.ParseFiles(fileName)
+	require.NoError(t, err)
+
+	// ToFileDescriptorSet lists the files in topological order, which is
+	// what protoc does too.
+	fdSet := desc.ToFileDescriptorSet(fileDescrs...)
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fileName},
+		ProtoFile:      fdSet.File,
+	}
+	if parameter != "" {
+		req.Parameter = proto.String(parameter)
+	}
+
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return reqBytes
+}
+
+func runRequest(t *testing.T, reqBytes []byte) *pluginpb.CodeGeneratorResponse {
+	var out bytes.Buffer
+	err := run(bytes.NewReader(reqBytes), &out)
+	require.NoError(t, err)
+
+	var resp pluginpb.CodeGeneratorResponse
+	err = proto.Unmarshal(out.Bytes(), &resp)
+	require.NoError(t, err)
+	return &resp
+}
+
+func TestGenerateSimpleExample(t *testing.T) {
+	resp := runRequest(t, createRequest(t, "logs.proto", ""))
+	require.Empty(t, resp.GetError())
+	require.Len(t, resp.File, 1)
+
+	// The plugin must produce exactly the same code as the command line generator.
+	expected, err := os.ReadFile(simpleExampleDir + "/lazy/logs.pb.go")
+	require.NoError(t, err)
+
+	// The file is placed in the directory of the go_package import path.
+	assert.EqualValues(t, "gen/logs/logs.pb.go", resp.File[0].GetName())
+	assert.EqualValues(t, string(expected), resp.File[0].GetContent())
+	assert.EqualValues(
+		t, pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL,
+		resp.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL),
+	)
+}
+
+func TestParameters(t *testing.T) {
+	resp := runRequest(t, createRequest(t, "logs.proto", "with_presence,paths=source_relative"))
+	require.Empty(t, resp.GetError())
+	require.Len(t, resp.File, 1)
+	assert.Contains(t, resp.File[0].GetContent(), "func (m *LogRecord) HasSeverityText() bool")
+	assert.EqualValues(t, "logs.pb.go", resp.File[0].GetName())
+
+	resp = runRequest(t, createRequest(t, "logs.proto", "no_such_param"))
+	assert.Contains(t, resp.GetError(), "no_such_param")
+	assert.Empty(t, resp.File)
+
+	resp = runRequest(t, createRequest(t, "logs.proto", "paths=unknown"))
+	assert.Contains(t, resp.GetError(), "paths")
+
+	resp = runRequest(t, createRequest(t, "logs.proto", "marshal_stream=backward"))
+	require.Empty(t, resp.GetError())
+	assert.Contains(t, resp.File[0].GetContent(), "Marshal(ps *backwardstream.BackwardMemStream) error")
+
+	resp = runRequest(t, createRequest(t, "logs.proto", "single_pass_decode"))
+	require.Empty(t, resp.GetError())
+	assert.NotContains(t, resp.File[0].GetContent(), "// Count all repeated fields.")
+	assert.Contains(t, resp.File[0].GetContent(), "m.attributes = append(m.attributes, nil)")
+
+	resp = runRequest(t, createRequest(t, "logs.proto", "marshal_stream=unknown"))
+	assert.Contains(t, resp.GetError(), "marshal stream")
+
+	// The gRPC stubs use the codec that requires the molecule stream.
+	resp = runRequest(t, createRequestFromDir(t, typesExampleDir, "service.proto", "marshal_stream=sized"))
+	assert.Contains(t, resp.GetError(), "molecule")
+}
+
+func TestGenerateServices(t *testing.T) {
+	resp := runRequest(t, createRequestFromDir(t, typesExampleDir, "service.proto", ""))
+	require.Empty(t, resp.GetError())
+	require.Len(t, resp.File, 2)
+
+	// The services are generated to a separate file of the same package.
+	for i, name := range []string{"service.pb.go", "service_grpc.pb.go"} {
+		expected, err := os.ReadFile(typesExampleDir + "/lazy/" + name)
+		require.NoError(t, err)
+		assert.EqualValues(t, name, resp.File[i].GetName())
+		assert.EqualValues(t, string(expected), resp.File[i].GetContent())
+	}
+}
+
+// TestSimpleExampleVariants runs the tests of the simple example with the code
+// generated using the options that are not used by the checked-in code. The
+// generated code replaces the checked-in code using an overlay, so the source tree
+// is not modified.
+func TestSimpleExampleVariants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs the tests of the simple example")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	tests := []struct {
+		parameter string
+		tags      string
+	}{
+		{parameter: "marshal_stream=sized", tags: "sizedstream"},
+		{parameter: "marshal_stream=backward", tags: "backwardstream"},
+		{parameter: "single_pass_decode"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(
+			test.parameter, func(t *testing.T) {
+				resp := runRequest(t, createRequest(t, "logs.proto", test.parameter))
+				require.Empty(t, resp.GetError())
+				require.Len(t, resp.File, 1)
+
+				generated := filepath.Join(t.TempDir(), "logs.pb.go")
+				require.NoError(t, os.WriteFile(generated, []byte(resp.File[0].GetContent()), 0o644))
+
+				checkedIn, err := filepath.Abs(simpleExampleDir + "/lazy/logs.pb.go")
+				require.NoError(t, err)
+				overlay, err := json.Marshal(
+					map[string]map[string]string{"Replace": {checkedIn: generated}},
+				)
+				require.NoError(t, err)
+				overlayFile := filepath.Join(t.TempDir(), "overlay.json")
+				require.NoError(t, os.WriteFile(overlayFile, overlay, 0o644))
+
+				cmd := exec.Command(
+					goTool, "test", "-count=1", "-overlay", overlayFile, "-tags", test.tags,
+					// Run every lazy benchmark once too.
+					"-bench", "BenchmarkLazy", "-benchtime", "1x", ".",
+				)
+				cmd.Dir = simpleExampleDir
+				out, err := cmd.CombinedOutput()
+				require.NoError(t, err, string(out))
+			},
+		)
+	}
+}
@@ -0,0 +1,162 @@
+package generator
+
+func (g *generator) oCloneMethod() error {
+	g.o(
+		`
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *$MessageName) Clone() *$MessageName {
+	c := $messagePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+`,
+	)
+
+	if g.options.MarshalStream == MoleculeStream {
+		// Only the messages marshaled to molecule stream implement lazyproto.Message.
+		g.o(
+			`
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *$MessageName) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}`,
+		)
+	}
+
+	g.o(
+		`
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *$MessageName) cloneInto(c *$MessageName) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}`,
+	)
+	g.i(1)
+
+	if g.msg.FlagsBitCount > 0 {
+		g.o(`c._flags = m._flags`)
+	}
+
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+
+		switch {
+		case field.IsMap():
+			g.oCloneMapField()
+
+		case field.GetOneOf() != nil:
+			if g.calcOneOfFieldIndex() == 0 {
+				// We generate all oneof cases when we see the first field. Skip for the rest.
+				g.oCloneOneofField()
+			}
+
+		case isMessageField(field):
+			if field.IsRepeated() {
+				g.o(
+					`
+// Clone $fieldName elements into structs taken from the pool all at once.
+if cap(c.$fieldName) < len(m.$fieldName) {
+	c.$fieldName = make(%s, len(m.$fieldName))
+} else {
+	c.$fieldName = c.$fieldName[:len(m.$fieldName)]
+}
+$fieldTypeMessagePool.GetSlice(c.$fieldName)
+for i, elem := range m.$fieldName {
+	elem.$fieldTypeCloneInto(c.$fieldName[i])
+	c.$fieldName[i].$fieldTypeProtoMessage.Parent = &c._protoMessage
+}`, g.convertTypeToGo(field),
+				)
+			} else {
+				g.o(
+					`
+if m.$fieldName != nil {
+	c.$fieldName = $fieldTypeMessagePool.Get()
+	m.$fieldName.$fieldTypeCloneInto(c.$fieldName)
+	c.$fieldName.$fieldTypeProtoMessage.Parent = &c._protoMessage
+}`,
+				)
+			}
+
+		case field.IsRepeated():
+			g.o(`c.$fieldName = append(c.$fieldName[:0], m.$fieldName...)`)
+
+		default:
+			g.o(`c.$fieldName = m.$fieldName`)
+		}
+	}
+
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+
+	return g.lastErr
+}
+
+func (g *generator) oCloneOneofField() {
+	oneofName := g.field.GetOneOf().GetName()
+	g.o(`c.%s = m.%s`, oneofName, oneofName)
+
+	typeName := composeOneOfAliasTypeName(g.msg, g.field.GetOneOf())
+	var msgChoices []*Field
+	for _, choice := range g.field.GetOneOf().GetChoices() {
+		choiceField := g.msg.FieldsMap[choice.GetName()]
+		if isMessageField(choiceField) {
+			msgChoices = append(msgChoices, choiceField)
+		}
+	}
+	if len(msgChoices) == 0 {
+		return
+	}
+
+	g.o(`// Embedded messages cannot be shared, clone them.`)
+	g.o(`switch %s(m.%s.FieldIndex()) {`, typeName, oneofName)
+	for _, choiceField := range msgChoices {
+		g.setField(choiceField)
+		choiceName := composeOneOfChoiceName(g.msg, choiceField)
+		g.o(
+			`
+case %[1]s:
+	ptr := (*$FieldMessageTypeName)(m.%[2]s.PtrVal())
+	if ptr != nil {
+		elem := $fieldTypeMessagePool.Get()
+		ptr.$fieldTypeCloneInto(elem)
+		elem.$fieldTypeProtoMessage.Parent = &c._protoMessage
+		c.%[2]s = oneof.NewPtr(unsafe.Pointer(elem), int(%[1]s))
+	}`, choiceName, oneofName,
+		)
+	}
+	g.o(`}`)
+}
+
+func (g *generator) oCloneMapField() {
+	g.setMapField()
+	_, _, value := g.mapEntry(g.field)
+
+	g.o(`c.$fieldNameRaw = m.$fieldNameRaw`)
+	g.o(`c.$fieldNameDecodeErr = m.$fieldNameDecodeErr`)
+	g.o(`if m._flags&$mapDecodedFlag != 0 {`)
+	g.o(`	// The map is decoded, copy the entries.`)
+	g.o(`	if c.$fieldName == nil && len(m.$fieldName) > 0 {`)
+	g.o(`		c.$fieldName = make(map[$MapKeyType]$MapValueType, len(m.$fieldName))`)
+	g.o(`	}`)
+	g.o(`	for k, v := range m.$fieldName {`)
+	if isMessageField(value) {
+		g.o(`		if v != nil {`)
+		g.o(`			elem := $mapValuePool.Get()`)
+		g.o(`			v.$mapValueCloneInto(elem)`)
+		g.o(`			elem.$mapValueProtoMessage.Parent = &c._protoMessage`)
+		g.o(`			v = elem`)
+		g.o(`		}`)
+	}
+	g.o(`		c.$fieldName[k] = v`)
+	g.o(`	}`)
+	g.o(`}`)
+}
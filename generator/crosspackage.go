@@ -0,0 +1,277 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+)
+
+// goPackage is the Go package that the code generated from a proto file belongs to.
+type goPackage struct {
+	// ImportPath is the Go import path of the package. Empty if the proto file
+	// does not have the go_package option.
+	ImportPath string
+
+	// Name is the Go package name.
+	Name string
+}
+
+// fileGoPackage returns the Go package of the proto file. The package is derived
+// from the go_package option, which is either "import/path" or "import/path;name".
+// If the option is not specified the last element of the proto package is used as
+// the package name.
+func fileGoPackage(fdescr *desc.FileDescriptor) goPackage {
+	goPkg := fdescr.GetFileOptions().GetGoPackage()
+	if goPkg == "" {
+		protoPkg := fdescr.GetPackage()
+		if protoPkg == "" {
+			protoPkg = strings.TrimSuffix(path.Base(fdescr.GetName()), ".proto")
+		}
+		packagePath := strings.Split(protoPkg, ".")
+		return goPackage{Name: goSanitizedName(packagePath[len(packagePath)-1])}
+	}
+
+	importPath, name, found := strings.Cut(goPkg, ";")
+	if !found {
+		name = path.Base(importPath)
+	}
+	return goPackage{ImportPath: importPath, Name: goSanitizedName(name)}
+}
+
+// goSanitizedName converts s to a valid Go identifier.
+func goSanitizedName(s string) string {
+	r := []rune(s)
+	for i, c := range r {
+		isLetter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			r[i] = '_'
+		}
+	}
+	return string(r)
+}
+
+// reservedImportNames are the names of the packages that are always imported
+// by the generated code.
+var reservedImportNames = map[string]bool{
+	"bytes":          true,
+	"fmt":            true,
+	"math":           true,
+	"sort":           true,
+	"sync":           true,
+	"unsafe":         true,
+	"lazyproto":      true,
+	"protomessage":   true,
+	"oneof":          true,
+	"molecule":       true,
+	"codec":          true,
+	"jsonstream":     true,
+	"textstream":     true,
+	"lazyreflect":    true,
+	"protoreflect":   true,
+	"protoconv":      true,
+	"sizedstream":    true,
+	"backwardstream": true,
+	// Imported by the gRPC service stubs.
+	"context":   true,
+	"grpc":      true,
+	"codes":     true,
+	"status":    true,
+	"grpccodec": true,
+}
+
+// collectImports finds the Go packages of the message and enum types that are
+// referenced by the fields of the messages that are generated for the current
+// file and assigns unique import names to them.
+func (g *generator) collectImports() error {
+	g.imports = map[string]string{}
+	usedNames := map[string]bool{}
+
+	for _, msg := range g.messagesToGen {
+		for _, field := range msg.Fields {
+			fieldDescr := &field.FieldDescriptor
+			if field.IsMap() {
+				fieldDescr = field.GetMapValueType()
+			}
+
+			var fdescr *desc.FileDescriptor
+			if fieldDescr.GetMessageType() != nil {
+				fdescr = fieldDescr.GetMessageType().GetFile()
+			} else if fieldDescr.GetEnumType() != nil {
+				fdescr = fieldDescr.GetEnumType().GetFile()
+			} else {
+				continue
+			}
+
+			if err := g.addImport(fdescr, usedNames); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addImport assigns a unique import name to the Go package of fdescr, unless the
+// package is the current one or is already imported.
+func (g *generator) addImport(fdescr *desc.FileDescriptor, usedNames map[string]bool) error {
+	if g.isCurrentPackage(fdescr) {
+		return nil
+	}
+
+	pkg := fileGoPackage(fdescr)
+	if pkg.ImportPath == "" {
+		return fmt.Errorf(
+			"%s is imported by %s, but does not specify go_package option",
+			fdescr.GetName(), g.file.GetName(),
+		)
+	}
+	if _, exists := g.imports[pkg.ImportPath]; exists {
+		return nil
+	}
+
+	// Add a numeric suffix if the package name is already taken.
+	name := pkg.Name
+	for i := 1; usedNames[name] || reservedImportNames[name]; i++ {
+		name = pkg.Name + strconv.Itoa(i)
+	}
+	usedNames[name] = true
+	g.imports[pkg.ImportPath] = name
+	return nil
+}
+
+// oImports generates the imports of the packages found by collectImports.
+func (g *generator) oImports() {
+	var importPaths []string
+	for importPath := range g.imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	for _, importPath := range importPaths {
+		g.o(`	%s %q`, g.imports[importPath], importPath)
+	}
+}
+
+// isCurrentPackage returns true if the code for fdescr is generated in the same
+// Go package as the current file.
+func (g *generator) isCurrentPackage(fdescr *desc.FileDescriptor) bool {
+	return fdescr == g.file ||
+		fileGoPackage(fdescr).ImportPath == fileGoPackage(g.file).ImportPath
+}
+
+// qualifiedName returns the name of a declaration from the package generated for
+// fdescr, qualified by the import name if the package is not the current one.
+func (g *generator) qualifiedName(fdescr *desc.FileDescriptor, name string) string {
+	if g.isCurrentPackage(fdescr) {
+		return name
+	}
+	return g.imports[fileGoPackage(fdescr).ImportPath] + "." + name
+}
+
+// messageRefs are the Go expressions that the generated code uses to refer to
+// a message type and to its unexported members.
+type messageRefs struct {
+	TypeName     string
+	SliceName    string
+	Pool         string
+	ProtoMessage string
+	Decode       string
+	CloneInto    string
+	Validate     string
+	// MarshalJSONUndecoded is the method that writes JSON of undecoded message.
+	MarshalJSONUndecoded string
+	// MarshalTextUndecoded is the method that writes text format of undecoded message.
+	MarshalTextUndecoded string
+	// NewSlice is the constructor of the slice type. Empty if the slice type
+	// is declared in the current package and can be constructed directly.
+	NewSlice string
+}
+
+// messageRefs returns the references to the message type msg. The unexported
+// members are not accessible if msg is declared in another Go package, so the
+// exported XXX_ members generated by oCrossPackageMembers are used instead.
+func (g *generator) messageRefs(msg *Message) messageRefs {
+	name := msg.GetName()
+	fdescr := msg.GetFile()
+	refs := messageRefs{
+		TypeName:  g.qualifiedName(fdescr, name),
+		SliceName: g.qualifiedName(fdescr, sliceTypeName(msg)),
+	}
+
+	if g.isCurrentPackage(fdescr) {
+		refs.Pool = getPoolName(name)
+		refs.ProtoMessage = "_protoMessage"
+		refs.Decode = "decode"
+		refs.CloneInto = "cloneInto"
+		refs.Validate = "validate" + name
+		refs.MarshalJSONUndecoded = "marshalJSONUndecoded"
+		refs.MarshalTextUndecoded = "marshalTextUndecoded"
+	} else {
+		refs.Pool = g.qualifiedName(fdescr, "XXX_"+name+"Pool")
+		refs.ProtoMessage = "XXX_ProtoMessage()"
+		refs.Decode = "XXX_Decode"
+		refs.CloneInto = "XXX_CloneInto"
+		refs.Validate = g.qualifiedName(fdescr, "XXX_Validate"+name)
+		refs.MarshalJSONUndecoded = "XXX_MarshalJSONUndecoded"
+		refs.MarshalTextUndecoded = "XXX_MarshalTextUndecoded"
+		refs.NewSlice = g.qualifiedName(fdescr, "XXX_New"+sliceTypeName(msg))
+	}
+	return refs
+}
+
+// oCrossPackageMembers generates exported members that give the code generated
+// for other Go packages access to the unexported members of the message.
+func (g *generator) oCrossPackageMembers() error {
+	g.o(
+		`
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *$MessageName) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *$MessageName) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *$MessageName) XXX_CloneInto(c *$MessageName) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *$MessageName) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *$MessageName) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_Validate$MessageName is for use by the code generated for other packages only.
+func XXX_Validate$MessageName(b []byte) error {
+	return validate$MessageName(b)
+}
+
+// XXX_New$MessageNameSlice is for use by the code generated for other packages only.
+func XXX_New$MessageNameSlice(elems *[]*$MessageName, parent *protomessage.ProtoMessage) $MessageNameSlice {
+	return $MessageNameSlice{elems: elems, parent: parent}
+}
+
+// XXX_$MessageNamePool is for use by the code generated for other packages only.
+var XXX_$MessageNamePool = &$messagePool
+`,
+	)
+	return g.lastErr
+}
+
+// enumTypeName returns the name of the Go type of the enum, qualified by the
+// import name if the enum is declared in another Go package.
+func (g *generator) enumTypeName(descr *desc.EnumDescriptor) string {
+	return g.qualifiedName(descr.GetFile(), g.enumDescrToEnum[descr].GetName())
+}
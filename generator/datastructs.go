@@ -88,6 +88,19 @@ func (f *Message) GetName() string {
 	return f.FullName
 }
 
+// GetOneOfs returns the oneofs of the message, excluding synthetic oneofs. The
+// synthetic oneofs are created for proto3 "optional" fields and such fields are
+// generated as regular fields with presence flags.
+func (f *Message) GetOneOfs() []*desc.OneOfDescriptor {
+	var r []*desc.OneOfDescriptor
+	for _, oneof := range f.MessageDescriptor.GetOneOfs() {
+		if !oneof.IsSynthetic() {
+			r = append(r, oneof)
+		}
+	}
+	return r
+}
+
 // Field is the representation of a source proto field.
 type Field struct {
 	desc.FieldDescriptor
@@ -98,6 +111,16 @@ func (f *Field) GetName() string {
 	return f.camelName
 }
 
+// GetOneOf returns the oneof that the field belongs to or nil if the field does not
+// belong to a oneof. Synthetic oneofs of proto3 "optional" fields are not reported.
+func (f *Field) GetOneOf() *desc.OneOfDescriptor {
+	oneof := f.FieldDescriptor.GetOneOf()
+	if oneof != nil && oneof.IsSynthetic() {
+		return nil
+	}
+	return oneof
+}
+
 func (f *Field) GetCapitalName() string {
 	return capitalCamelCase(f.camelName)
 }
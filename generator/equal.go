@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func (g *generator) oEqualMethod() error {
+	g.o(
+		`
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *$MessageName) Equal(other *$MessageName) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}`,
+	)
+	g.i(1)
+
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+
+		switch {
+		case field.IsMap():
+			g.oEqualMapField()
+
+		case field.GetOneOf() != nil:
+			if g.calcOneOfFieldIndex() == 0 {
+				// We generate all oneof cases when we see the first field. Skip for the rest.
+				g.oEqualOneofField()
+			}
+
+		case field.IsRepeated() && isMessageField(field):
+			g.o(
+				`
+{
+	a, b := m.$FieldName(), other.$FieldName()
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if !a.At(i).Equal(b.At(i)) {
+			return false
+		}
+	}
+}`,
+			)
+
+		case field.IsRepeated():
+			g.o(
+				`
+{
+	a, b := m.$fieldName, other.$fieldName
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if %s {
+			return false
+		}
+	}
+}`, g.notEqualExpr(field, "a[i]", "b[i]"),
+			)
+
+		case hasExplicitPresence(field):
+			// Present field is not equal to absent field even if the values are equal.
+			getter := field.GetCapitalName() + "()"
+			g.o(
+				`if m.Has%s != other.Has%s || %s {`, getter, getter,
+				g.notEqualExpr(field, "m."+getter, "other."+getter),
+			)
+			g.o(`	return false`)
+			g.o(`}`)
+
+		default:
+			getter := field.GetCapitalName() + "()"
+			g.o(`if %s {`, g.notEqualExpr(field, "m."+getter, "other."+getter))
+			g.o(`	return false`)
+			g.o(`}`)
+		}
+	}
+
+	g.o(`return m._unknownFields.Equal(&other._unknownFields)`)
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+
+	return g.lastErr
+}
+
+// notEqualExpr returns a Go expression that is true if the values a and b of the
+// type of the field (or of the element type for repeated fields) are not equal.
+func (g *generator) notEqualExpr(field *Field, a, b string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		// Equal handles nil pointers, so absent and present messages are not equal.
+		return "!" + a + ".Equal(" + b + ")"
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "!bytes.Equal(" + a + ", " + b + ")"
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "!protomessage.EqualFloat64(" + a + ", " + b + ")"
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "!protomessage.EqualFloat32(" + a + ", " + b + ")"
+	default:
+		return a + " != " + b
+	}
+}
+
+func (g *generator) oEqualOneofField() {
+	oneofName := g.field.GetOneOf().GetName()
+	typeName := composeOneOfAliasTypeName(g.msg, g.field.GetOneOf())
+
+	g.o(`if m.%s.FieldIndex() != other.%s.FieldIndex() {`, oneofName, oneofName)
+	g.o(`	return false`)
+	g.o(`}`)
+	g.o(`switch %s(m.%s.FieldIndex()) {`, typeName, oneofName)
+	for _, choice := range g.field.GetOneOf().GetChoices() {
+		choiceField := g.msg.FieldsMap[choice.GetName()]
+		g.setField(choiceField)
+		g.o(`case %s:`, composeOneOfChoiceName(g.msg, choiceField))
+		getter := choiceField.GetCapitalName() + "()"
+		g.o(`	if %s {`, g.notEqualExpr(choiceField, "m."+getter, "other."+getter))
+		g.o(`		return false`)
+		g.o(`	}`)
+	}
+	g.o(`}`)
+}
+
+func (g *generator) oEqualMapField() {
+	_, _, value := g.mapEntry(g.field)
+	g.setMapField()
+
+	g.o(
+		`
+if m.$FieldNameLen() != other.$FieldNameLen() {
+	return false
+}
+for k, v := range m.$fieldName {
+	ov, ok := other.$fieldName[k]
+	if !ok || %s {
+		return false
+	}
+}`, g.notEqualExpr(value, "v", "ov"),
+	)
+}
@@ -2,8 +2,8 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	_ "github.com/jhump/protoreflect/desc/protoparse"
 )
 
@@ -11,7 +11,14 @@ func (g *generator) oFieldsAccessors() error {
 	for _, field := range g.msg.Fields {
 		g.setField(field)
 
-		if g.options.WithPresence {
+		if field.IsMap() {
+			if err := g.oMapFieldAccessors(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := g.msg.PresenceFlagName[field]; ok || g.options.WithPresence {
 			if err := g.oHasMethod(); err != nil {
 				return err
 			}
@@ -25,13 +32,27 @@ func (g *generator) oFieldsAccessors() error {
 			return err
 		}
 
-		if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE && g.field.IsRepeated() {
-			if err := g.oFieldSliceMethods(); err != nil {
+		if hasExplicitPresence(field) {
+			if err := g.oClearMethod(); err != nil {
 				return err
 			}
 		}
 	}
-	return nil
+	return g.oUnknownFieldsMethod()
+}
+
+func (g *generator) oUnknownFieldsMethod() error {
+	g.o(
+		`
+// UnknownFields returns the wire representation of the fields that are not
+// known to the $MessageName schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *$MessageName) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+`,
+	)
+	return g.lastErr
 }
 
 func (g *generator) oFieldDecodeMethod() {
@@ -40,11 +61,12 @@ func (g *generator) oFieldDecodeMethod() {
 	g.o(`func (m *$MessageName) decode$FieldName() {`)
 	g.i(1)
 
-	g.o(`// Decode nested message(s).`)
+	g.o(`// Decode nested message(s). The errors are recorded on the nested messages.`)
 	if g.field.IsRepeated() {
-		g.o(`for i := range m.$fieldName {`)
-		g.o(`	// TODO: decide how to handle decoding errors.`)
-		g.o(`	_ = m.$fieldName[i].decode()`)
+		g.o(`for _, elem := range m.$fieldName {`)
+		g.o(`	if err := elem.$fieldTypeDecode(); err != nil {`)
+		g.o(`		elem.$fieldTypeProtoMessage.SetDecodeErr(err)`)
+		g.o(`	}`)
 		g.o(`}`)
 	} else {
 		if g.field.GetOneOf() != nil {
@@ -63,8 +85,9 @@ func (g *generator) oFieldDecodeMethod() {
 		}
 
 		g.o(`if $fieldName != nil {`)
-		g.o(`	// TODO: decide how to handle decoding errors.`)
-		g.o(`	_ = $fieldName.decode()`)
+		g.o(`	if err := $fieldName.$fieldTypeDecode(); err != nil {`)
+		g.o(`		$fieldName.$fieldTypeProtoMessage.SetDecodeErr(err)`)
+		g.o(`	}`)
 		g.o(`}`)
 
 		if g.field.GetOneOf() != nil {
@@ -88,13 +111,28 @@ func (g *generator) oFieldGetter() error {
 		)
 	}
 
+	defaultVal, hasDefault := g.defaultValue(g.field)
+	if hasDefault {
+		g.o(`// If the field is not present then the default value %s is returned.`, defaultVal)
+	}
+
 	goType := g.convertTypeToGo(g.field)
+	if g.field.IsRepeated() && !isMessageField(g.field) {
+		goType = g.scalarSliceType(g.field)
+	}
+	isMessageSlice := isMessageField(g.field) &&
+		g.field.IsRepeated()
+	var refs messageRefs
+	if isMessageSlice {
+		refs = g.messageRefs(g.messageDescrToMessage[g.field.GetMessageType()])
+		goType = refs.SliceName
+	}
 
 	g.o(`func (m *$MessageName) $FieldName() (r %s) {`, goType)
 
 	g.i(1)
 
-	if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+	if isMessageField(g.field) {
 		g.o(`if m._flags&%s == 0 {`, g.msg.DecodedFlagName[g.field])
 		g.o("	m.decode$FieldName()")
 		g.o("}")
@@ -112,6 +150,10 @@ func (g *generator) oFieldGetter() error {
 			g.i(-1)
 			g.o("}")
 			g.o("return nil")
+		} else if isMessageSlice && refs.NewSlice != "" {
+			g.o("return %s(&m.$fieldName, &m._protoMessage)", refs.NewSlice)
+		} else if isMessageSlice {
+			g.o("return %s{elems: &m.$fieldName, parent: &m._protoMessage}", goType)
 		} else {
 			g.o("return m.$fieldName")
 		}
@@ -119,6 +161,7 @@ func (g *generator) oFieldGetter() error {
 		g.o("}")
 		g.o("")
 		g.oFieldDecodeMethod()
+		g.oFieldGetterE(goType)
 		return g.lastErr
 	}
 
@@ -130,32 +173,27 @@ func (g *generator) oFieldGetter() error {
 		)
 		g.i(1)
 
-		switch g.field.GetType() {
-		case descriptor.FieldDescriptorProto_TYPE_BOOL:
-			g.o(`return m.%s.BoolVal()`, g.field.GetOneOf().GetName())
-
-		case descriptor.FieldDescriptorProto_TYPE_INT64,
-			descriptor.FieldDescriptorProto_TYPE_SFIXED64:
-			g.o(`return m.%s.Int64Val()`, g.field.GetOneOf().GetName())
-
-		case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-			g.o(`return m.%s.DoubleVal()`, g.field.GetOneOf().GetName())
-		case descriptor.FieldDescriptorProto_TYPE_STRING:
-			g.o(`return m.%s.StringVal()`, g.field.GetOneOf().GetName())
-		case descriptor.FieldDescriptorProto_TYPE_BYTES:
-			g.o(`return m.%s.BytesVal()`, g.field.GetOneOf().GetName())
-		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-			g.o(`return (%s)(m.%s.PtrVal())`, goType, g.field.GetOneOf().GetName())
-		default:
+		decode, ok := primitiveTypeDecode[g.field.GetType()]
+		if !ok {
 			return fmt.Errorf("unsupported oneof field type %v", g.field.GetType())
 		}
+		g.o(`return m.%s.%sVal()`, g.field.GetOneOf().GetName(), decode.oneOfType)
 
 		g.i(-1)
 		g.o("}")
 		g.o("return")
 
 	} else {
-		g.o(`return m.$fieldName`)
+		if hasDefault {
+			g.o(`if m._flags&%s == 0 {`, g.msg.PresenceFlagName[g.field])
+			g.o(`	return %s`, defaultVal)
+			g.o(`}`)
+		}
+		if g.field.IsRepeated() {
+			g.o(`return protomessage.NewScalarSlice(&m.$fieldName, &m._protoMessage)`)
+		} else {
+			g.o(`return m.$fieldName`)
+		}
 	}
 
 	g.i(-1)
@@ -165,6 +203,42 @@ func (g *generator) oFieldGetter() error {
 	return g.lastErr
 }
 
+// scalarSliceType returns the type that the getter of the repeated scalar field
+// returns.
+func (g *generator) scalarSliceType(field *Field) string {
+	return fmt.Sprintf(
+		"protomessage.ScalarSlice[%s]", strings.TrimPrefix(g.convertTypeToGo(field), "[]"),
+	)
+}
+
+// oFieldGetterE generates the getter of the message field that also returns the
+// error of the lazy decoding of the returned message(s).
+func (g *generator) oFieldGetterE(goType string) {
+	g.o(``)
+	g.o(`// $FieldNameE is the same as $FieldName, but also returns the first error of`)
+	g.o(`// the decoding of the returned message(s) or of their nested messages that were`)
+	g.o(`// accessed so far.`)
+	g.o(`func (m *$MessageName) $FieldNameE() (r %s, err error) {`, goType)
+	g.i(1)
+	g.o(`r = m.$FieldName()`)
+	if g.field.IsRepeated() {
+		g.o(`for _, elem := range m.$fieldName {`)
+		g.o(`	if err := elem.DecodeErr(); err != nil {`)
+		g.o(`		return r, err`)
+		g.o(`	}`)
+		g.o(`}`)
+		g.o(`return r, nil`)
+	} else {
+		g.o(`if r == nil {`)
+		g.o(`	return r, nil`)
+		g.o(`}`)
+		g.o(`return r, r.DecodeErr()`)
+	}
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+}
+
 func (g *generator) oFieldSetter() error {
 	g.o(`// Set$FieldName sets the value of the $fieldName.`)
 
@@ -182,48 +256,38 @@ func (g *generator) oFieldSetter() error {
 		oneofName := g.field.GetOneOf().GetName()
 
 		g.i(1)
-		switch g.field.GetType() {
-		case descriptor.FieldDescriptorProto_TYPE_BOOL:
-			g.o("m.%s = oneof.NewBool(v, int(%s))", oneofName, choiceName)
-
-		case descriptor.FieldDescriptorProto_TYPE_INT64,
-			descriptor.FieldDescriptorProto_TYPE_SFIXED64:
-			g.o("m.%s = oneof.NewInt64(v, int(%s))", oneofName, choiceName)
-
-		case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-			g.o("m.%s = oneof.NewDouble(v, int(%s))", oneofName, choiceName)
-
-		case descriptor.FieldDescriptorProto_TYPE_STRING:
-			g.o("m.%s = oneof.NewString(v, int(%s))", oneofName, choiceName)
-
-		case descriptor.FieldDescriptorProto_TYPE_BYTES:
-			g.o("m.%s = oneof.NewBytes(v, int(%s))", oneofName, choiceName)
-
-		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		if isMessageField(g.field) {
 			g.o("m.%s = oneof.NewPtr(unsafe.Pointer(v), int(%s))", oneofName, choiceName)
-
-		default:
-			return fmt.Errorf("unsupported oneof field type %v", g.field.GetType())
+		} else {
+			decode, ok := primitiveTypeDecode[g.field.GetType()]
+			if !ok {
+				return fmt.Errorf("unsupported oneof field type %v", g.field.GetType())
+			}
+			g.o("m.%s = oneof.New%s(v, int(%s))", oneofName, decode.oneOfType, choiceName)
 		}
 		g.i(-1)
 	} else {
 		g.o(`	m.$fieldName = v`)
-		if g.options.WithPresence {
-			if g.field.GetType() != descriptor.FieldDescriptorProto_TYPE_MESSAGE {
-				g.o(`m._flags |= %s`, g.msg.PresenceFlagName[g.field])
-			}
+		if flagName, ok := g.msg.PresenceFlagName[g.field]; ok {
+			g.o(`m._flags |= %s`, flagName)
 		}
 	}
 
-	if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+	if isMessageField(g.field) {
+		g.o(``)
+		g.o(`	// The new value does not need decoding, it is either constructed locally`)
+		g.o(`	// or obtained from a decoded message.`)
+		g.o(`	m._flags |= %s`, g.msg.DecodedFlagName[g.field])
 		g.o(``)
 		g.o(`	// Make sure the field's Parent points to this message.`)
 		if g.field.IsRepeated() {
-			g.o(`	for _, elem := range m.$fieldName {`)
-			g.o(`		elem._protoMessage.Parent = &m._protoMessage`)
+			g.o(`	for _, elem := range v {`)
+			g.o(`		elem.$fieldTypeProtoMessage.Parent = &m._protoMessage`)
 			g.o(`	}`)
 		} else {
-			g.o(`	v._protoMessage.Parent = &m._protoMessage`)
+			g.o(`	if v != nil {`)
+			g.o(`		v.$fieldTypeProtoMessage.Parent = &m._protoMessage`)
+			g.o(`	}`)
 		}
 	}
 	g.o(``)
@@ -235,6 +299,20 @@ func (g *generator) oFieldSetter() error {
 	return g.lastErr
 }
 
+func (g *generator) oClearMethod() error {
+	g.o(`// Clear$FieldName clears the $fieldName, so that it is no longer present.`)
+	g.o(`func (m *$MessageName) Clear$FieldName() {`)
+	g.o(`	m.$fieldName = %s`, g.zeroValue(g.field))
+	g.o(`	m._flags &^= %s`, g.msg.PresenceFlagName[g.field])
+	g.o(``)
+	g.o(`	// Mark this message modified, if not already.`)
+	g.o(`	m._protoMessage.MarkModified()`)
+	g.o(`}`)
+	g.o(``)
+
+	return g.lastErr
+}
+
 func (g *generator) oHasMethod() error {
 	if g.field.GetOneOf() != nil {
 		// Has() func is not needed for oneof fields since they have the Type() func.
@@ -246,14 +324,14 @@ func (g *generator) oHasMethod() error {
 
 	g.i(1)
 
-	if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+	if isMessageField(g.field) {
 		if g.field.IsRepeated() {
 			g.o(`return len(m.$fieldName) > 0`)
 		} else {
 			g.o(`return m.$fieldName != nil`)
 		}
 	} else {
-		if g.field.GetType() != descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if !isMessageField(g.field) {
 			g.o(`return m._flags & %s != 0`, g.msg.PresenceFlagName[g.field])
 		}
 	}
@@ -264,38 +342,3 @@ func (g *generator) oHasMethod() error {
 
 	return g.lastErr
 }
-
-func (g *generator) oFieldSliceMethods() error {
-	g.o(
-		`
-func (m *$MessageName) $FieldNameRemoveIf(f func(*$FieldMessageTypeName) bool) {
-	// Call getter to load the field.
-	m.$FieldName()
-
-	newLen := 0
-	for i := 0; i < len(m.$fieldName); i++ {
-		if f(m.$fieldName[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.$fieldName[newLen] != nil {
-			m.$fieldName[newLen].Free()
-		}
-		m.$fieldName[newLen] = m.$fieldName[i]
-		m.$fieldName[i] = nil
-		newLen++
-	}
-	if newLen != len(m.$fieldName) {
-		m.$fieldName = m.$fieldName[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
-}
-`,
-	)
-	return g.lastErr
-}
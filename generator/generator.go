@@ -13,10 +13,20 @@ import (
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/desc/protoparse"
 	_ "github.com/jhump/protoreflect/desc/protoparse"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
 )
 
 type Options struct {
 	WithPresence bool
+
+	// MarshalStream is the stream that the generated Marshal methods write to.
+	MarshalStream MarshalStream
+
+	// SinglePassDecode generates decode methods that append the elements of the
+	// repeated fields as they are decoded instead of counting them by a separate
+	// pass over the wire bytes first.
+	SinglePassDecode bool
 }
 
 func Generate(
@@ -32,27 +42,80 @@ func Generate(
 	// Generation options.
 	options Options,
 ) error {
+	for _, inputFilePath := range inputProtoFiles {
+		fmt.Printf("Reading %s\n", inputFilePath)
+
+		fileDescrs, err := parseFile(protoImportPath, inputFilePath)
+		if err != nil {
+			return err
+		}
+
+		// The files are written even if the generation fails, so that the
+		// unformatted code is there to look at.
+		files, genErr := GenerateFiles(fileDescrs, options)
+		for _, file := range files {
+			if err := writeFile(outputDir, file); err != nil {
+				return err
+			}
+		}
+		if genErr != nil {
+			return genErr
+		}
+	}
+	return nil
+}
+
+// OutputFile is a generated Go file.
+type OutputFile struct {
+	// Name of the file. This is a path relative to the output directory.
+	Name string
+
+	// GoImportPath is the Go import path of the package of the file, as specified
+	// by the go_package option. Empty if the option is not specified.
+	GoImportPath string
+
+	// ProtoFile is the name of the proto file that the file is generated from.
+	ProtoFile string
+
+	// Content is the formatted Go source code.
+	Content []byte
+}
+
+// GenerateFiles generates Go code for already parsed proto files. The dependencies
+// of the files must be available via the descriptors, but are not generated.
+// The generated files are returned and are not written anywhere. If the generated
+// code of a file fails to format, the files generated so far and the unformatted
+// file are returned together with the error.
+func GenerateFiles(fileDescrs []*desc.FileDescriptor, options Options) ([]*OutputFile, error) {
 	g := generator{
-		protoImportPath:       protoImportPath,
-		outputDir:             outputDir,
 		options:               options,
 		templateData:          map[string]string{},
 		messageDescrToMessage: map[*desc.MessageDescriptor]*Message{},
 		enumDescrToEnum:       map[*desc.EnumDescriptor]*Enum{},
 	}
 
-	for _, f := range inputProtoFiles {
-		if err := g.processFile(f); err != nil {
-			return err
+	var files []*OutputFile
+	for _, fileDescr := range fileDescrs {
+		file, err := g.processFile(fileDescr)
+		if file != nil {
+			files = append(files, file)
+		}
+		if err != nil {
+			return files, err
+		}
+
+		servicesFile, err := g.processServices(fileDescr)
+		if servicesFile != nil {
+			files = append(files, servicesFile)
+		}
+		if err != nil {
+			return files, err
 		}
 	}
-	return g.lastErr
+	return files, g.lastErr
 }
 
 type generator struct {
-	protoImportPath []string
-	outputDir       string
-
 	options Options
 
 	// Buffer to accumulated generated file.
@@ -61,6 +124,12 @@ type generator struct {
 	// Number of tabs to indent output of the o() method.
 	indentTabs int
 
+	// The current file being generated.
+	file *desc.FileDescriptor
+	// Import names of the Go packages that the current file refers to. Key is
+	// the import path.
+	imports map[string]string
+
 	// List of messages that need to be generated for the current file.
 	messagesToGen []*Message
 	// Map of all message declared in the current file or any of imported files.
@@ -79,70 +148,70 @@ type generator struct {
 	// Fields to replace in templates. Key is the field name, value is the value
 	// replace the key by.
 	templateData map[string]string
-
-	useSizedMarshaler bool
 }
 
-func (g *generator) processFile(inputFilePath string) error {
-	// Parse the input file.
+// parseFile parses the input file and returns the descriptors of the file.
+func parseFile(protoImportPath []string, inputFilePath string) ([]*desc.FileDescriptor, error) {
 	p := protoparse.Parser{
 		// Accessor is used when the parser needs to read an input file.
 		Accessor: func(filename string) (io.ReadCloser, error) {
 			// Try all import paths until we find the requested file.
-			for _, includePath := range g.protoImportPath {
+			for _, includePath := range protoImportPath {
 				f, err := os.Open(path.Join(includePath, filename))
 				if err == nil {
 					return f, nil
 				}
 			}
 			return nil, fmt.Errorf(
-				"file %s not found in paths %v", inputFilePath, g.protoImportPath,
+				"file %s not found in paths %v", inputFilePath, protoImportPath,
 			)
 		},
 		IncludeSourceCodeInfo: true,
 	}
 
-	fmt.Printf("Reading %s\n", inputFilePath)
+	return p.ParseFiles(inputFilePath)
+}
 
-	fileDescrs, err := p.ParseFiles(inputFilePath)
-	if err != nil {
-		return err
-	}
+func (g *generator) processFile(fileDescr *desc.FileDescriptor) (*OutputFile, error) {
+	// Start with a clean list of things to generate for this file.
+	g.messagesToGen = nil
+	g.enumsToGen = nil
 
-	for _, fileDescr := range fileDescrs {
-		if err := g.oStartFile(fileDescr); err != nil {
-			return err
-		}
+	g.file = fileDescr
 
-		// List all enums declared in this file.
-		g.listAllEnums(nil, fileDescr.GetEnumTypes(), true)
+	// List all enums declared in this file.
+	g.listAllEnums(nil, fileDescr.GetEnumTypes(), true)
 
-		// List all messages declared in this file.
-		g.listAllMessages(nil, fileDescr.GetMessageTypes(), true)
+	// List all messages declared in this file.
+	g.listAllMessages(nil, fileDescr.GetMessageTypes(), true)
 
-		// List and remember all messages in imported files, but without generating.
-		// We need these messages to be available for lookup if they are used as a
-		// field type.
-		for _, dep := range fileDescr.GetDependencies() {
-			g.listAllMessages(nil, dep.GetMessageTypes(), false)
-		}
+	// List and remember all messages and enums in imported files, but without
+	// generating. We need these to be available for lookup if they are used as a
+	// field type.
+	g.listDependencies(fileDescr, map[*desc.FileDescriptor]bool{})
 
-		if err := g.oEnums(); err != nil {
-			return err
-		}
+	if err := g.collectImports(); err != nil {
+		return nil, err
+	}
 
-		if err := g.oMessages(); err != nil {
-			return err
-		}
+	if err := g.oStartFile(fileDescr); err != nil {
+		return nil, err
+	}
 
-		// Generation is done. Format the generated code nicely and write it to the
-		// target file.
-		if err := g.formatAndWriteToFile(fileDescr); err != nil {
-			return err
-		}
+	if err := g.oEnums(); err != nil {
+		return nil, err
 	}
 
-	return g.lastErr
+	if err := g.oMessages(); err != nil {
+		return nil, err
+	}
+
+	if err := g.oFileDescriptor(); err != nil {
+		return nil, err
+	}
+
+	// Generation is done. Format the generated code nicely.
+	return g.formatFile(fileDescr)
 }
 
 func (g *generator) oEnums() error {
@@ -169,6 +238,22 @@ func (g *generator) oMessages() error {
 	return g.lastErr
 }
 
+// listDependencies lists the messages and enums of all direct and indirect
+// dependencies of the file.
+func (g *generator) listDependencies(
+	fileDescr *desc.FileDescriptor, visited map[*desc.FileDescriptor]bool,
+) {
+	for _, dep := range fileDescr.GetDependencies() {
+		if visited[dep] {
+			continue
+		}
+		visited[dep] = true
+		g.listAllEnums(nil, dep.GetEnumTypes(), false)
+		g.listAllMessages(nil, dep.GetMessageTypes(), false)
+		g.listDependencies(dep, visited)
+	}
+}
+
 func (g *generator) listAllEnums(
 	parent *Message, enums []*desc.EnumDescriptor, toGen bool,
 ) {
@@ -189,7 +274,9 @@ func (g *generator) listAllMessages(
 	for _, descr := range msgs {
 		msg := NewMessage(parent, descr)
 
-		if toGen {
+		// Map entry messages are not generated as standalone types. Map fields
+		// are represented as Go maps instead.
+		if toGen && !descr.IsMapEntry() {
 			g.messagesToGen = append(g.messagesToGen, msg)
 		}
 		g.messageDescrToMessage[descr] = msg
@@ -202,34 +289,42 @@ func (g *generator) listAllMessages(
 	}
 }
 
-func (g *generator) formatAndWriteToFile(fdescr *desc.FileDescriptor) error {
-	destFileName := path.Base(strings.TrimSuffix(fdescr.GetName(), ".proto")) + ".pb.go"
-	destFileName = path.Join(g.outputDir, destFileName)
-	destDir := path.Dir(destFileName)
-
-	fmt.Printf("Generating %s\n", destFileName)
-
-	if err := os.MkdirAll(destDir, 0700); err != nil {
-		return err
-	}
-
-	var err error
-	f, err := os.Create(destFileName)
-	if err != nil {
-		return err
+func (g *generator) formatFile(fdescr *desc.FileDescriptor) (*OutputFile, error) {
+	file := &OutputFile{
+		Name:         path.Base(strings.TrimSuffix(fdescr.GetName(), ".proto")) + ".pb.go",
+		GoImportPath: fileGoPackage(fdescr).ImportPath,
+		ProtoFile:    fdescr.GetName(),
 	}
+	return g.formatOutputFile(file)
+}
 
+// formatOutputFile formats the code accumulated in the output buffer and stores
+// it in the file.
+func (g *generator) formatOutputFile(file *OutputFile) (*OutputFile, error) {
 	// Nicely format the generated Go code.
 	goCode, err := format.Source(g.outBuf.Bytes())
 	if err != nil {
-		// Write unformatted code to have something to look at.
-		f.Write(g.outBuf.Bytes())
+		// Return unformatted code to have something to look at.
+		file.Content = g.outBuf.Bytes()
 		// But still return an error.
+		return file, err
+	}
+
+	file.Content = goCode
+	return file, g.lastErr
+}
+
+func writeFile(outputDir string, file *OutputFile) error {
+	destFileName := path.Join(outputDir, file.Name)
+	destDir := path.Dir(destFileName)
+
+	fmt.Printf("Generating %s\n", destFileName)
+
+	if err := os.MkdirAll(destDir, 0700); err != nil {
 		return err
 	}
 
-	_, err = f.Write(goCode)
-	return err
+	return os.WriteFile(destFileName, file.Content, 0600)
 }
 
 func (g *generator) oStartFile(fdescr *desc.FileDescriptor) error {
@@ -242,40 +337,65 @@ func (g *generator) oStartFile(fdescr *desc.FileDescriptor) error {
 `, fdescr.GetName(),
 	)
 
-	packagePath := strings.Split(fdescr.GetPackage(), ".")
-
-	if len(packagePath) > 0 {
-		packageName := packagePath[len(packagePath)-1]
-		g.o(`package %s`, packageName)
-		g.o(``)
-	}
+	g.o(`package %s`, fileGoPackage(fdescr).Name)
+	g.o(``)
 
 	g.o(
 		`
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"unsafe"
 
 	"github.com/tigrannajaryan/exp-lazyproto"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/protomessage"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/oneof"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/oneof"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/jsonstream"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/textstream"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/lazyreflect"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protoconv"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/codec"
+`,
+	)
+
+	if stream := g.marshalStream(); stream.pkg != "molecule" {
+		g.o(`	"%s"`, stream.importPath)
+	}
+
+	g.oImports()
 
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule/src/codec"
+	g.o(
+		`
 )
 
 var _ = oneof.OneOf{} // To avoid unused import warning.
 var _ = unsafe.Pointer(nil) // To avoid unused import warning.
 var _ = fmt.Errorf // To avoid unused import warning.
+var _ = bytes.Equal // To avoid unused import warning.
+var _ = sort.SliceStable // To avoid unused import warning.
+var _ = math.Inf // To avoid unused import warning.
+var _ = jsonstream.NewWriter // To avoid unused import warning.
+var _ = textstream.NewWriter // To avoid unused import warning.
+var _ = lazyreflect.RegisterFile // To avoid unused import warning.
+var _ = protoreflect.ValueOf // To avoid unused import warning.
+var _ = protoconv.Marshal // To avoid unused import warning.
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protomessage.EnforceVersion(%[1]d - protomessage.MinVersion)
+	// Verify that the runtime is sufficiently up-to-date.
+	_ = protomessage.EnforceVersion(protomessage.MaxVersion - %[1]d)
+)
 
-`,
+`, protomessage.GenVersion,
 	)
 
-	if g.useSizedMarshaler {
-		g.o(`import "github.com/tigrannajaryan/exp-lazyproto/internal/streams/sizedstream"`)
-	}
-
 	return g.lastErr
 }
 
@@ -284,7 +404,7 @@ func (g *generator) prepareMessage() error {
 	// Prepare "decoded" flags.
 	for _, field := range g.msg.Fields {
 		maskVal := uint64(1) << g.msg.FlagsBitCount
-		if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if isMessageField(field) {
 			// We need a flag for this field since it is an embedded message.
 
 			// Create a name for the flag.
@@ -310,7 +430,7 @@ func (g *generator) prepareMessage() error {
 	for _, field := range g.msg.Fields {
 		g.setField(field)
 
-		if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if isMessageField(field) {
 			// Embedded messages don't need a bit for presence since they use
 			// non-nil pointer value to indicate presence instead.
 			continue
@@ -324,7 +444,7 @@ func (g *generator) prepareMessage() error {
 
 		maskVal := uint64(1) << g.msg.FlagsBitCount
 
-		if g.options.WithPresence {
+		if g.options.WithPresence || hasExplicitPresence(field) {
 			// Create a name for the flag.
 			flagName := fmt.Sprintf(
 				"flags_%s_%s_Present", g.msg.GetName(), field.GetCapitalName(),
@@ -380,11 +500,23 @@ func (g *generator) setField(field *Field) {
 	fieldMessage := g.messageDescrToMessage[field.GetMessageType()]
 
 	if fieldMessage != nil {
-		g.templateData["$fieldTypeMessagePool"] = getPoolName(fieldMessage.GetName())
-		g.templateData["$FieldMessageTypeName"] = fieldMessage.GetName()
+		refs := g.messageRefs(fieldMessage)
+		g.templateData["$fieldTypeMessagePool"] = refs.Pool
+		g.templateData["$FieldMessageTypeName"] = refs.TypeName
+		g.templateData["$fieldTypeProtoMessage"] = refs.ProtoMessage
+		g.templateData["$fieldTypeDecode"] = refs.Decode
+		g.templateData["$fieldTypeCloneInto"] = refs.CloneInto
+		g.templateData["$fieldTypeValidate"] = refs.Validate
+		g.templateData["$fieldTypeMarshalJSONUndecoded"] = refs.MarshalJSONUndecoded
+		g.templateData["$fieldTypeMarshalTextUndecoded"] = refs.MarshalTextUndecoded
 	} else {
-		g.templateData["$fieldTypeMessagePool"] = "$fieldTypeMessagePool not defined for " + field.GetName()
-		g.templateData["$FieldMessageTypeName"] = "$FieldMessageTypeName not defined for " + field.GetName()
+		for _, k := range []string{
+			"$fieldTypeMessagePool", "$FieldMessageTypeName", "$fieldTypeProtoMessage",
+			"$fieldTypeDecode", "$fieldTypeCloneInto", "$fieldTypeValidate",
+			"$fieldTypeMarshalJSONUndecoded", "$fieldTypeMarshalTextUndecoded",
+		} {
+			g.templateData[k] = k + " not defined for " + field.GetName()
+		}
 	}
 }
 
@@ -428,6 +560,11 @@ func (g *generator) i(ofs int) {
 func (g *generator) convertTypeToGo(field *Field) string {
 	var s string
 
+	if field.IsMap() {
+		_, key, value := g.mapEntry(field)
+		return "map[" + g.convertTypeToGo(key) + "]" + g.convertTypeToGo(value)
+	}
+
 	if field.IsRepeated() {
 		s = "[]"
 	}
@@ -441,6 +578,7 @@ func (g *generator) convertTypeToGo(field *Field) string {
 		s += "uint64"
 
 	case descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
 		descriptor.FieldDescriptorProto_TYPE_INT64:
 		s += "int64"
 
@@ -448,7 +586,8 @@ func (g *generator) convertTypeToGo(field *Field) string {
 		s += "uint32"
 
 	case descriptor.FieldDescriptorProto_TYPE_SFIXED32,
-		descriptor.FieldDescriptorProto_TYPE_SINT32:
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_INT32:
 		s += "int32"
 
 	case descriptor.FieldDescriptorProto_TYPE_UINT32:
@@ -456,14 +595,17 @@ func (g *generator) convertTypeToGo(field *Field) string {
 
 	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
 		s += "float64"
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		s += "float32"
 	case descriptor.FieldDescriptorProto_TYPE_STRING:
 		s += "string"
 	case descriptor.FieldDescriptorProto_TYPE_BYTES:
 		s += "[]byte"
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		s += "*" + g.messageDescrToMessage[field.GetMessageType()].GetName()
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		s += "*" + g.messageRefs(g.messageDescrToMessage[field.GetMessageType()]).TypeName
 	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		s += g.enumDescrToEnum[field.GetEnumType()].GetName()
+		s += g.enumTypeName(field.GetEnumType())
 	default:
 		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
 	}
@@ -485,6 +627,10 @@ func (g *generator) oMessage() error {
 		return err
 	}
 
+	if err := g.oNewFuncs(); err != nil {
+		return err
+	}
+
 	if err := g.oUnmarshalFunc(); err != nil {
 		return err
 	}
@@ -493,6 +639,14 @@ func (g *generator) oMessage() error {
 		return err
 	}
 
+	if err := g.oCloneMethod(); err != nil {
+		return err
+	}
+
+	if err := g.oEqualMethod(); err != nil {
+		return err
+	}
+
 	if err := g.oOneOfFields(); err != nil {
 		return err
 	}
@@ -513,14 +667,42 @@ func (g *generator) oMessage() error {
 		return err
 	}
 
+	if err := g.oSizeMethod(); err != nil {
+		return err
+	}
+
 	if err := g.oMarshalMethod(); err != nil {
 		return err
 	}
 
+	if err := g.oJSONMethods(); err != nil {
+		return err
+	}
+
+	if err := g.oTextMethods(); err != nil {
+		return err
+	}
+
+	if err := g.oReflectMethods(); err != nil {
+		return err
+	}
+
+	if err := g.oProtoConvMethods(); err != nil {
+		return err
+	}
+
+	if err := g.oSliceType(); err != nil {
+		return err
+	}
+
 	if err := g.oPool(); err != nil {
 		return err
 	}
 
+	if err := g.oCrossPackageMembers(); err != nil {
+		return err
+	}
+
 	g.o(``)
 
 	return nil
@@ -533,7 +715,8 @@ func (g *generator) oComment(comment string) {
 
 	lines := strings.Split(comment, "\n")
 	for _, line := range lines {
-		g.o(`// %s`, line)
+		// The lines of proto comments start with the space that follows "//".
+		g.o(`// %s`, strings.TrimPrefix(line, " "))
 	}
 }
 
@@ -548,6 +731,7 @@ func (g *generator) oMessageStruct() error {
 	if g.msg.FlagsBitCount > 0 {
 		g.o(`_flags %s`, g.msg.FlagsTypeAlias)
 	}
+	g.o(`_unknownFields protomessage.UnknownFields`)
 	g.o(``)
 
 	// Add fields to the struct.
@@ -564,6 +748,12 @@ func (g *generator) oMessageStruct() error {
 		}
 		g.oComment(comment)
 		g.o(`$fieldName %s`, g.convertTypeToGo(field))
+		if field.IsMap() {
+			// The bytes that contain the map entries. Decoded on first access.
+			g.o(`$fieldNameRaw protomessage.BytesView`)
+			// The first error of decoding the entries. Returned by the E accessors.
+			g.o(`$fieldNameDecodeErr error`)
+		}
 		first = false
 	}
 
@@ -712,6 +902,18 @@ func (g *generator) calcOneOfFieldIndex() int {
 	return fieldIdx
 }
 
+// isMessageField returns true if the field is an embedded message. Groups are
+// represented the same way as embedded messages and differ only in the encoding.
+func isMessageField(field *Field) bool {
+	return field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE ||
+		field.GetType() == descriptor.FieldDescriptorProto_TYPE_GROUP
+}
+
+// isGroupField returns true if the field is a proto2 group.
+func isGroupField(field *Field) bool {
+	return field.GetType() == descriptor.FieldDescriptorProto_TYPE_GROUP
+}
+
 // Returns true if the current field belongs to a oneof field.
 func (g *generator) isOneOfField() bool {
 	return g.calcOneOfFieldIndex() >= 0
@@ -743,6 +945,8 @@ func (g *generator) oEnumDecl(enum *Enum) error {
 	g.o(`)`) // const
 	g.o(``)
 
+	g.oEnumNameMaps(enum)
+
 	return g.lastErr
 }
 
@@ -752,7 +956,26 @@ func (g *generator) oFreeMethod() error {
 func (m *$MessageName) Free() {
 	$messagePool.Release(m)
 }
+
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *$MessageName) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *$MessageName) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
 `,
 	)
+	if g.options.MarshalStream == MoleculeStream {
+		g.o(`var _ lazyproto.Message = (*$MessageName)(nil)`)
+		g.o(``)
+	}
 	return g.lastErr
 }
@@ -0,0 +1,526 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+)
+
+// processServices generates the gRPC service stubs for the services declared in
+// the file. The stubs are equivalent to the ones generated by protoc-gen-go-grpc,
+// but use the lazyproto messages and codec. Returns nil if the file does not
+// declare any services.
+func (g *generator) processServices(fileDescr *desc.FileDescriptor) (*OutputFile, error) {
+	if len(fileDescr.GetServices()) == 0 {
+		return nil, nil
+	}
+	if g.options.MarshalStream != MoleculeStream {
+		return nil, fmt.Errorf(
+			"%s: gRPC services require the molecule marshal stream", fileDescr.GetName(),
+		)
+	}
+
+	g.file = fileDescr
+
+	if err := g.collectServiceImports(); err != nil {
+		return nil, err
+	}
+
+	if err := g.oStartServicesFile(fileDescr); err != nil {
+		return nil, err
+	}
+
+	for _, service := range fileDescr.GetServices() {
+		if err := g.oService(service); err != nil {
+			return nil, err
+		}
+	}
+
+	file := &OutputFile{
+		Name:         path.Base(strings.TrimSuffix(fileDescr.GetName(), ".proto")) + "_grpc.pb.go",
+		GoImportPath: fileGoPackage(fileDescr).ImportPath,
+		ProtoFile:    fileDescr.GetName(),
+	}
+	return g.formatOutputFile(file)
+}
+
+// collectServiceImports finds the Go packages of the request and response
+// messages of the methods of the services declared in the current file.
+func (g *generator) collectServiceImports() error {
+	g.imports = map[string]string{}
+	usedNames := map[string]bool{}
+
+	for _, service := range g.file.GetServices() {
+		for _, method := range service.GetMethods() {
+			for _, msgDescr := range []*desc.MessageDescriptor{
+				method.GetInputType(), method.GetOutputType(),
+			} {
+				if err := g.addImport(msgDescr.GetFile(), usedNames); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) oStartServicesFile(fdescr *desc.FileDescriptor) error {
+	g.outBuf = bytes.NewBuffer(nil)
+
+	g.o(
+		`
+// Code generated by lazyproto. DO NOT EDIT.
+// source: %s
+`, fdescr.GetName(),
+	)
+
+	g.o(`package %s`, fileGoPackage(fdescr).Name)
+	g.o(``)
+
+	g.o(
+		`
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/grpccodec"
+`,
+	)
+
+	g.oImports()
+
+	g.o(
+		`
+)
+
+var _ = context.Background // To avoid unused import warning.
+var _ = codes.Unimplemented // To avoid unused import warning.
+var _ = status.Errorf // To avoid unused import warning.
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion9
+
+`,
+	)
+
+	return g.lastErr
+}
+
+// serviceMethod is a method of the service being generated.
+type serviceMethod struct {
+	*desc.MethodDescriptor
+
+	// Name is the Go name of the method.
+	Name string
+
+	// FullMethodName is the name of the const that holds the full gRPC name of
+	// the method.
+	FullMethodName string
+
+	// InputType and OutputType are the Go names of the request and response
+	// message types.
+	InputType  string
+	OutputType string
+
+	// NewInput and NewOutput are the constructors of the request and response
+	// messages.
+	NewInput  string
+	NewOutput string
+
+	// HandlerName is the name of the func that handles the method on the server.
+	HandlerName string
+
+	// StreamIndex is the index of the method in the Streams of the ServiceDesc.
+	// Only set for streaming methods.
+	StreamIndex int
+}
+
+func (m *serviceMethod) isStreaming() bool {
+	return m.IsClientStreaming() || m.IsServerStreaming()
+}
+
+// clientSignature returns the signature of the method of the client interface.
+func (m *serviceMethod) clientSignature() string {
+	switch {
+	case m.IsClientStreaming() && m.IsServerStreaming():
+		return fmt.Sprintf(
+			"%s(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[%s, %s], error)",
+			m.Name, m.InputType, m.OutputType,
+		)
+	case m.IsClientStreaming():
+		return fmt.Sprintf(
+			"%s(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[%s, %s], error)",
+			m.Name, m.InputType, m.OutputType,
+		)
+	case m.IsServerStreaming():
+		return fmt.Sprintf(
+			"%s(ctx context.Context, in *%s, opts ...grpc.CallOption) (grpc.ServerStreamingClient[%s], error)",
+			m.Name, m.InputType, m.OutputType,
+		)
+	default:
+		return fmt.Sprintf(
+			"%s(ctx context.Context, in *%s, opts ...grpc.CallOption) (*%s, error)",
+			m.Name, m.InputType, m.OutputType,
+		)
+	}
+}
+
+// serverSignature returns the signature of the method of the server interface.
+func (m *serviceMethod) serverSignature() string {
+	switch {
+	case m.IsClientStreaming() && m.IsServerStreaming():
+		return fmt.Sprintf(
+			"%s(grpc.BidiStreamingServer[%s, %s]) error", m.Name, m.InputType, m.OutputType,
+		)
+	case m.IsClientStreaming():
+		return fmt.Sprintf(
+			"%s(grpc.ClientStreamingServer[%s, %s]) error", m.Name, m.InputType, m.OutputType,
+		)
+	case m.IsServerStreaming():
+		return fmt.Sprintf(
+			"%s(*%s, grpc.ServerStreamingServer[%s]) error", m.Name, m.InputType, m.OutputType,
+		)
+	default:
+		return fmt.Sprintf(
+			"%s(context.Context, *%s) (*%s, error)", m.Name, m.InputType, m.OutputType,
+		)
+	}
+}
+
+func (g *generator) serviceMethods(service *desc.ServiceDescriptor) []*serviceMethod {
+	serviceName := capitalCamelCase(camelCase(service.GetName()))
+
+	var methods []*serviceMethod
+	streamIndex := 0
+	for _, methodDescr := range service.GetMethods() {
+		input := g.messageDescrToMessage[methodDescr.GetInputType()]
+		output := g.messageDescrToMessage[methodDescr.GetOutputType()]
+		method := &serviceMethod{
+			MethodDescriptor: methodDescr,
+			Name:             capitalCamelCase(camelCase(methodDescr.GetName())),
+			InputType:        g.messageRefs(input).TypeName,
+			OutputType:       g.messageRefs(output).TypeName,
+			NewInput:         g.qualifiedName(input.GetFile(), "New"+input.GetName()),
+			NewOutput:        g.qualifiedName(output.GetFile(), "New"+output.GetName()),
+		}
+		method.FullMethodName = serviceName + "_" + method.Name + "_FullMethodName"
+		method.HandlerName = "_" + serviceName + "_" + method.Name + "_Handler"
+		if method.isStreaming() {
+			method.StreamIndex = streamIndex
+			streamIndex++
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+func (g *generator) oService(service *desc.ServiceDescriptor) error {
+	serviceName := capitalCamelCase(camelCase(service.GetName()))
+	g.templateData["$ServiceName"] = serviceName
+	g.templateData["$serviceClient"] = unexportedName(serviceName) + "Client"
+
+	methods := g.serviceMethods(service)
+
+	g.o(`const (`)
+	for _, method := range methods {
+		g.o(
+			`	%s = "/%s/%s"`, method.FullMethodName, service.GetFullyQualifiedName(),
+			method.GetName(),
+		)
+	}
+	g.o(`)`)
+	g.o(``)
+
+	g.oServiceClient(service, methods)
+	g.oServiceServer(service, methods)
+	g.oServiceDesc(service, methods)
+
+	return g.lastErr
+}
+
+// oServiceComment appends the comment of the service to the doc comment of the
+// client and server interfaces.
+func (g *generator) oServiceComment(service *desc.ServiceDescriptor) {
+	comment := getLeadingComment(service.GetSourceInfo())
+	if comment != "" {
+		g.o(`//`)
+		g.oComment(comment)
+	}
+}
+
+func (g *generator) oServiceClient(service *desc.ServiceDescriptor, methods []*serviceMethod) {
+	g.o(
+		`
+// $ServiceNameClient is the client API for $ServiceName service.
+//
+// The client uses the lazyproto codec to marshal the requests and to unmarshal
+// the responses, unless a different codec is forced by the call options.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to
+// https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.`,
+	)
+	g.oServiceComment(service)
+	g.o(`type $ServiceNameClient interface {`)
+	for _, method := range methods {
+		g.i(1)
+		g.oComment(getLeadingComment(method.GetSourceInfo()))
+		g.o(`%s`, method.clientSignature())
+		g.i(-1)
+	}
+	g.o(
+		`}
+
+type $serviceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func New$ServiceNameClient(cc grpc.ClientConnInterface) $ServiceNameClient {
+	return &$serviceClient{cc}
+}
+`,
+	)
+
+	for _, method := range methods {
+		g.templateData["$MethodName"] = method.Name
+		g.templateData["$FullMethodName"] = method.FullMethodName
+		g.templateData["$InputType"] = method.InputType
+		g.templateData["$OutputType"] = method.OutputType
+
+		g.o(
+			`
+func (c *$serviceClient) %s {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod(), grpc.ForceCodecV2(grpccodec.CodecV2{})}, opts...)`,
+			method.clientSignature(),
+		)
+
+		if !method.isStreaming() {
+			g.o(
+				`
+	out := %s()
+	err := c.cc.Invoke(ctx, $FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+`, method.NewOutput,
+			)
+			continue
+		}
+
+		g.o(
+			`
+	stream, err := c.cc.NewStream(ctx, &$ServiceName_ServiceDesc.Streams[%d], $FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[$InputType, $OutputType]{ClientStream: stream}`,
+			method.StreamIndex,
+		)
+		if !method.IsClientStreaming() {
+			g.o(
+				`
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}`,
+			)
+		}
+		g.o(
+			`
+	return x, nil
+}
+`,
+		)
+	}
+}
+
+func (g *generator) oServiceServer(service *desc.ServiceDescriptor, methods []*serviceMethod) {
+	g.o(
+		`
+// $ServiceNameServer is the server API for $ServiceName service.
+// All implementations must embed Unimplemented$ServiceNameServer
+// for forward compatibility.`,
+	)
+	g.oServiceComment(service)
+	g.o(`type $ServiceNameServer interface {`)
+	for _, method := range methods {
+		g.i(1)
+		g.oComment(getLeadingComment(method.GetSourceInfo()))
+		g.o(`%s`, method.serverSignature())
+		g.i(-1)
+	}
+	g.o(
+		`	mustEmbedUnimplemented$ServiceNameServer()
+}
+
+// Unimplemented$ServiceNameServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type Unimplemented$ServiceNameServer struct{}
+`,
+	)
+
+	for _, method := range methods {
+		// Streaming methods return only an error.
+		results := "nil, "
+		if method.isStreaming() {
+			results = ""
+		}
+		g.o(
+			`
+func (Unimplemented$ServiceNameServer) %s {
+	return %sstatus.Errorf(codes.Unimplemented, "method %s not implemented")
+}`,
+			method.serverSignature(), results, method.Name,
+		)
+	}
+
+	g.o(
+		`
+func (Unimplemented$ServiceNameServer) mustEmbedUnimplemented$ServiceNameServer() {}
+func (Unimplemented$ServiceNameServer) testEmbeddedByValue() {}
+
+// Unsafe$ServiceNameServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to $ServiceNameServer will
+// result in compilation errors.
+type Unsafe$ServiceNameServer interface {
+	mustEmbedUnimplemented$ServiceNameServer()
+}
+
+// Register$ServiceNameServer registers the implementation of $ServiceName service.
+// The server must use the lazyproto codec to unmarshal the requests into the
+// lazyproto messages efficiently, i.e. it must be created with the
+// grpc.ForceServerCodecV2(grpccodec.CodecV2{}) option or grpccodec.Register()
+// must be called during initialization.
+func Register$ServiceNameServer(s grpc.ServiceRegistrar, srv $ServiceNameServer) {
+	// If the following call panics, it indicates Unimplemented$ServiceNameServer was
+	// embedded by pointer and is nil. This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&$ServiceName_ServiceDesc, srv)
+}
+`,
+	)
+
+	for _, method := range methods {
+		g.templateData["$MethodName"] = method.Name
+		g.templateData["$FullMethodName"] = method.FullMethodName
+		g.templateData["$InputType"] = method.InputType
+		g.templateData["$OutputType"] = method.OutputType
+
+		switch {
+		case !method.isStreaming():
+			g.o(
+				`
+func %s(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := %s()
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.($ServiceNameServer).$MethodName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: $FullMethodName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.($ServiceNameServer).$MethodName(ctx, req.(*$InputType))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+`, method.HandlerName, method.NewInput,
+			)
+		case !method.IsClientStreaming():
+			g.o(
+				`
+func %s(srv any, stream grpc.ServerStream) error {
+	m := %s()
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.($ServiceNameServer).$MethodName(m, &grpc.GenericServerStream[$InputType, $OutputType]{ServerStream: stream})
+}
+`, method.HandlerName, method.NewInput,
+			)
+		default:
+			g.o(
+				`
+func %s(srv any, stream grpc.ServerStream) error {
+	return srv.($ServiceNameServer).$MethodName(&grpc.GenericServerStream[$InputType, $OutputType]{ServerStream: stream})
+}
+`, method.HandlerName,
+			)
+		}
+	}
+}
+
+func (g *generator) oServiceDesc(service *desc.ServiceDescriptor, methods []*serviceMethod) {
+	g.o(
+		`
+// $ServiceName_ServiceDesc is the grpc.ServiceDesc for $ServiceName service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var $ServiceName_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: %q,
+	HandlerType: (*$ServiceNameServer)(nil),
+	Methods: []grpc.MethodDesc{`, service.GetFullyQualifiedName(),
+	)
+	for _, method := range methods {
+		if method.isStreaming() {
+			continue
+		}
+		g.o(
+			`
+		{
+			MethodName: %q,
+			Handler:    %s,
+		},`, method.GetName(), method.HandlerName,
+		)
+	}
+	g.o(
+		`
+	},
+	Streams: []grpc.StreamDesc{`,
+	)
+	for _, method := range methods {
+		if !method.isStreaming() {
+			continue
+		}
+		g.o(
+			`
+		{
+			StreamName:    %q,
+			Handler:       %s,`, method.GetName(), method.HandlerName,
+		)
+		if method.IsServerStreaming() {
+			g.o(`			ServerStreams: true,`)
+		}
+		if method.IsClientStreaming() {
+			g.o(`			ClientStreams: true,`)
+		}
+		g.o(`		},`)
+	}
+	g.o(
+		`
+	},
+	Metadata: %q,
+}
+`, g.file.GetName(),
+	)
+}
@@ -0,0 +1,627 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The JSON representation follows the proto3 JSON mapping and is the same as
+// produced by protojson (apart from the whitespace). The messages are written
+// without populating the lazy message tree: the nested messages that are not
+// decoded yet are decoded into temporary structs taken from the pools and the
+// structs are returned to the pools right after they are written.
+
+// streamTypeMethod maps the proto type to the name of the jsonstream.Writer and
+// textstream.Writer methods that write the value of the type. The Reader methods
+// that read the value have the same name with "Read" prefix.
+var streamTypeMethod = map[descriptor.FieldDescriptorProto_Type]string{
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     "Bool",
+	descriptor.FieldDescriptorProto_TYPE_INT32:    "Int32",
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   "Int32",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: "Int32",
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   "Uint32",
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  "Uint32",
+	descriptor.FieldDescriptorProto_TYPE_INT64:    "Int64",
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   "Int64",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: "Int64",
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   "Uint64",
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  "Uint64",
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    "Float",
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   "Double",
+	descriptor.FieldDescriptorProto_TYPE_STRING:   "String",
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    "Bytes",
+}
+
+// jsonFieldNames returns the quoted names by which the field may appear in JSON:
+// the lowerCamelCase name (or the json_name option) and the original proto name.
+// The first name is the one that is used for output.
+func jsonFieldNames(field *Field) []string {
+	jsonName := field.GetJSONName()
+	protoName := field.FieldDescriptor.GetName()
+	if isGroupField(field) {
+		// The proto name of a group is the name of the group type.
+		protoName = field.GetMessageType().GetName()
+	}
+	if jsonName == protoName {
+		return []string{fmt.Sprintf("%q", jsonName)}
+	}
+	return []string{fmt.Sprintf("%q", jsonName), fmt.Sprintf("%q", protoName)}
+}
+
+// enumNamesVar returns the name of the var that maps the enum values to the names.
+func (g *generator) enumNamesVar(field *Field) string {
+	descr := field.GetEnumType()
+	return g.qualifiedName(descr.GetFile(), g.enumDescrToEnum[descr].GetName()+"_name")
+}
+
+// enumValuesVar returns the name of the var that maps the enum names to the values.
+func (g *generator) enumValuesVar(field *Field) string {
+	descr := field.GetEnumType()
+	return g.qualifiedName(descr.GetFile(), g.enumDescrToEnum[descr].GetName()+"_value")
+}
+
+// oEnumNameMaps generates the maps between the values and the names of the enum.
+func (g *generator) oEnumNameMaps(enum *Enum) {
+	g.o(`// %[1]s_name maps the values of %[1]s to the names of the values.`, enum.GetName())
+	g.o(`var %s_name = map[uint32]string{`, enum.GetName())
+	seen := map[int32]bool{}
+	for _, value := range enum.GetValues() {
+		// Aliases have the same number, the first declared name is used for output.
+		if seen[value.GetNumber()] {
+			continue
+		}
+		seen[value.GetNumber()] = true
+		g.o(`	%d: %q,`, value.GetNumber(), value.GetName())
+	}
+	g.o(`}`)
+	g.o(``)
+
+	g.o(`// %[1]s_value maps the names of the values of %[1]s to the values.`, enum.GetName())
+	g.o(`var %s_value = map[string]uint32{`, enum.GetName())
+	for _, value := range enum.GetValues() {
+		g.o(`	%q: %d,`, value.GetName(), value.GetNumber())
+	}
+	g.o(`}`)
+	g.o(``)
+}
+
+func (g *generator) oJSONMethods() error {
+	g.o(
+		`
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *$MessageName) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *$MessageName) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *$MessageName) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := $messagePool.Get()
+	defer $messagePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+`,
+	)
+
+	g.oMarshalJSONStream()
+	g.oUnmarshalJSONStream()
+
+	return g.lastErr
+}
+
+func (g *generator) oMarshalJSONStream() {
+	g.o(`// MarshalJSONStream writes the JSON representation of the message to js.`)
+	g.o(`func (m *$MessageName) MarshalJSONStream(js *jsonstream.Writer) error {`)
+	g.i(1)
+	g.o(`js.BeginObject()`)
+
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+
+		switch {
+		case field.IsMap():
+			g.oMarshalJSONMapField()
+
+		case field.GetOneOf() != nil:
+			if g.calcOneOfFieldIndex() == 0 {
+				// We generate all oneof cases when we see the first field. Skip for the rest.
+				g.oMarshalJSONOneofField()
+			}
+
+		case field.IsRepeated():
+			g.o(`if len(m.$fieldName) > 0 {`)
+			g.o(`	js.Name(%s)`, jsonFieldNames(field)[0])
+			g.o(`	js.BeginArray()`)
+			g.o(`	for _, elem := range m.$fieldName {`)
+			g.i(2)
+			if isMessageField(field) {
+				g.oMarshalJSONMessage("elem")
+			} else {
+				g.oMarshalJSONValue(field, "elem")
+			}
+			g.i(-2)
+			g.o(`	}`)
+			g.o(`	js.EndArray()`)
+			g.o(`}`)
+
+		case isMessageField(field):
+			g.o(`if m.$fieldName != nil {`)
+			g.o(`	js.Name(%s)`, jsonFieldNames(field)[0])
+			g.i(1)
+			g.oMarshalJSONMessage("m." + field.GetName())
+			g.i(-1)
+			g.o(`}`)
+
+		default:
+			if flagName, ok := g.msg.PresenceFlagName[field]; ok && hasExplicitPresence(field) {
+				g.o(`if m._flags&%s != 0 {`, flagName)
+			} else {
+				g.o(`if %s {`, nonZeroValueCheck(field, "m."+field.GetName()))
+			}
+			g.o(`	js.Name(%s)`, jsonFieldNames(field)[0])
+			g.i(1)
+			g.oMarshalJSONValue(field, "m."+field.GetName())
+			g.i(-1)
+			g.o(`}`)
+		}
+	}
+
+	g.o(`js.EndObject()`)
+	g.o(`return nil`)
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+}
+
+// nonZeroValueCheck returns a Go expression that is true if the value of the
+// non-message field is not the zero value of its type. Negative zero floats
+// are not zero values, the same way as protojson treats them.
+func nonZeroValueCheck(field *Field, value string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return value
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return value + ` != ""`
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "len(" + value + ") > 0"
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "math.Float64bits(" + value + ") != 0"
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "math.Float32bits(" + value + ") != 0"
+	default:
+		return value + " != 0"
+	}
+}
+
+// oMarshalJSONValue generates code that writes the non-message value of the field.
+func (g *generator) oMarshalJSONValue(field *Field, value string) {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		g.o(`js.Enum(uint32(%s), %s)`, value, g.enumNamesVar(field))
+		return
+	}
+	method, ok := streamTypeMethod[field.GetType()]
+	if !ok {
+		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+		return
+	}
+	g.o(`js.%s(%s)`, method, value)
+}
+
+// oMarshalJSONMessage generates code that writes the embedded message of the
+// current field, which is stored in the specified variable.
+func (g *generator) oMarshalJSONMessage(varName string) {
+	g.o(
+		`
+if m._flags&%[1]s != 0 {
+	if err := %[2]s.MarshalJSONStream(js); err != nil {
+		return err
+	}
+} else if err := %[2]s.$fieldTypeMarshalJSONUndecoded(js); err != nil {
+	return err
+}`, g.msg.DecodedFlagName[g.field], varName,
+	)
+}
+
+func (g *generator) oMarshalJSONOneofField() {
+	oneofName := g.field.GetOneOf().GetName()
+	typeName := composeOneOfAliasTypeName(g.msg, g.field.GetOneOf())
+
+	g.o(`switch %s(m.%s.FieldIndex()) {`, typeName, oneofName)
+	for _, choice := range g.field.GetOneOf().GetChoices() {
+		choiceField := g.msg.FieldsMap[choice.GetName()]
+		g.setField(choiceField)
+		g.o(`case %s:`, composeOneOfChoiceName(g.msg, choiceField))
+		g.i(1)
+		if isMessageField(choiceField) {
+			g.o(`if ptr := (*$FieldMessageTypeName)(m.%s.PtrVal()); ptr != nil {`, oneofName)
+			g.o(`	js.Name(%s)`, jsonFieldNames(choiceField)[0])
+			g.i(1)
+			g.oMarshalJSONMessage("ptr")
+			g.i(-1)
+			g.o(`}`)
+		} else {
+			g.o(`js.Name(%s)`, jsonFieldNames(choiceField)[0])
+			g.oMarshalJSONValue(choiceField, g.marshalValueExpr())
+		}
+		g.i(-1)
+	}
+	g.o(`}`)
+}
+
+// jsonMapKeyName returns the Go statement that writes the map key k as the name
+// of the JSON object member.
+func jsonMapKeyName(key *Field) string {
+	switch streamTypeMethod[key.GetType()] {
+	case "Bool":
+		return "js.BoolName(k)"
+	case "Int32", "Int64":
+		return "js.Int64Name(int64(k))"
+	case "Uint32", "Uint64":
+		return "js.Uint64Name(uint64(k))"
+	default:
+		return "js.Name(k)"
+	}
+}
+
+func (g *generator) oMarshalJSONMapField() {
+	g.setMapField()
+	_, key, value := g.mapEntry(g.field)
+
+	less := "keys[i] < keys[j]"
+	if key.GetType() == descriptor.FieldDescriptorProto_TYPE_BOOL {
+		less = "!keys[i] && keys[j]"
+	}
+
+	g.o(
+		`
+{
+	src := m
+	if m._flags&$mapDecodedFlag == 0 {
+		// Decode the entries into a temporary message, so that this message
+		// remains undecoded.
+		tmp := $messagePool.Get()
+		defer $messagePool.Release(tmp)
+		tmp.$fieldNameRaw = m.$fieldNameRaw
+		tmp.decode$FieldName()
+		src = tmp
+	}
+	if len(src.$fieldName) > 0 {
+		js.Name(%s)
+		js.BeginObject()
+
+		// Write the entries ordered by the key, the same way as protojson does.
+		keys := make([]$MapKeyType, 0, len(src.$fieldName))
+		for k := range src.$fieldName {
+			keys = append(keys, k)
+		}
+		sort.Slice(
+			keys, func(i, j int) bool {
+				return %s
+			},
+		)
+		for _, k := range keys {
+			%s
+			v := src.$fieldName[k]`, jsonFieldNames(g.field)[0], less, jsonMapKeyName(key),
+	)
+
+	g.i(3)
+	if isMessageField(value) {
+		g.o(
+			`
+if v == nil {
+	// The value is an empty message.
+	js.BeginObject()
+	js.EndObject()
+} else if err := v.MarshalJSONStream(js); err != nil {
+	return err
+}`,
+		)
+	} else {
+		g.oMarshalJSONValue(value, "v")
+	}
+	g.i(-3)
+
+	g.o(
+		`
+		}
+		js.EndObject()
+	}
+}`,
+	)
+}
+
+func (g *generator) oUnmarshalJSONStream() {
+	g.o(
+		`
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *$MessageName) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}`,
+	)
+	g.i(1)
+
+	if len(g.msg.DecodedFlags) > 0 {
+		g.o(``)
+		g.o(`// The message is built from JSON, there is nothing to decode from wire bytes.`)
+		var flags string
+		for i, bitDef := range g.msg.DecodedFlags {
+			if i > 0 {
+				flags += " | "
+			}
+			flags += bitDef.flagName
+		}
+		g.o(`m._flags = %s`, flags)
+	}
+
+	g.o(
+		`
+if err := r.BeginObject(); err != nil {
+	return err
+}
+for {
+	name, ok, err := r.NextField()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		break
+	}
+	switch name {`,
+	)
+	g.i(1)
+
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+		g.o(`case %s:`, joinNames(jsonFieldNames(field)))
+		g.i(1)
+		g.o(`if r.ReadNull() {`)
+		g.o(`	// Null is the same as absent field.`)
+		g.o(`	continue`)
+		g.o(`}`)
+
+		switch {
+		case field.IsMap():
+			g.oUnmarshalJSONMapField()
+		case field.GetOneOf() != nil:
+			g.oUnmarshalJSONOneofField()
+		case field.IsRepeated():
+			g.oUnmarshalJSONRepeatedField()
+		case isMessageField(field):
+			g.o(
+				`
+if m.$fieldName != nil {
+	// Duplicate field, the last one wins.
+	$fieldTypeMessagePool.Release(m.$fieldName)
+}
+m.$fieldName = $fieldTypeMessagePool.Get()
+m.$fieldName.$fieldTypeProtoMessage.Parent = &m._protoMessage
+if err := m.$fieldName.UnmarshalJSONStream(r); err != nil {
+	return err
+}`,
+			)
+		default:
+			g.oUnmarshalJSONValue(field, "v")
+			g.o(`m.$fieldName = v`)
+			if flagName, ok := g.msg.PresenceFlagName[field]; ok {
+				g.o(`m._flags |= %s`, flagName)
+			}
+		}
+		g.i(-1)
+	}
+
+	g.o(`default:`)
+	g.o(`	return fmt.Errorf("unknown field %%q in $MessageName", name)`)
+	g.i(-1)
+	g.o(`}`) // switch
+	g.o(`}`) // for
+
+	for _, field := range g.requiredFields() {
+		g.setField(field)
+		if isMessageField(field) {
+			g.o(`if m.$fieldName == nil {`)
+		} else {
+			g.o(`if m._flags&%s == 0 {`, g.msg.PresenceFlagName[field])
+		}
+		g.o(`	return fmt.Errorf("required field $MessageName.$fieldName is missing")`)
+		g.o(`}`)
+	}
+
+	g.o(`return nil`)
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+}
+
+func joinNames(names []string) string {
+	r := names[0]
+	for _, name := range names[1:] {
+		r += ", " + name
+	}
+	return r
+}
+
+// oUnmarshalJSONValue generates code that reads the non-message value of the field
+// into the specified variable.
+func (g *generator) oUnmarshalJSONValue(field *Field, varName string) {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		g.o(
+			`
+ev, err := r.ReadEnum(%s)
+if err != nil {
+	return err
+}
+%s := %s(ev)`, g.enumValuesVar(field), varName, g.enumTypeName(field.GetEnumType()),
+		)
+		return
+	}
+
+	method, ok := streamTypeMethod[field.GetType()]
+	if !ok {
+		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+		return
+	}
+	g.o(
+		`
+%s, err := r.Read%s()
+if err != nil {
+	return err
+}`, varName, method,
+	)
+}
+
+func (g *generator) oUnmarshalJSONOneofField() {
+	oneofName := g.field.GetOneOf().GetName()
+	choiceName := composeOneOfChoiceName(g.msg, g.field)
+
+	g.o(`if m.%s.FieldIndex() != 0 {`, oneofName)
+	g.o(`	return fmt.Errorf("more than one field of oneof %s in $MessageName is set")`, oneofName)
+	g.o(`}`)
+
+	if isMessageField(g.field) {
+		g.o(
+			`
+elem := $fieldTypeMessagePool.Get()
+elem.$fieldTypeProtoMessage.Parent = &m._protoMessage
+m.%s = oneof.NewPtr(unsafe.Pointer(elem), int(%s))
+if err := elem.UnmarshalJSONStream(r); err != nil {
+	return err
+}`, oneofName, choiceName,
+		)
+		return
+	}
+
+	g.oUnmarshalJSONValue(g.field, "v")
+	g.o(
+		"m.%s = oneof.New%s(v, int(%s))", oneofName,
+		primitiveTypeDecode[g.field.GetType()].oneOfType, choiceName,
+	)
+}
+
+func (g *generator) oUnmarshalJSONRepeatedField() {
+	g.o(
+		`
+if err := r.BeginArray(); err != nil {
+	return err
+}`,
+	)
+	if !isMessageField(g.field) {
+		g.o(`// Duplicate field, the last one wins.`)
+		g.o(`m.$fieldName = nil`)
+	}
+	g.o(
+		`
+for {
+	ok, err := r.NextElem()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		break
+	}`,
+	)
+	g.i(1)
+	if isMessageField(g.field) {
+		g.o(
+			`
+elem := $fieldTypeMessagePool.Get()
+elem.$fieldTypeProtoMessage.Parent = &m._protoMessage
+m.$fieldName = append(m.$fieldName, elem)
+if err := elem.UnmarshalJSONStream(r); err != nil {
+	return err
+}`,
+		)
+	} else {
+		g.oUnmarshalJSONValue(g.field, "v")
+		g.o(`m.$fieldName = append(m.$fieldName, v)`)
+	}
+	g.i(-1)
+	g.o(`}`)
+}
+
+func (g *generator) oUnmarshalJSONMapField() {
+	g.setMapField()
+	_, key, value := g.mapEntry(g.field)
+
+	g.o(
+		`
+if err := r.BeginObject(); err != nil {
+	return err
+}
+if m.$fieldName == nil {
+	m.$fieldName = map[$MapKeyType]$MapValueType{}
+}
+for {
+	key, ok, err := r.NextField()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		break
+	}`,
+	)
+	g.i(1)
+
+	if key.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING {
+		g.o(`k := key`)
+	} else {
+		g.o(
+			`
+k, err := jsonstream.Parse%sKey(key)
+if err != nil {
+	return err
+}`, streamTypeMethod[key.GetType()],
+		)
+	}
+
+	if isMessageField(value) {
+		g.o(
+			`
+if old, ok := m.$fieldName[k]; ok && old != nil {
+	// Duplicate key, the last one wins.
+	$mapValuePool.Release(old)
+}
+v := $mapValuePool.Get()
+v.$mapValueProtoMessage.Parent = &m._protoMessage
+m.$fieldName[k] = v
+if err := v.UnmarshalJSONStream(r); err != nil {
+	return err
+}`,
+		)
+	} else {
+		g.oUnmarshalJSONValue(value, "v")
+		g.o(`m.$fieldName[k] = v`)
+	}
+
+	g.i(-1)
+	g.o(`}`)
+}
@@ -0,0 +1,606 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/codec"
+)
+
+// Map fields are represented as Go maps. The entries of the map are decoded on
+// the first access of the map. During decoding of the message we only remember
+// the bytes of the message that contain the entries in the $fieldNameRaw field.
+// The "decoded" bit in _flags indicates whether the entries are already decoded
+// from $fieldNameRaw into the Go map.
+
+// mapEntry returns the synthetic entry message of the map field and the key and
+// value fields of the entry.
+func (g *generator) mapEntry(field *Field) (entry *Message, key *Field, value *Field) {
+	entry = g.messageDescrToMessage[field.GetMessageType()]
+	return entry, entry.FieldsMap["key"], entry.FieldsMap["value"]
+}
+
+// setMapField sets the template data for the current map field.
+func (g *generator) setMapField() {
+	entry, key, value := g.mapEntry(g.field)
+	g.templateData["$MapEntryName"] = entry.GetName()
+	g.templateData["$MapKeyType"] = g.convertTypeToGo(key)
+	g.templateData["$MapValueType"] = g.convertTypeToGo(value)
+	g.templateData["$mapDecodedFlag"] = g.msg.DecodedFlagName[g.field]
+
+	valueMessage := g.messageDescrToMessage[value.GetMessageType()]
+	if valueMessage != nil {
+		refs := g.messageRefs(valueMessage)
+		g.templateData["$mapValuePool"] = refs.Pool
+		g.templateData["$mapValueProtoMessage"] = refs.ProtoMessage
+		g.templateData["$mapValueDecode"] = refs.Decode
+		g.templateData["$mapValueCloneInto"] = refs.CloneInto
+		g.templateData["$mapValueValidate"] = refs.Validate
+	} else {
+		for _, k := range []string{
+			"$mapValuePool", "$mapValueProtoMessage", "$mapValueDecode",
+			"$mapValueCloneInto", "$mapValueValidate",
+		} {
+			g.templateData[k] = k + " not defined for " + g.field.GetName()
+		}
+	}
+}
+
+func (g *generator) oMapFieldAccessors() error {
+	g.setMapField()
+	_, _, value := g.mapEntry(g.field)
+
+	g.o(
+		`
+// $FieldNameLen returns the number of entries in the $fieldName map.
+func (m *$MessageName) $FieldNameLen() int {
+	if m._flags&$mapDecodedFlag == 0 {
+		m.decode$FieldName()
+	}
+	return len(m.$fieldName)
+}
+
+// $FieldNameGet returns the value for the key k in the $fieldName map and true
+// if the key is found.
+func (m *$MessageName) $FieldNameGet(k $MapKeyType) (v $MapValueType, ok bool) {
+	if m._flags&$mapDecodedFlag == 0 {
+		m.decode$FieldName()
+	}
+	v, ok = m.$fieldName[k]
+	return v, ok
+}
+
+// $FieldNameRange calls f for each entry of the $fieldName map. If f returns
+// false the iteration stops. The map must not be modified by f.
+func (m *$MessageName) $FieldNameRange(f func(k $MapKeyType, v $MapValueType) bool) {
+	if m._flags&$mapDecodedFlag == 0 {
+		m.decode$FieldName()
+	}
+	for k, v := range m.$fieldName {
+		if !f(k, v) {
+			break
+		}
+	}
+}
+
+// $FieldNameSet sets the value for the key k in the $fieldName map.
+func (m *$MessageName) $FieldNameSet(k $MapKeyType, v $MapValueType) {
+	if m._flags&$mapDecodedFlag == 0 {
+		m.decode$FieldName()
+	}
+	if m.$fieldName == nil {
+		m.$fieldName = map[$MapKeyType]$MapValueType{}
+	}`,
+	)
+
+	if isMessageField(value) {
+		g.o(
+			`
+	if old, ok := m.$fieldName[k]; ok && old != nil && old != v {
+		// Return the replaced value to the pool.
+		old.Free()
+	}
+
+	// Make sure the value's Parent points to this message.
+	if v != nil {
+		v.$mapValueProtoMessage.Parent = &m._protoMessage
+	}`,
+		)
+	}
+
+	g.o(
+		`
+	m.$fieldName[k] = v
+
+	// Mark this message modified, if not already.
+	m._protoMessage.MarkModified()
+}
+
+// $FieldNameDelete deletes the entry with the key k from the $fieldName map.
+func (m *$MessageName) $FieldNameDelete(k $MapKeyType) {
+	if m._flags&$mapDecodedFlag == 0 {
+		m.decode$FieldName()
+	}
+	v, ok := m.$fieldName[k]
+	if !ok {
+		return
+	}`,
+	)
+
+	if isMessageField(value) {
+		g.o(
+			`
+	if v != nil {
+		// Return the deleted value to the pool.
+		v.Free()
+	}`,
+		)
+	} else {
+		g.o(`	_ = v`)
+	}
+
+	g.o(
+		`
+	delete(m.$fieldName, k)
+
+	// Mark this message modified, if not already.
+	m._protoMessage.MarkModified()
+}
+`,
+	)
+
+	g.oMapFieldAccessorsE(value)
+	g.oMapFieldDecodeMethods()
+
+	return g.lastErr
+}
+
+// oMapFieldAccessorsE generates the map accessors that also return the errors of
+// the lazy decoding.
+func (g *generator) oMapFieldAccessorsE(value *Field) {
+	valueDoc := ""
+	if isMessageField(value) {
+		valueDoc = " or of the returned value or of its nested\n// messages that were accessed so far"
+	}
+	g.o(
+		`
+// $FieldNameGetE is the same as $FieldNameGet, but also returns the first error of
+// the decoding of the $fieldName map entries%s.
+func (m *$MessageName) $FieldNameGetE(k $MapKeyType) (v $MapValueType, ok bool, err error) {
+	v, ok = m.$FieldNameGet(k)
+	if m.$fieldNameDecodeErr != nil {
+		return v, ok, m.$fieldNameDecodeErr
+	}`, valueDoc,
+	)
+	if isMessageField(value) {
+		g.o(`	if v != nil {`)
+		g.o(`		return v, ok, v.DecodeErr()`)
+		g.o(`	}`)
+	}
+	g.o(`	return v, ok, nil`)
+	g.o(`}`)
+
+	if isMessageField(value) {
+		valueDoc = " or of the values or of\n// their nested messages that were accessed so far"
+	}
+	g.o(
+		`
+// $FieldNameRangeE is the same as $FieldNameRange, but also returns the first error
+// of the decoding of the $fieldName map entries%s.
+func (m *$MessageName) $FieldNameRangeE(f func(k $MapKeyType, v $MapValueType) bool) error {
+	m.$FieldNameRange(f)
+	if m.$fieldNameDecodeErr != nil {
+		return m.$fieldNameDecodeErr
+	}`, valueDoc,
+	)
+	if isMessageField(value) {
+		g.o(`	for _, v := range m.$fieldName {`)
+		g.o(`		if v == nil {`)
+		g.o(`			continue`)
+		g.o(`		}`)
+		g.o(`		if err := v.DecodeErr(); err != nil {`)
+		g.o(`			return err`)
+		g.o(`		}`)
+		g.o(`	}`)
+	}
+	g.o(`	return nil`)
+	g.o(`}`)
+	g.o(``)
+}
+
+func (g *generator) oMapFieldDecodeMethods() {
+	_, key, value := g.mapEntry(g.field)
+
+	g.o(
+		`
+// This is noinline, so that the map accessors are inlined instead.
+//go:noinline
+func (m *$MessageName) decode$FieldName() {
+	m._flags |= $mapDecodedFlag
+	m.$fieldNameDecodeErr = nil
+
+	// Find all entries of the map in the original bytes and decode them. The errors
+	// are recorded on the message.
+	buf := codec.NewBuffer(protomessage.BytesFromBytesView(m.$fieldNameRaw))
+	for !buf.EOF() {
+		v, err := buf.DecodeVarint()
+		if err != nil {
+			m.set$FieldNameDecodeErr(err)
+			return
+		}
+		fieldNum, wireType, err := codec.AsTagAndWireType(v)
+		if err != nil {
+			m.set$FieldNameDecodeErr(err)
+			return
+		}
+		if fieldNum != %d || wireType != codec.WireBytes {
+			// Not an entry of this map, skip it.
+			if err := buf.SkipFieldByWireType(wireType); err != nil {
+				m.set$FieldNameDecodeErr(err)
+				return
+			}
+			continue
+		}
+		entry, err := buf.AsBytesUnsafe()
+		if err != nil {
+			m.set$FieldNameDecodeErr(err)
+			return
+		}
+		if err := m.decode$FieldNameEntry(entry); err != nil {
+			m.set$FieldNameDecodeErr(err)
+		}
+	}
+}
+
+// set$FieldNameDecodeErr records the error of decoding the entries of the
+// $fieldName map on the message.
+func (m *$MessageName) set$FieldNameDecodeErr(err error) {
+	if m.$fieldNameDecodeErr == nil {
+		m.$fieldNameDecodeErr = err
+	}
+	m._protoMessage.SetDecodeErr(err)
+}
+
+// decode$FieldNameEntry decodes one entry of the $fieldName map and adds it to the map.
+func (m *$MessageName) decode$FieldNameEntry(b []byte) error {
+	buf := codec.NewBuffer(b)
+	var k $MapKeyType
+	var v $MapValueType
+	for !buf.EOF() {
+		tag, err := buf.DecodeVarint()
+		if err != nil {
+			return err
+		}
+		fieldNum, wireType, err := codec.AsTagAndWireType(tag)
+		if err != nil {
+			return err
+		}
+		switch fieldNum {`, g.field.GetNumber(),
+	)
+
+	g.i(2)
+	g.oMapEntryFieldDecode(key, decodeFull)
+	g.oMapEntryFieldDecode(value, decodeFull)
+	g.oMapEntryUnknownField()
+	g.i(-2)
+
+	g.o(
+		`
+		}
+	}`,
+	)
+
+	if isMessageField(value) {
+		g.o(
+			`
+	if v == nil {
+		// The value is absent, which means it is an empty message.
+		v = $mapValuePool.Get()
+		v.$mapValueProtoMessage.Parent = &m._protoMessage
+	}
+	if err := v.$mapValueDecode(); err != nil {
+		return err
+	}`,
+		)
+	}
+
+	g.o(
+		`
+	if m.$fieldName == nil {
+		m.$fieldName = map[$MapKeyType]$MapValueType{}
+	}`,
+	)
+
+	if isMessageField(value) {
+		g.o(
+			`
+	if old, ok := m.$fieldName[k]; ok && old != nil {
+		// Duplicate key, last one wins. Return the old value to the pool.
+		old.Free()
+	}`,
+		)
+	}
+
+	g.o(
+		`
+	m.$fieldName[k] = v
+	return nil
+}
+
+func validate$MapEntryName(b []byte) error {
+	buf := codec.NewBuffer(b)
+	for !buf.EOF() {
+		tag, err := buf.DecodeVarint()
+		if err != nil {
+			return err
+		}
+		fieldNum, wireType, err := codec.AsTagAndWireType(tag)
+		if err != nil {
+			return err
+		}
+		switch fieldNum {`,
+	)
+
+	g.i(2)
+	g.oMapEntryFieldDecode(key, decodeValidate)
+	g.oMapEntryFieldDecode(value, decodeValidate)
+	g.oMapEntryUnknownField()
+	g.i(-2)
+
+	g.o(
+		`
+		}
+	}
+	return nil
+}
+`,
+	)
+}
+
+func (g *generator) oMapEntryUnknownField() {
+	g.o(
+		`
+default:
+	// Unknown field number.
+	if err := buf.SkipFieldByWireType(wireType); err != nil {
+		return err
+	}`,
+	)
+}
+
+// oMapEntryFieldDecode generates a case for decoding the key or value field of
+// a map entry. In decodeFull mode the decoded value is stored in "k" or "v" var.
+func (g *generator) oMapEntryFieldDecode(field *Field, mode decodeMode) {
+	wireType := protoTypeToWireType[field.GetType()]
+	varName := "k"
+	if field.GetNumber() == 2 {
+		varName = "v"
+	}
+
+	g.o(`case %d:`, field.GetNumber())
+	g.i(1)
+	g.o(
+		`
+if wireType != codec.Wire%s {
+	return fmt.Errorf("invalid wire type %%d for field number %d ($MessageName.$fieldName %s)", wireType)
+}`, wireTypeToString[wireType], field.GetNumber(), field.GetName(),
+	)
+
+	switch {
+	case isMessageField(field):
+		g.o(
+			`
+vb, err := buf.AsBytesUnsafe()
+if err != nil {
+	return err
+}`,
+		)
+		if mode == decodeValidate {
+			g.o(`if err := $mapValueValidate(vb); err != nil {`)
+			g.o(`	return err`)
+			g.o(`}`)
+		} else {
+			g.o(
+				`
+if v == nil {
+	// Get a struct for the embedded message from the pool.
+	v = $mapValuePool.Get()
+	v.$mapValueProtoMessage.Parent = &m._protoMessage
+}
+v.$mapValueProtoMessage.Bytes = protomessage.BytesViewFromBytes(vb)`,
+			)
+		}
+
+	case field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM:
+		g.o(
+			`
+ev, err := buf.AsUint32()
+if err != nil {
+	return err
+}`,
+		)
+		if mode == decodeValidate {
+			g.o(`_ = ev`)
+		} else {
+			g.o(`%s = %s(ev)`, varName, g.convertTypeToGo(field))
+		}
+
+	default:
+		decode, ok := primitiveTypeDecode[field.GetType()]
+		if !ok {
+			g.lastErr = fmt.Errorf("unsupported map field type %v", field.GetType())
+			return
+		}
+		if mode == decodeValidate {
+			if wireType == codec.WireBytes {
+				g.o(`if err := buf.SkipRawBytes(); err != nil {`)
+			} else {
+				g.o(`if _, err := buf.As%s(); err != nil {`, decode.asProtoType)
+			}
+			g.o(`	return err`)
+			g.o(`}`)
+		} else {
+			g.o(
+				`
+%s, err = buf.As%s()
+if err != nil {
+	return err
+}`, varName, decode.asProtoType,
+			)
+		}
+	}
+	g.i(-1)
+}
+
+// oDecodeFieldMap generates code to decode or validate the map field when it
+// is encountered in the message bytes.
+func (g *generator) oDecodeFieldMap(mode decodeMode, checkWireType bool) {
+	if checkWireType {
+		g.o(
+			`
+if wireType != codec.WireBytes {
+	return fmt.Errorf("invalid wire type %%d for field number %d ($MessageName.$fieldName)", wireType)
+}`, g.field.GetNumber(),
+		)
+	}
+
+	if mode == decodeValidate {
+		entry, _, _ := g.mapEntry(g.field)
+		g.o(
+			`
+v, err := buf.DecodeRawBytes()
+if err != nil {
+	return err
+}
+if err := validate%s(v); err != nil {
+	return err
+}`, entry.GetName(),
+		)
+		return
+	}
+
+	g.o(
+		`
+// Remember the bytes, the map entries will be decoded on first access.
+m.$fieldNameRaw = m._protoMessage.Bytes
+if err := buf.SkipRawBytes(); err != nil {
+	return err
+}`,
+	)
+}
+
+func (g *generator) oPrepareMarshalMapField() {
+	entry, key, value := g.mapEntry(g.field)
+	g.o(g.preparedFieldDecl(g.msg, g.field, "Embedded"))
+	g.o(g.preparedFieldDecl(entry, key, primitiveTypePrepare[key.GetType()]))
+	g.o(g.preparedFieldDecl(entry, value, primitiveTypePrepare[value.GetType()]))
+}
+
+func (g *generator) oMarshalMapField() {
+	g.setMapField()
+	entry, key, value := g.mapEntry(g.field)
+	keyPrepared := embeddedFieldPreparedVarName(entry, key)
+	valuePrepared := embeddedFieldPreparedVarName(entry, value)
+
+	// With the backward stream the undecoded entries are written in reverse order,
+	// which is fine since the order of the map entries is not significant.
+	g.o(
+		`
+if m._flags&$mapDecodedFlag == 0 {
+	// The map is not decoded, so it is unchanged. Copy the original entries as is.
+	buf := codec.NewBuffer(protomessage.BytesFromBytesView(m.$fieldNameRaw))
+	for !buf.EOF() {
+		start := buf.Bytes()
+		v, err := buf.DecodeVarint()
+		if err != nil {
+			return err
+		}
+		fieldNum, wireType, err := codec.AsTagAndWireType(v)
+		if err != nil {
+			return err
+		}
+		if err := buf.SkipFieldByWireType(wireType); err != nil {
+			return err
+		}
+		if fieldNum == %d {
+			// Copy the key and the entry bytes.
+			ps.Raw(start[:len(start)-buf.Len()])
+		}
+	}
+} else {
+	for k, v := range m.$fieldName {`, g.field.GetNumber(),
+	)
+
+	g.i(2)
+	if g.options.MarshalStream == BackwardStream {
+		// The entry is written backward: the value first, then the key, followed by
+		// the length prefix of the entry.
+		g.o(`entryToken := ps.BeginEmbedded()`)
+		g.oMarshalMapEntryField(value, "v", valuePrepared)
+		g.oMarshalMapEntryField(key, "k", keyPrepared)
+		g.o(`ps.EndEmbedded(entryToken, %s)`, embeddedFieldPreparedVarName(g.msg, g.field))
+	} else {
+		g.oSizeMapEntry("entrySize", "k", "v")
+		g.o(`ps.EmbeddedSizePrepared(%s, entrySize)`, embeddedFieldPreparedVarName(g.msg, g.field))
+		g.oMarshalMapEntryField(key, "k", keyPrepared)
+		g.oMarshalMapEntryField(value, "v", valuePrepared)
+	}
+	g.i(-2)
+
+	g.o(`	}`)
+	g.o(`}`)
+}
+
+func (g *generator) oMarshalMapEntryField(field *Field, varName string, preparedName string) {
+	switch {
+	case isMessageField(field):
+		g.o(`if %s != nil {`, varName)
+		g.i(1)
+		if g.options.MarshalStream == BackwardStream {
+			g.oMarshalEmbeddedBackward(preparedName, varName)
+		} else {
+			g.o(`ps.EmbeddedSizePrepared(%s, %s.Size())`, preparedName, varName)
+			g.o(`if err := %s.Marshal(ps); err != nil {`, varName)
+			g.o(`	return err`)
+			g.o(`}`)
+		}
+		g.i(-1)
+		g.o(`}`)
+
+	case field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM:
+		g.o(`ps.Uint32Prepared(%s, uint32(%s))`, preparedName, varName)
+
+	default:
+		typeName, ok := primitiveTypeMarshal[field.GetType()]
+		if !ok {
+			g.lastErr = fmt.Errorf("unsupported map field type %v", field.GetType())
+			return
+		}
+		g.o(`ps.%sPrepared(%s, %s)`, typeName, preparedName, varName)
+	}
+}
+
+// oPoolReleaseMapField generates code that releases the map values to their pool.
+func (g *generator) oPoolReleaseMapField() {
+	_, _, value := g.mapEntry(g.field)
+	if !isMessageField(value) {
+		return
+	}
+	g.setMapField()
+	g.o(`// Release $fieldName values recursively to their pool.`)
+	g.o(`for _, v := range elem.$fieldName {`)
+	g.o(`	if v != nil {`)
+	g.o(`		$mapValuePool.Release(v)`)
+	g.o(`	}`)
+	g.o(`}`)
+}
+
+// oResetMapField generates code that resets the map field for reuse.
+func (g *generator) oResetMapField() {
+	g.o(`// Delete all $fieldName entries, but keep the map for reuse.`)
+	g.o(`for k := range elem.$fieldName {`)
+	g.o(`	delete(elem.$fieldName, k)`)
+	g.o(`}`)
+	g.o(`elem.$fieldNameRaw = protomessage.BytesView{}`)
+	g.o(`elem.$fieldNameDecodeErr = nil`)
+}
@@ -27,19 +27,50 @@ func (g *generator) oMarshalMethod() error {
 
 	// Output the Marshal() func.
 
+	stream := g.marshalStream()
 	g.o(``)
-	if g.useSizedMarshaler {
-		g.o(`func (m *$MessageName) Marshal(ps *sizedstream.ProtoStream) error {`)
-	} else {
-		g.o(`func (m *$MessageName) Marshal(ps *molecule.ProtoStream) error {`)
-	}
+	g.o(`func (m *$MessageName) Marshal(ps *%s.%s) error {`, stream.pkg, stream.typeName)
 	g.i(1)
 
 	g.o(`if m._protoMessage.IsModified() {`)
-	g.o(`// The struct is modified, marshal from the struct fields.`)
 	g.i(1)
+	g.o(`// The struct is modified, marshal from the struct fields.`)
 
-	g.oMarshalFieldsFromStruct()
+	switch g.options.MarshalStream {
+	case MoleculeStream:
+		g.o(`if ps.UpfrontSizes() {`)
+		g.o(`	// The written bytes can't be moved, so the length prefixes of all nested`)
+		g.o(`	// messages are written upfront. Size() calculates and caches their sizes.`)
+		g.o(`	m.Size()`)
+		g.o(`}`)
+		g.o(`if m._protoMessage.Parent == nil {`)
+		g.o(`	if size, ok := m._protoMessage.KnownSize(); ok {`)
+		g.o(`		// The size is cached by Size() or by the previous Marshal.`)
+		g.o(`		ps.Grow(size)`)
+		g.o(`	}`)
+		g.o(`}`)
+		g.o(`start := ps.Len()`)
+		g.o(``)
+
+		g.oMarshalFieldsFromStruct()
+		g.oMarshalUnknownFields()
+		g.o(`ps.EndModified(&m._protoMessage, start)`)
+
+	case SizedStream:
+		g.o(`// The stream doesn't check its capacity on every write, so the space must be`)
+		g.o(`// reserved. Size() of the nested messages returns the size cached by Size()`)
+		g.o(`// of the root message.`)
+		g.o(`ps.Grow(m.Size())`)
+		g.o(``)
+
+		g.oMarshalFieldsFromStruct()
+		g.oMarshalUnknownFields()
+
+	case BackwardStream:
+		g.o(`// The stream is written backward, so the fields are marshaled in reverse order.`)
+		g.oMarshalUnknownFields()
+		g.oMarshalFieldsFromStruct()
+	}
 
 	g.i(-1)
 
@@ -56,16 +87,37 @@ func (g *generator) oMarshalMethod() error {
 	return g.lastErr
 }
 
+func (g *generator) oMarshalUnknownFields() {
+	g.o(`// Marshal unknown fields.`)
+	if g.options.MarshalStream == BackwardStream {
+		g.o(`for i := m._unknownFields.Len() - 1; i >= 0; i-- {`)
+	} else {
+		g.o(`for i := 0; i < m._unknownFields.Len(); i++ {`)
+	}
+	g.o(`	ps.Raw(m._unknownFields.At(i))`)
+	g.o(`}`)
+}
+
 func (g *generator) oPrepareMarshalFields() {
 	// Output "prepared" field definitions.
 	for _, field := range g.msg.Fields {
 		g.setField(field)
 
-		if field.IsRepeated() && field.GetType() != descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if field.IsMap() {
+			g.oPrepareMarshalMapField()
+			continue
+		}
+
+		if field.IsRepeated() && !isMessageField(field) {
 			// We don't use "prepared" for primitive repeated fields.
 			continue
 		}
 
+		if isGroupField(field) {
+			// Groups are delimited by start and end keys, they don't use "prepared".
+			continue
+		}
+
 		g.oPrepareMarshalField(field)
 	}
 }
@@ -75,6 +127,11 @@ func (g *generator) oMarshalFieldsFromStruct() {
 	// order that we can rely on in the tests (same order as other as Protobuf
 	// libs so that we can compare the results).
 	fields := orderedByFieldNumber(g.msg.Fields)
+	if g.options.MarshalStream == BackwardStream {
+		for i, j := 0, len(fields)-1; i < j; i, j = i+1, j-1 {
+			fields[i], fields[j] = fields[j], fields[i]
+		}
+	}
 
 	// Marshal one field at a time.
 	for _, field := range fields {
@@ -127,37 +184,32 @@ func embeddedFieldPreparedVarName(msg *Message, field *Field) string {
 	return fmt.Sprintf("prepared_%s_%s", msg.GetName(), field.GetCapitalName())
 }
 
-func convertProtoTypeToOneOfType(protoType string) string {
-	switch protoType {
-	case "SFixed64":
-		return "Int64"
-	case "SFixed32":
-		return "Int64"
-	default:
-		return protoType
-	}
+func (g *generator) oMarshalPreparedField(protoTypeName string) {
+	g.o(
+		"ps.%sPrepared(prepared_$MessageName_$FieldName, %s)",
+		protoTypeName, g.marshalValueExpr(),
+	)
 }
 
-func (g *generator) oMarshalPreparedField(protoTypeName string) {
+// marshalValueExpr returns the Go expression of the value of the current
+// non-message field to marshal.
+func (g *generator) marshalValueExpr() string {
 	if g.field.GetOneOf() != nil {
-		g.o(
-			"ps.%[1]sPrepared(prepared_$MessageName_$FieldName, m.%[2]s.%[3]sVal())",
-			protoTypeName,
-			g.field.GetOneOf().GetName(),
-			convertProtoTypeToOneOfType(protoTypeName),
-		)
-	} else {
-		g.o(
-			"ps.%sPrepared(prepared_$MessageName_$FieldName, m.$fieldName)",
-			protoTypeName,
-		)
+		return "m." + g.field.GetOneOf().GetName() + "." +
+			primitiveTypeDecode[g.field.GetType()].oneOfType + "Val()"
 	}
+	return "m." + g.field.GetName()
 }
 
 func (g *generator) oMarshalField() {
 	g.o(`// Marshal "$fieldName".`)
 
-	if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+	if g.field.IsMap() {
+		g.oMarshalMapField()
+		return
+	}
+
+	if isMessageField(g.field) {
 		g.oMarshalMessageTypeField()
 		return
 	} else if g.field.IsRepeated() {
@@ -167,63 +219,76 @@ func (g *generator) oMarshalField() {
 
 	// This is a primitive, non-repeated field.
 
-	usePresence := g.options.WithPresence && !g.isOneOfField()
+	flagName, usePresence := g.msg.PresenceFlagName[g.field]
 
 	if usePresence {
 		// If we have presence flags check if the field is set.
-		g.o(`if m._flags&%s != 0 {`, g.msg.PresenceFlagName[g.field])
+		g.o(`if m._flags&%s != 0 {`, flagName)
 		g.i(1)
 	}
 
-	switch g.field.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_BOOL:
-		g.oMarshalPreparedField("Bool")
-
-	case descriptor.FieldDescriptorProto_TYPE_STRING:
-		g.oMarshalPreparedField("String")
-
-	case descriptor.FieldDescriptorProto_TYPE_BYTES:
-		g.oMarshalPreparedField("Bytes")
-
-	case descriptor.FieldDescriptorProto_TYPE_FIXED64:
-		g.oMarshalPreparedField("Fixed64")
-
-	case descriptor.FieldDescriptorProto_TYPE_SFIXED64:
-		g.oMarshalPreparedField("SFixed64")
-
-	case descriptor.FieldDescriptorProto_TYPE_UINT64:
-		g.oMarshalPreparedField("Uint64")
-
-	case descriptor.FieldDescriptorProto_TYPE_INT64:
-		g.oMarshalPreparedField("Int64")
-
-	case descriptor.FieldDescriptorProto_TYPE_FIXED32:
-		g.oMarshalPreparedField("Fixed32")
-
-	case descriptor.FieldDescriptorProto_TYPE_UINT32:
-		g.oMarshalPreparedField("Uint32")
-
-	case descriptor.FieldDescriptorProto_TYPE_SINT32:
-		g.oMarshalPreparedField("Sint32")
-
-	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-		g.oMarshalPreparedField("Double")
+	// The fields with explicit presence and the oneof choices are present even if
+	// set to zero value.
+	explicitZero := hasExplicitPresence(g.field) || g.field.GetOneOf() != nil
+	if explicitZero {
+		// Prepared methods skip zero values, but the field is present and must
+		// be marshaled.
+		g.o(`if %s {`, zeroValueCheck(g.field, g.marshalValueExpr()))
+		g.o(`	ps.ZeroPrepared(prepared_$MessageName_$FieldName)`)
+		g.o(`} else {`)
+		g.i(1)
+	}
 
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+	if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
 		g.o(`ps.Uint32Prepared(prepared_$MessageName_$FieldName, uint32(m.$fieldName))`)
-
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-
-	default:
+	} else if typeName, ok := primitiveTypeMarshal[g.field.GetType()]; ok {
+		g.oMarshalPreparedField(typeName)
+	} else {
 		g.lastErr = fmt.Errorf("unsupported field type %v", g.field.GetType())
 	}
 
+	if explicitZero {
+		g.i(-1)
+		g.o(`}`) // else
+	}
+
 	if usePresence {
 		g.i(-1)
 		g.o(`}`) // if
 	}
 }
 
+// zeroValueCheck returns a Go expression that is true if the value of the
+// non-message field is the zero value of its type.
+func zeroValueCheck(field *Field, value string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "!" + value
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return value + ` == ""`
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "len(" + value + ") == 0"
+	default:
+		return value + " == 0"
+	}
+}
+
+// wireNonZeroValueCheck returns a Go expression that is true if the value of the
+// non-message field is not the zero value of its type, i.e. if the value is written
+// by the Prepared methods. Unlike in JSON, negative zero floats are zero values.
+func wireNonZeroValueCheck(field *Field, value string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return value
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return value + ` != ""`
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "len(" + value + ") != 0"
+	default:
+		return value + " != 0"
+	}
+}
+
 func (g *generator) oMarshalPrimitiveRepeated() {
 	switch g.field.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_BOOL:
@@ -239,7 +304,7 @@ func (g *generator) oMarshalPrimitiveRepeated() {
 		g.oMarshalPrimitiveRepeatedField("Fixed64")
 
 	case descriptor.FieldDescriptorProto_TYPE_SFIXED64:
-		g.oMarshalPrimitiveRepeatedField("SFixed64")
+		g.oMarshalPrimitiveRepeatedField("Sfixed64")
 
 	case descriptor.FieldDescriptorProto_TYPE_UINT64:
 		g.oMarshalPrimitiveRepeatedField("Uint64")
@@ -256,13 +321,29 @@ func (g *generator) oMarshalPrimitiveRepeated() {
 	case descriptor.FieldDescriptorProto_TYPE_SINT32:
 		g.oMarshalPrimitiveRepeatedField("Sint32")
 
+	case descriptor.FieldDescriptorProto_TYPE_INT32:
+		g.oMarshalPrimitiveRepeatedField("Int32")
+
+	case descriptor.FieldDescriptorProto_TYPE_SINT64:
+		g.oMarshalPrimitiveRepeatedField("Sint64")
+
+	case descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		g.oMarshalPrimitiveRepeatedField("Sfixed32")
+
 	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
 		g.oMarshalPrimitiveRepeatedField("Double")
 
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		g.oMarshalPrimitiveRepeatedField("Float")
+
 	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		g.o(`ps.Uint32Prepared(prepared_$MessageName_$FieldName, uint32(m.$fieldName))`)
+		// Enums are marshaled as uint32.
+		g.o(
+			`ps.Uint32Packed(%d, protomessage.EnumValues(m.$fieldName))`, g.field.GetNumber(),
+		)
 
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
 
 	default:
 		g.lastErr = fmt.Errorf("unsupported field type %v", g.field.GetType())
@@ -275,15 +356,15 @@ func (g *generator) oMarshalPrimitiveRepeatedField(protoTypeName string) {
 
 func (g *generator) oMarshalMessageTypeField() {
 	if g.field.IsRepeated() {
-		g.o(`for _, elem := range m.$fieldName {`)
-		g.o(`	token := ps.BeginEmbedded()`)
-		g.o(`	if err := elem.Marshal(ps); err != nil {`)
-		g.o(`		return err`)
-		g.o(`	}`)
-		g.o(
-			"	ps.EndEmbeddedPrepared(token, %s)",
-			embeddedFieldPreparedVarName(g.msg, g.field),
-		)
+		if g.options.MarshalStream == BackwardStream {
+			g.o(`for i := len(m.$fieldName) - 1; i >= 0; i-- {`)
+			g.o(`	elem := m.$fieldName[i]`)
+		} else {
+			g.o(`for _, elem := range m.$fieldName {`)
+		}
+		g.i(1)
+		g.oMarshalEmbedded("elem")
+		g.i(-1)
 	} else {
 		if g.field.GetOneOf() != nil {
 			g.o(
@@ -295,76 +376,135 @@ func (g *generator) oMarshalMessageTypeField() {
 		}
 
 		g.o(`if $fieldName != nil {`)
-		g.o(`	token := ps.BeginEmbedded()`)
-		g.o(`	if err := $fieldName.Marshal(ps); err != nil {`)
-		g.o(`		return err`)
-		g.o(`	}`)
-		g.o(
-			"	ps.EndEmbeddedPrepared(token, %s)",
-			embeddedFieldPreparedVarName(g.msg, g.field),
-		)
+		g.i(1)
+		g.oMarshalEmbedded(g.field.GetName())
+		g.i(-1)
 	}
 	g.o(`}`)
 }
 
-func (g *generator) oPrepareMarshalField(field *Field) {
-	switch field.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_BOOL:
-		g.o(g.preparedFieldDecl(g.msg, field, "Bool"))
-
-	case descriptor.FieldDescriptorProto_TYPE_STRING:
-		g.o(g.preparedFieldDecl(g.msg, field, "String"))
-
-	case descriptor.FieldDescriptorProto_TYPE_BYTES:
-		g.o(g.preparedFieldDecl(g.msg, field, "Bytes"))
-
-	case descriptor.FieldDescriptorProto_TYPE_FIXED64:
-		g.o(g.preparedFieldDecl(g.msg, field, "Fixed64"))
-
-	case descriptor.FieldDescriptorProto_TYPE_SFIXED64:
-		g.o(g.preparedFieldDecl(g.msg, field, "Fixed64"))
-
-	case descriptor.FieldDescriptorProto_TYPE_UINT64:
-		g.o(g.preparedFieldDecl(g.msg, field, "Uint64"))
-
-	case descriptor.FieldDescriptorProto_TYPE_INT64:
-		g.o(g.preparedFieldDecl(g.msg, field, "Int64"))
-
-	case descriptor.FieldDescriptorProto_TYPE_FIXED32:
-		g.o(g.preparedFieldDecl(g.msg, field, "Fixed32"))
-
-	case descriptor.FieldDescriptorProto_TYPE_UINT32:
-		g.o(g.preparedFieldDecl(g.msg, field, "Uint32"))
+// oMarshalEmbedded generates code that marshals the message stored in the
+// specified variable as the current field.
+func (g *generator) oMarshalEmbedded(varName string) {
+	if isGroupField(g.field) {
+		// Groups are delimited by the start and end keys instead of being
+		// length-prefixed.
+		first, last := "BeginGroup", "EndGroup"
+		if g.options.MarshalStream == BackwardStream {
+			first, last = last, first
+		}
+		g.o(`ps.%s(%d)`, first, g.field.GetNumber())
+		g.o(`if err := %s.Marshal(ps); err != nil {`, varName)
+		g.o(`	return err`)
+		g.o(`}`)
+		g.o(`ps.%s(%d)`, last, g.field.GetNumber())
+		return
+	}
 
-	case descriptor.FieldDescriptorProto_TYPE_SINT32:
-		g.o(g.preparedFieldDecl(g.msg, field, "Sint32"))
+	if g.options.MarshalStream == BackwardStream {
+		g.oMarshalEmbeddedBackward(embeddedFieldPreparedVarName(g.msg, g.field), varName)
+		return
+	}
 
-	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-		g.o(g.preparedFieldDecl(g.msg, field, "Double"))
+	if g.options.MarshalStream == SizedStream {
+		// The size is already calculated and cached by the Size() call of this
+		// message, so the length prefix is written before the nested message.
+		g.o(
+			`ps.EmbeddedSizePrepared(%s, %s.Size())`, embeddedFieldPreparedVarName(g.msg, g.field),
+			varName,
+		)
+		g.o(`if err := %s.Marshal(ps); err != nil {`, varName)
+		g.o(`	return err`)
+		g.o(`}`)
+		return
+	}
 
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		g.o(g.preparedFieldDecl(g.msg, field, "Uint32"))
+	// The length prefix is written upfront if the size of the nested message is
+	// known. Otherwise it is written after the message, which is cheaper than
+	// calculating the sizes of all nested messages in advance.
+	preparedName := embeddedFieldPreparedVarName(g.msg, g.field)
+	g.o(`if size, ok := %s.$fieldTypeProtoMessage.KnownSize(); ok {`, varName)
+	g.o(`	ps.EmbeddedSizePrepared(%s, size)`, preparedName)
+	g.o(`	if err := %s.Marshal(ps); err != nil {`, varName)
+	g.o(`		return err`)
+	g.o(`	}`)
+	g.o(`} else {`)
+	g.o(`	token := ps.BeginEmbedded()`)
+	g.o(`	if err := %s.Marshal(ps); err != nil {`, varName)
+	g.o(`		return err`)
+	g.o(`	}`)
+	g.o(`	ps.EndEmbeddedPrepared(token, %s)`, preparedName)
+	g.o(`}`)
+}
 
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		g.o(g.preparedFieldDecl(g.msg, field, "Embedded"))
+// oMarshalEmbeddedBackward generates code that marshals the message stored in the
+// specified variable to the backward stream. The length prefix is written after the
+// message, so its size doesn't need to be calculated.
+func (g *generator) oMarshalEmbeddedBackward(preparedName string, varName string) {
+	g.o(`token := ps.BeginEmbedded()`)
+	g.o(`if err := %s.Marshal(ps); err != nil {`, varName)
+	g.o(`	return err`)
+	g.o(`}`)
+	g.o(`ps.EndEmbedded(token, %s)`, preparedName)
+}
 
-	default:
+func (g *generator) oPrepareMarshalField(field *Field) {
+	prefix, ok := primitiveTypePrepare[field.GetType()]
+	if !ok {
 		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+		return
 	}
+	g.o(g.preparedFieldDecl(g.msg, field, prefix))
+}
+
+// primitiveTypeMarshal maps the proto type to the name of the ProtoStream
+// method that marshals it (the name without "Prepared" suffix).
+var primitiveTypeMarshal = map[descriptor.FieldDescriptorProto_Type]string{
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     "Bool",
+	descriptor.FieldDescriptorProto_TYPE_STRING:   "String",
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    "Bytes",
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  "Fixed64",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: "SFixed64",
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   "Uint64",
+	descriptor.FieldDescriptorProto_TYPE_INT64:    "Int64",
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  "Fixed32",
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   "Uint32",
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   "Sint32",
+	descriptor.FieldDescriptorProto_TYPE_INT32:    "Int32",
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   "Sint64",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: "SFixed32",
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   "Double",
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    "Float",
+}
+
+// primitiveTypePrepare maps the proto type to the name of the Prepare*Field
+// function that prepares the key of the field of that type.
+var primitiveTypePrepare = map[descriptor.FieldDescriptorProto_Type]string{
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     "Bool",
+	descriptor.FieldDescriptorProto_TYPE_STRING:   "String",
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    "Bytes",
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  "Fixed64",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: "Fixed64",
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   "Uint64",
+	descriptor.FieldDescriptorProto_TYPE_INT64:    "Int64",
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  "Fixed32",
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   "Uint32",
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   "Sint32",
+	descriptor.FieldDescriptorProto_TYPE_INT32:    "Int32",
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   "Sint64",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: "Fixed32",
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   "Double",
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    "Float",
+	descriptor.FieldDescriptorProto_TYPE_ENUM:     "Uint32",
+	descriptor.FieldDescriptorProto_TYPE_MESSAGE:  "Embedded",
 }
 
 func (g *generator) preparedFieldDecl(
 	msg *Message, field *Field, prepareFuncNamePrefix string,
 ) string {
-	if g.useSizedMarshaler {
-		return fmt.Sprintf(
-			"var prepared_%s_%s = sizedstream.Prepare%sField(%d)", msg.GetName(),
-			field.GetCapitalName(), prepareFuncNamePrefix, field.GetNumber(),
-		)
-	} else {
-		return fmt.Sprintf(
-			"var prepared_%s_%s = molecule.Prepare%sField(%d)", msg.GetName(),
-			field.GetCapitalName(), prepareFuncNamePrefix, field.GetNumber(),
-		)
-	}
+	return fmt.Sprintf(
+		"var prepared_%s_%s = %s.Prepare%sField(%d)", msg.GetName(),
+		field.GetCapitalName(), g.marshalStream().pkg, prepareFuncNamePrefix,
+		field.GetNumber(),
+	)
 }
@@ -12,6 +12,7 @@ func unexportedName(name string) string {
 }
 
 func (g *generator) oPool() error {
+	g.oResetMethod()
 	g.oPoolStruct()
 	g.oPoolGetFuncs()
 	g.oPoolReleaseSliceFunc()
@@ -89,22 +90,66 @@ func (p *$messagePoolType) GetSlice(r []*$MessageName) {
 	)
 }
 
+// oNewFuncs generates the exported constructors that take the messages from the pool.
+func (g *generator) oNewFuncs() error {
+	g.o(
+		`
+// New$MessageName returns an empty $MessageName message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func New$MessageName() *$MessageName {
+	return $messagePool.Get()
+}
+
+// New$MessageNameSlice returns a slice of n empty $MessageName messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func New$MessageNameSlice(n int) []*$MessageName {
+	r := make([]*$MessageName, n)
+	$messagePool.GetSlice(r)
+	return r
+}
+`,
+	)
+	return g.lastErr
+}
+
 func getPoolName(msgName string) string {
 	return unexportedName(msgName) + "Pool"
 }
 
+// oResetMethod generates the method that releases the nested messages to their
+// pools and resets the message to the empty state.
+func (g *generator) oResetMethod() {
+	g.o(
+		`
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *$MessageName) reset() {
+	elem := m
+`,
+	)
+
+	g.i(1)
+	g.oPoolReleaseElem()
+	g.i(-1)
+
+	g.o(`}`)
+	g.o(``)
+}
+
 func (g *generator) oPoolReleaseElem() {
 	for _, field := range g.msg.Fields {
 		g.setField(field)
 
-		if field.GetOneOf() != nil {
+		if field.IsMap() {
+			g.oPoolReleaseMapField()
+		} else if field.GetOneOf() != nil {
 			// A oneof field.
 			fieldIndex := g.calcOneOfFieldIndex()
 			if fieldIndex == 0 {
 				// We generate all oneof cases when we see the first field. Skip for the rest.
 				g.oPoolReleaseOneofField()
 			}
-		} else if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		} else if isMessageField(field) {
 			// Embedded message that is not a oneof field.
 			g.o(`// Release nested $fieldName recursively to their pool.`)
 			if field.IsRepeated() {
@@ -128,7 +173,7 @@ func (g *generator) oPoolReleaseOneofField() {
 	for _, choice := range g.field.GetOneOf().GetChoices() {
 		choiceField := g.msg.FieldsMap[choice.GetName()]
 		g.setField(choiceField)
-		if choiceField.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if isMessageField(choiceField) {
 			choiceName := composeOneOfChoiceName(g.msg, choiceField)
 			g.o(`case %s:`, choiceName)
 			g.i(1)
@@ -147,14 +192,17 @@ func (g *generator) oPoolReleaseOneofField() {
 
 func (g *generator) oResetElem() {
 	g.o(`// Reset the released element.`)
-	g.o(`elem._protoMessage = protomessage.ProtoMessage{}`)
+	g.o(`elem._protoMessage.Reset()`)
+	g.o(`elem._unknownFields.Reset()`)
 	if g.msg.FlagsBitCount > 0 {
 		g.o(`elem._flags = 0`)
 	}
 	for _, field := range g.msg.Fields {
 		g.setField(field)
 
-		if field.IsRepeated() {
+		if field.IsMap() {
+			g.oResetMapField()
+		} else if field.IsRepeated() {
 			g.o(`elem.$fieldName = elem.$fieldName[:0]`)
 		} else if field.GetOneOf() != nil {
 			idx := g.calcOneOfFieldIndex()
@@ -163,54 +211,56 @@ func (g *generator) oResetElem() {
 			}
 		} else {
 			// Not a repeated field and not a oneof. Need to assign a zero value.
-			var zeroVal string
-			switch field.GetType() {
-			case descriptor.FieldDescriptorProto_TYPE_BOOL:
-				zeroVal = "false"
-
-			case descriptor.FieldDescriptorProto_TYPE_FIXED64,
-				descriptor.FieldDescriptorProto_TYPE_SFIXED64,
-				descriptor.FieldDescriptorProto_TYPE_UINT64,
-				descriptor.FieldDescriptorProto_TYPE_INT64,
-				descriptor.FieldDescriptorProto_TYPE_FIXED32,
-				descriptor.FieldDescriptorProto_TYPE_SFIXED32,
-				descriptor.FieldDescriptorProto_TYPE_SINT32,
-				descriptor.FieldDescriptorProto_TYPE_UINT32,
-				descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-				zeroVal = "0"
-
-			case descriptor.FieldDescriptorProto_TYPE_STRING:
-				zeroVal = `""`
-			case descriptor.FieldDescriptorProto_TYPE_BYTES:
-				zeroVal = "nil"
-			case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-				zeroVal = "nil"
-			case descriptor.FieldDescriptorProto_TYPE_ENUM:
-				zeroVal = g.enumDescrToEnum[field.GetEnumType()].GetName() + "(0)"
-			default:
-				g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
-			}
-
+			zeroVal := g.zeroValue(field)
 			g.o(`elem.$fieldName = %s`, zeroVal)
 		}
 	}
 }
 
+// zeroValue returns the Go expression of the zero value of the non-repeated field.
+func (g *generator) zeroValue(field *Field) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "false"
+
+	case descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "0"
+
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return `""`
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "nil"
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return "nil"
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return g.enumTypeName(field.GetEnumType()) + "(0)"
+	default:
+		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+		return ""
+	}
+}
+
 func (g *generator) oPoolReleaseSliceFunc() {
 	g.o(``)
 	g.o(
 		`
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *$messagePoolType) ReleaseSlice(slice []*$MessageName) {
-	for _, elem := range slice {`,
-	)
-
-	g.i(2)
-	g.oPoolReleaseElem()
-	g.i(-2)
-
-	g.o(
-		`	}
+	for _, elem := range slice {
+		elem.reset()
+	}
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -226,16 +276,9 @@ func (g *generator) oPoolReleaseFunc() {
 	g.o(
 		`
 // Release an element back to the pool.
-func (p *$messagePoolType) Release(elem *$MessageName) {`,
-	)
-
-	g.i(1)
-	g.oPoolReleaseElem()
-	g.i(-1)
+func (p *$messagePoolType) Release(elem *$MessageName) {
+	elem.reset()
 
-	g.o(``)
-	g.o(
-		`
 	p.mux.Lock()
 	defer p.mux.Unlock()
 
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// hasExplicitPresence returns true if the field tracks presence regardless of
+// WithPresence option. This is the case for singular non-message proto2 fields
+// and for proto3 "optional" fields. Such fields are marshaled when present even
+// if set to zero value.
+func hasExplicitPresence(field *Field) bool {
+	if field.IsRepeated() || isMessageField(field) || field.GetOneOf() != nil {
+		return false
+	}
+	return !field.GetFile().IsProto3() || field.IsProto3Optional()
+}
+
+// defaultValue returns the Go expression of the default value of the field, which
+// the getter returns when the field is not present. Returns false if the default
+// is the zero value of the Go type and no special handling is needed.
+func (g *generator) defaultValue(field *Field) (string, bool) {
+	if !hasExplicitPresence(field) {
+		return "", false
+	}
+
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		// The default of proto2 enum is the first declared value, unless specified.
+		enumDescr := field.GetEnumType()
+		valueName := field.AsFieldDescriptorProto().GetDefaultValue()
+		if valueName == "" {
+			if enumDescr.GetValues()[0].GetNumber() == 0 {
+				return "", false
+			}
+			valueName = enumDescr.GetValues()[0].GetName()
+		}
+		return g.qualifiedName(
+			enumDescr.GetFile(), g.enumDescrToEnum[enumDescr].GetName()+"_"+valueName,
+		), true
+	}
+
+	if field.AsFieldDescriptorProto().DefaultValue == nil {
+		return "", false
+	}
+
+	switch v := field.GetDefaultValue().(type) {
+	case string:
+		return strconv.Quote(v), true
+	case []byte:
+		return "[]byte(" + strconv.Quote(string(v)) + ")", true
+	case bool, int32, int64, uint32, uint64:
+		return fmt.Sprintf("%v", v), true
+	case float32:
+		return formatFloat(float64(v), 32), true
+	case float64:
+		return formatFloat(v, 64), true
+	default:
+		g.lastErr = fmt.Errorf(
+			"unsupported default value %v of field %s", v, field.GetFullyQualifiedName(),
+		)
+		return "", false
+	}
+}
+
+// formatFloat returns the Go expression for the float value of the specified
+// bit size.
+func formatFloat(v float64, bitSize int) string {
+	var s string
+	switch {
+	case math.IsInf(v, 1):
+		s = "math.Inf(1)"
+	case math.IsInf(v, -1):
+		s = "math.Inf(-1)"
+	case math.IsNaN(v):
+		s = "math.NaN()"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, bitSize)
+	}
+	if bitSize == 32 {
+		s = "float32(" + s + ")"
+	}
+	return s
+}
+
+// requiredFields returns the fields of the current message that are declared
+// as required.
+func (g *generator) requiredFields() []*Field {
+	var r []*Field
+	for _, field := range g.msg.Fields {
+		if field.IsRequired() {
+			r = append(r, field)
+		}
+	}
+	return r
+}
+
+// requiredSeenVarName returns the name of the variable that indicates that the
+// required field was seen during validation.
+func requiredSeenVarName(field *Field) string {
+	return field.GetName() + "Seen"
+}
@@ -0,0 +1,44 @@
+package generator
+
+// oProtoConvMethods generates the methods that convert the message to and from
+// the message of the same type generated by another protobuf implementation.
+func (g *generator) oProtoConvMethods() error {
+	stream := g.marshalStream()
+
+	g.o(
+		`
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *$MessageName) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *$MessageName) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := %s.%s()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+`, stream.pkg, stream.newFunc,
+	)
+	return g.lastErr
+}
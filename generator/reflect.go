@@ -0,0 +1,319 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/protobuf/proto"
+)
+
+// The generated messages implement protoreflect.ProtoMessage. The reflective
+// view of the message is implemented by runtime/lazyreflect on top of the field
+// accessors generated here. The descriptors are built from the serialized
+// FileDescriptorProto that is embedded in the generated file.
+
+// fileDescrVarName returns the name of the var that refers to the lazyreflect.File
+// of the current file.
+func (g *generator) fileDescrVarName() string {
+	return "file_" + goSanitizedName(g.file.GetName())
+}
+
+// reflectInfoName returns the name of the var that contains the
+// lazyreflect.MessageInfo of the message.
+func reflectInfoName(msg *Message) string {
+	return unexportedName(msg.GetName()) + "ReflectInfo"
+}
+
+// oFileDescriptor generates the serialized descriptor of the current file and
+// registers it.
+func (g *generator) oFileDescriptor() error {
+	fdp := proto.Clone(g.file.AsFileDescriptorProto()).(*descriptor.FileDescriptorProto)
+	// Comments are not needed at run time.
+	fdp.SourceCodeInfo = nil
+	rawDesc, err := proto.MarshalOptions{Deterministic: true}.Marshal(fdp)
+	if err != nil {
+		return err
+	}
+
+	varName := g.fileDescrVarName()
+	g.o(`// %s_rawDesc is the serialized FileDescriptorProto of %s.`, varName, g.file.GetName())
+	g.o(`var %s_rawDesc = []byte{`, varName)
+	for len(rawDesc) > 0 {
+		n := 16
+		if n > len(rawDesc) {
+			n = len(rawDesc)
+		}
+		line := ""
+		for _, b := range rawDesc[:n] {
+			line += fmt.Sprintf("0x%02x, ", b)
+		}
+		g.o(`	%s`, line)
+		rawDesc = rawDesc[n:]
+	}
+	g.o(`}`)
+	g.o(``)
+	g.o(`var %[1]s = lazyreflect.RegisterFile(%[2]q, %[1]s_rawDesc)`, varName, g.file.GetName())
+	return g.lastErr
+}
+
+func (g *generator) oReflectMethods() error {
+	g.templateData["$messageReflectInfo"] = reflectInfoName(g.msg)
+
+	g.o(
+		`
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *$MessageName) ProtoReflect() protoreflect.Message {
+	return $messageReflectInfo.MessageOf(m)
+}
+`,
+	)
+
+	// Generate the helpers that create the views of the lists and maps.
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+		switch {
+		case field.IsMap():
+			g.oReflectMapMethod()
+		case field.IsRepeated():
+			g.oReflectListMethod()
+		}
+	}
+
+	g.o(`var $messageReflectInfo = &lazyreflect.MessageInfo[$MessageName]{`)
+	g.i(1)
+	g.o(`File: %s,`, g.fileDescrVarName())
+	g.o(`FullName: %q,`, g.msg.MessageDescriptor.GetFullyQualifiedName())
+	g.o(`NewMessage: New$MessageName,`)
+	g.o(`UnknownFields: (*$MessageName).UnknownFields,`)
+	g.o(
+		`
+SetUnknownFields: func(m *$MessageName, b []byte) {
+	m._unknownFields.Reset()
+	m._unknownFields.Add(b)
+	m._protoMessage.MarkModified()
+},`,
+	)
+	g.o(`Fields: []lazyreflect.FieldInfo[$MessageName]{`)
+	g.i(1)
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+		g.o(`{`)
+		g.i(1)
+		g.o(`// %s`, field.FieldDescriptor.GetName())
+		switch {
+		case field.IsMap():
+			g.oReflectMapFieldInfo()
+		case field.IsRepeated():
+			g.oReflectListFieldInfo()
+		case isMessageField(field):
+			g.oReflectMessageFieldInfo()
+		default:
+			g.oReflectScalarFieldInfo()
+		}
+		g.i(-1)
+		g.o(`},`)
+	}
+	g.i(-1)
+	g.o(`},`)
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+
+	return g.lastErr
+}
+
+// reflectConv returns the lazyreflect.Conv of the scalar field.
+func (g *generator) reflectConv(field *Field) string {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		return fmt.Sprintf("lazyreflect.EnumConv[%s]()", g.enumTypeName(field.GetEnumType()))
+	}
+	method, ok := streamTypeMethod[field.GetType()]
+	if !ok {
+		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+	}
+	return "lazyreflect." + method + "Conv"
+}
+
+// newMessageFunc returns the name of the function that creates a new message of
+// the type of the field.
+func (g *generator) newMessageFunc(field *Field) string {
+	msg := g.messageDescrToMessage[field.GetMessageType()]
+	return g.qualifiedName(msg.GetFile(), "New"+msg.GetName())
+}
+
+// zeroValue returns the Go expression of the zero value of the scalar field.
+func zeroValue(field *Field) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "false"
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return `""`
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "nil"
+	default:
+		return "0"
+	}
+}
+
+// oReflectOneofHas generates the Has function of the oneof field.
+func (g *generator) oReflectOneofHas(extraCheck string) {
+	g.o(
+		`Has: func(m *$MessageName) bool { return m.%s.FieldIndex() == int(%s)%s },`,
+		g.field.GetOneOf().GetName(), composeOneOfChoiceName(g.msg, g.field), extraCheck,
+	)
+}
+
+// oReflectOneofClear generates the Clear function of the oneof field.
+func (g *generator) oReflectOneofClear() {
+	oneofName := g.field.GetOneOf().GetName()
+	g.o(
+		`
+Clear: func(m *$MessageName) {
+	if m.%s.FieldIndex() == int(%s) {
+		m.%s()
+		m._protoMessage.MarkModified()
+	}
+},`, oneofName, composeOneOfChoiceName(g.msg, g.field), capitalCamelCase(oneofName)+"Unset",
+	)
+}
+
+func (g *generator) oReflectScalarFieldInfo() {
+	field := g.field
+	switch {
+	case field.GetOneOf() != nil:
+		g.oReflectOneofHas("")
+	case hasExplicitPresence(field):
+		g.o(`Has: func(m *$MessageName) bool { return m.Has$FieldName() },`)
+	default:
+		g.o(
+			`Has: func(m *$MessageName) bool { return %s },`,
+			nonZeroValueCheck(field, "m."+field.GetName()),
+		)
+	}
+
+	conv := g.reflectConv(field)
+	g.o(`Get: func(m *$MessageName) protoreflect.Value { return %s.ToValue(m.$FieldName()) },`, conv)
+	g.o(`Set: func(m *$MessageName, v protoreflect.Value) { m.Set$FieldName(%s.FromValue(v)) },`, conv)
+
+	switch {
+	case field.GetOneOf() != nil:
+		g.oReflectOneofClear()
+	case hasExplicitPresence(field):
+		g.o(`Clear: func(m *$MessageName) { m.Clear$FieldName() },`)
+	default:
+		g.o(`Clear: func(m *$MessageName) { m.Set$FieldName(%s) },`, zeroValue(field))
+	}
+}
+
+func (g *generator) oReflectMessageFieldInfo() {
+	field := g.field
+	newFunc := g.newMessageFunc(field)
+
+	if field.GetOneOf() != nil {
+		g.oReflectOneofHas(fmt.Sprintf(" && m.%s.PtrVal() != nil", field.GetOneOf().GetName()))
+	} else {
+		g.o(`Has: func(m *$MessageName) bool { return m.$fieldName != nil },`)
+	}
+	g.o(`Get: func(m *$MessageName) protoreflect.Value { return lazyreflect.ValueOfMessage(m.$FieldName()) },`)
+	g.o(
+		`Set: func(m *$MessageName, v protoreflect.Value) { m.Set$FieldName(lazyreflect.MessageFromValue(v, %s)) },`,
+		newFunc,
+	)
+	if field.GetOneOf() != nil {
+		g.oReflectOneofClear()
+	} else {
+		g.o(`Clear: func(m *$MessageName) { m.Set$FieldName(nil) },`)
+	}
+	g.o(
+		`
+Mutable: func(m *$MessageName) protoreflect.Value {
+	if m.$FieldName() == nil {
+		m.Set$FieldName(%s())
+	}
+	return lazyreflect.ValueOfMessage(m.$FieldName())
+},`, newFunc,
+	)
+}
+
+// oReflectListMethod generates the method that returns the view of the list.
+func (g *generator) oReflectListMethod() {
+	g.o(`// reflect$FieldName returns the view of the $fieldName list.`)
+	g.o(`func (m *$MessageName) reflect$FieldName() protoreflect.List {`)
+	if isMessageField(g.field) {
+		g.o(`	if m._flags&%s == 0 {`, g.msg.DecodedFlagName[g.field])
+		g.o(`		m.decode$FieldName()`)
+		g.o(`	}`)
+		g.o(
+			`	return lazyreflect.NewMessageList(&m.$fieldName, &m._protoMessage, %s)`,
+			g.newMessageFunc(g.field),
+		)
+	} else {
+		g.o(
+			`	return lazyreflect.NewScalarList(&m.$fieldName, &m._protoMessage, %s)`,
+			g.reflectConv(g.field),
+		)
+	}
+	g.o(`}`)
+	g.o(``)
+}
+
+func (g *generator) oReflectListFieldInfo() {
+	g.o(`Has: func(m *$MessageName) bool { return len(m.$fieldName) > 0 },`)
+	g.o(`Get: func(m *$MessageName) protoreflect.Value { return protoreflect.ValueOfList(m.reflect$FieldName()) },`)
+	if isMessageField(g.field) {
+		g.o(
+			`Set: func(m *$MessageName, v protoreflect.Value) { m.Set$FieldName(lazyreflect.MessagesFromList(v.List(), %s)) },`,
+			g.newMessageFunc(g.field),
+		)
+	} else {
+		g.o(
+			`Set: func(m *$MessageName, v protoreflect.Value) { m.Set$FieldName(lazyreflect.ScalarsFromList(v.List(), %s)) },`,
+			g.reflectConv(g.field),
+		)
+	}
+	g.o(`Clear: func(m *$MessageName) { m.Set$FieldName(nil) },`)
+	g.o(`Mutable: func(m *$MessageName) protoreflect.Value { return protoreflect.ValueOfList(m.reflect$FieldName()) },`)
+}
+
+// oReflectMapMethod generates the method that returns the view of the map.
+func (g *generator) oReflectMapMethod() {
+	g.setMapField()
+	_, key, value := g.mapEntry(g.field)
+
+	g.o(
+		`
+// reflect$FieldName returns the view of the $fieldName map.
+func (m *$MessageName) reflect$FieldName() protoreflect.Map {
+	acc := lazyreflect.MapAccessors[$MapKeyType, $MapValueType]{
+		Len:    m.$FieldNameLen,
+		Get:    m.$FieldNameGet,
+		Range:  m.$FieldNameRange,
+		Set:    m.$FieldNameSet,
+		Delete: m.$FieldNameDelete,
+	}`,
+	)
+	if isMessageField(value) {
+		g.o(
+			`	return lazyreflect.NewMessageMap(acc, %s, %s)`, g.reflectConv(key),
+			g.newMessageFunc(value),
+		)
+	} else {
+		g.o(`	return lazyreflect.NewScalarMap(acc, %s, %s)`, g.reflectConv(key), g.reflectConv(value))
+	}
+	g.o(`}`)
+	g.o(``)
+}
+
+func (g *generator) oReflectMapFieldInfo() {
+	g.o(
+		`
+Has: func(m *$MessageName) bool { return m.$FieldNameLen() > 0 },
+Get: func(m *$MessageName) protoreflect.Value { return protoreflect.ValueOfMap(m.reflect$FieldName()) },
+Set: func(m *$MessageName, v protoreflect.Value) { lazyreflect.AssignMap(m.reflect$FieldName(), v.Map()) },
+Clear: func(m *$MessageName) { lazyreflect.ClearMap(m.reflect$FieldName()) },
+Mutable: func(m *$MessageName) protoreflect.Value { return protoreflect.ValueOfMap(m.reflect$FieldName()) },`,
+	)
+}
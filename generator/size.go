@@ -0,0 +1,334 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/protowire"
+)
+
+// The Size() method calculates the number of bytes that Marshal() writes. It must
+// mirror the marshaling code exactly, including the rules about skipping zero values.
+// The size of the modified message is cached in _protoMessage, so that Marshal()
+// can write the length prefixes of nested messages before the messages themselves.
+
+func (g *generator) oSizeMethod() error {
+	g.o(
+		`
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *$MessageName) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *$MessageName) calcSize() int {
+	size := 0`,
+	)
+	g.i(1)
+
+	for _, field := range orderedByFieldNumber(g.msg.Fields) {
+		g.setField(field)
+
+		if field.GetOneOf() != nil {
+			// Only calculate the size of the oneof once, when we see the first choice.
+			if g.calcOneOfFieldIndex() == 0 {
+				g.oSizeOneofField()
+			}
+		} else {
+			g.oSizeField()
+		}
+	}
+
+	g.i(-1)
+	g.o(
+		`
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}`,
+	)
+
+	return g.lastErr
+}
+
+func (g *generator) oSizeOneofField() {
+	g.o(`// Size of %q.`, g.field.GetOneOf().GetName())
+
+	typeName := composeOneOfAliasTypeName(g.msg, g.field.GetOneOf())
+	g.o(`switch %s(m.%s.FieldIndex()) {`, typeName, g.field.GetOneOf().GetName())
+	for _, choice := range g.field.GetOneOf().GetChoices() {
+		g.setField(g.msg.FieldsMap[choice.GetName()])
+		g.o(`case %s:`, composeOneOfChoiceName(g.msg, g.field))
+		g.i(1)
+		g.oSizeField()
+		g.i(-1)
+	}
+	g.o(`}`)
+}
+
+func (g *generator) oSizeField() {
+	g.o(`// Size of "$fieldName".`)
+
+	if g.field.IsMap() {
+		g.oSizeMapField()
+		return
+	}
+
+	if isMessageField(g.field) {
+		g.oSizeMessageTypeField()
+		return
+	}
+
+	if g.field.IsRepeated() {
+		g.oSizePrimitiveRepeated()
+		return
+	}
+
+	// This is a primitive, non-repeated field.
+	value := g.marshalValueExpr()
+	valueSize := primitiveValueSize(g.field, value)
+	if valueSize == "" {
+		g.lastErr = fmt.Errorf("unsupported field type %v", g.field.GetType())
+		return
+	}
+	keySize := keySize(g.field)
+
+	flagName, usePresence := g.msg.PresenceFlagName[g.field]
+	if usePresence {
+		g.o(`if m._flags&%s != 0 {`, flagName)
+		g.i(1)
+	}
+
+	if hasExplicitPresence(g.field) || g.field.GetOneOf() != nil {
+		// The field is marshaled even if it is set to zero value.
+		g.o(`if %s {`, zeroValueCheck(g.field, value))
+		g.o(`	size += %d`, keySize+zeroValueSize(g.field))
+		g.o(`} else {`)
+		g.o(`	size += %s`, sizeSum(keySize, valueSize))
+		g.o(`}`)
+	} else {
+		// Zero values are not marshaled.
+		g.o(`if %s {`, wireNonZeroValueCheck(g.field, value))
+		g.o(`	size += %s`, sizeSum(keySize, valueSize))
+		g.o(`}`)
+	}
+
+	if usePresence {
+		g.i(-1)
+		g.o(`}`)
+	}
+}
+
+// keySize returns the encoded size of the key of the field.
+func keySize(field *Field) int {
+	return protowire.SizeVarint(uint64(field.GetNumber()) << 3)
+}
+
+// sizeSum returns the Go expression that adds the key size to the value size
+// expression. Constant value sizes are added at generation time.
+func sizeSum(keySize int, valueSize string) string {
+	if n, err := strconv.Atoi(valueSize); err == nil {
+		return strconv.Itoa(keySize + n)
+	}
+	return strconv.Itoa(keySize) + " + " + valueSize
+}
+
+// zeroValueSize returns the encoded size of the zero value of the field, as written
+// by ZeroPrepared.
+func zeroValueSize(field *Field) int {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return 4
+	case descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return 8
+	default:
+		return 1
+	}
+}
+
+// primitiveValueSize returns the Go expression that calculates the encoded size of
+// the non-zero value of the non-message field, excluding the key. Returns an empty
+// string if the type of the field is not supported.
+func primitiveValueSize(field *Field, value string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "1"
+	case descriptor.FieldDescriptorProto_TYPE_STRING,
+		descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "molecule.SizeBytes(len(" + value + "))"
+	case descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "4"
+	case descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "8"
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT64:
+		return "molecule.SizeVarint(uint64(" + value + "))"
+	case descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT64:
+		return "molecule.SizeZigZag(int64(" + value + "))"
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// Enums are marshaled as uint32.
+		return "molecule.SizeVarint(uint64(uint32(" + value + ")))"
+	default:
+		return ""
+	}
+}
+
+func (g *generator) oSizePrimitiveRepeated() {
+	num := g.field.GetNumber()
+	switch g.field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		g.o(`size += molecule.SizeFixedPacked(%d, len(m.$fieldName), 1)`, num)
+
+	case descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		g.o(`size += molecule.SizeFixedPacked(%d, len(m.$fieldName), 4)`, num)
+
+	case descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		g.o(`size += molecule.SizeFixedPacked(%d, len(m.$fieldName), 8)`, num)
+
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT64:
+		g.o(`size += molecule.SizeVarintPacked(%d, m.$fieldName)`, num)
+
+	case descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT64:
+		g.o(`size += molecule.SizeZigZagPacked(%d, m.$fieldName)`, num)
+
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// Enums are marshaled as uint32.
+		g.o(`size += molecule.SizeVarintPacked(%d, protomessage.EnumValues(m.$fieldName))`, num)
+
+	default:
+		g.lastErr = fmt.Errorf("unsupported repeated field type %v", g.field.GetType())
+	}
+}
+
+func (g *generator) oSizeMessageTypeField() {
+	if g.field.IsRepeated() {
+		g.o(`for _, elem := range m.$fieldName {`)
+		g.i(1)
+		g.oSizeEmbedded("elem")
+		g.i(-1)
+	} else {
+		if g.field.GetOneOf() != nil {
+			g.o(
+				"$fieldName := (*$FieldMessageTypeName)(m.%s.PtrVal())",
+				g.field.GetOneOf().GetName(),
+			)
+		} else {
+			g.o(`$fieldName := m.$fieldName`)
+		}
+
+		g.o(`if $fieldName != nil {`)
+		g.i(1)
+		g.oSizeEmbedded(g.field.GetName())
+		g.i(-1)
+	}
+	g.o(`}`)
+}
+
+// oSizeEmbedded generates code that adds the size of the message stored in the
+// specified variable as the current field.
+func (g *generator) oSizeEmbedded(varName string) {
+	if isGroupField(g.field) {
+		// The group is delimited by the start and end keys.
+		g.o(`size += %d + %s.Size()`, 2*keySize(g.field), varName)
+		return
+	}
+	g.o(`size += %d + molecule.SizeBytes(%s.Size())`, keySize(g.field), varName)
+}
+
+func (g *generator) oSizeMapField() {
+	g.setMapField()
+	g.o(
+		`
+if m._flags&$mapDecodedFlag == 0 {
+	// The map is not decoded, the original entries are marshaled as is.
+	buf := codec.NewBuffer(protomessage.BytesFromBytesView(m.$fieldNameRaw))
+	for !buf.EOF() {
+		start := buf.Bytes()
+		v, err := buf.DecodeVarint()
+		if err != nil {
+			break
+		}
+		fieldNum, wireType, err := codec.AsTagAndWireType(v)
+		if err != nil {
+			break
+		}
+		if err := buf.SkipFieldByWireType(wireType); err != nil {
+			break
+		}
+		if fieldNum == %d {
+			size += len(start) - buf.Len()
+		}
+	}
+} else {
+	for k, v := range m.$fieldName {`, g.field.GetNumber(),
+	)
+	g.i(2)
+	g.oSizeMapEntry("entrySize", "k", "v")
+	g.o(`size += %d + molecule.SizeBytes(entrySize)`, keySize(g.field))
+	g.i(-2)
+	g.o(`	}`)
+	g.o(`}`)
+}
+
+// oSizeMapEntry generates code that declares the variable with the specified name
+// and sets it to the size of the map entry with the key and value stored in the
+// kVar and vVar variables.
+func (g *generator) oSizeMapEntry(sizeVar string, kVar string, vVar string) {
+	_, key, value := g.mapEntry(g.field)
+	g.o(`%s := 0`, sizeVar)
+	for _, f := range []struct {
+		field   *Field
+		varName string
+	}{{key, kVar}, {value, vVar}} {
+		if isMessageField(f.field) {
+			g.o(`if %s != nil {`, f.varName)
+			g.o(
+				`	%s += %d + molecule.SizeBytes(%s.Size())`, sizeVar, keySize(f.field),
+				f.varName,
+			)
+			g.o(`}`)
+			continue
+		}
+
+		valueSize := primitiveValueSize(f.field, f.varName)
+		if valueSize == "" {
+			g.lastErr = fmt.Errorf("unsupported map field type %v", f.field.GetType())
+			return
+		}
+		// Zero keys and values are not marshaled.
+		g.o(`if %s {`, wireNonZeroValueCheck(f.field, f.varName))
+		g.o(`	%s += %s`, sizeVar, sizeSum(keySize(f.field), valueSize))
+		g.o(`}`)
+	}
+}
@@ -0,0 +1,128 @@
+package generator
+
+// sliceTypeName returns the name of the type that represents a repeated field
+// of the specified message type.
+func sliceTypeName(msg *Message) string {
+	return msg.GetName() + "Slice"
+}
+
+func (g *generator) oSliceType() error {
+	g.templateData["$MessageSliceName"] = sliceTypeName(g.msg)
+
+	g.o(
+		`
+// $MessageSliceName is a repeated field of $MessageName messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type $MessageSliceName struct {
+	elems  *[]*$MessageName
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s $MessageSliceName) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s $MessageSliceName) At(i int) *$MessageName {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s $MessageSliceName) Range(f func(i int, elem *$MessageName) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s $MessageSliceName) Append(elems ...*$MessageName) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s $MessageSliceName) AppendNew() *$MessageName {
+	elem := $messagePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s $MessageSliceName) InsertAt(i int, elem *$MessageName) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s $MessageSliceName) RemoveAt(i int) {
+	elems := *s.elems
+	$messagePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s $MessageSliceName) RemoveIf(f func(elem *$MessageName) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			$messagePool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s $MessageSliceName) Sort(less func(a, b *$MessageName) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+`,
+	)
+	return g.lastErr
+}
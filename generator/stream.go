@@ -0,0 +1,77 @@
+package generator
+
+import "fmt"
+
+// MarshalStream selects the stream that the generated Marshal methods write to.
+type MarshalStream int
+
+const (
+	// MoleculeStream is the default molecule.ProtoStream. Only the messages that
+	// are generated for it implement lazyproto.Message and can be used with the
+	// gRPC codecs.
+	MoleculeStream MarshalStream = iota
+
+	// SizedStream is the experimental sizedstream.ProtoStream, which writes to a
+	// buffer that is grown upfront by the size of the message.
+	SizedStream
+
+	// BackwardStream is the experimental backwardstream.BackwardMemStream, which
+	// writes the messages from the end to the beginning, so the sizes of the
+	// embedded messages don't need to be known in advance.
+	BackwardStream
+)
+
+type marshalStreamInfo struct {
+	name string
+	// Import path and import name of the stream package.
+	importPath string
+	pkg        string
+	// Name of the stream type and of its constructor function.
+	typeName string
+	newFunc  string
+}
+
+var marshalStreams = map[MarshalStream]marshalStreamInfo{
+	MoleculeStream: {
+		name:       "molecule",
+		importPath: "github.com/tigrannajaryan/exp-lazyproto/runtime/molecule",
+		pkg:        "molecule",
+		typeName:   "ProtoStream",
+		newFunc:    "NewProtoStream",
+	},
+	SizedStream: {
+		name:       "sized",
+		importPath: "github.com/tigrannajaryan/exp-lazyproto/runtime/streams/sizedstream",
+		pkg:        "sizedstream",
+		typeName:   "ProtoStream",
+		newFunc:    "NewProtoStream",
+	},
+	BackwardStream: {
+		name:       "backward",
+		importPath: "github.com/tigrannajaryan/exp-lazyproto/runtime/streams/backwardstream",
+		pkg:        "backwardstream",
+		typeName:   "BackwardMemStream",
+		newFunc:    "NewBackwardMemStream",
+	},
+}
+
+func (s MarshalStream) String() string {
+	return marshalStreams[s].name
+}
+
+// ParseMarshalStream returns the stream with the specified name: "molecule",
+// "sized" or "backward".
+func ParseMarshalStream(name string) (MarshalStream, error) {
+	for stream, info := range marshalStreams {
+		if info.name == name {
+			return stream, nil
+		}
+	}
+	return MoleculeStream, fmt.Errorf("unknown marshal stream %q", name)
+}
+
+// marshalStream returns the description of the stream that the generated Marshal
+// methods write to.
+func (g *generator) marshalStream() marshalStreamInfo {
+	return marshalStreams[g.options.MarshalStream]
+}
@@ -0,0 +1,543 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The text format output is the same as produced by prototext. Like JSON, the
+// messages are written without populating the lazy message tree.
+
+// textFieldName returns the name of the field in text format.
+func textFieldName(field *Field) string {
+	if isGroupField(field) {
+		// The name of a group field is the name of the group type.
+		return field.GetMessageType().GetName()
+	}
+	return field.FieldDescriptor.GetName()
+}
+
+func (g *generator) oTextMethods() error {
+	g.o(
+		`
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *$MessageName) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *$MessageName) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *$MessageName) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := $messagePool.Get()
+	defer $messagePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+`,
+	)
+
+	g.oMarshalTextStream()
+	g.oUnmarshalTextStream()
+
+	return g.lastErr
+}
+
+func (g *generator) oMarshalTextStream() {
+	g.o(`// MarshalTextStream writes the fields of the message in text format to tw.`)
+	g.o(`func (m *$MessageName) MarshalTextStream(tw *textstream.Writer) error {`)
+	g.i(1)
+
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+
+		switch {
+		case field.IsMap():
+			g.oMarshalTextMapField()
+
+		case field.GetOneOf() != nil:
+			if g.calcOneOfFieldIndex() == 0 {
+				// We generate all oneof cases when we see the first field. Skip for the rest.
+				g.oMarshalTextOneofField()
+			}
+
+		case field.IsRepeated():
+			g.o(`for _, elem := range m.$fieldName {`)
+			g.o(`	tw.Name(%q)`, textFieldName(field))
+			g.i(1)
+			if isMessageField(field) {
+				g.oMarshalTextMessage("elem")
+			} else {
+				g.oMarshalTextValue(field, "elem")
+			}
+			g.i(-1)
+			g.o(`}`)
+
+		case isMessageField(field):
+			g.o(`if m.$fieldName != nil {`)
+			g.o(`	tw.Name(%q)`, textFieldName(field))
+			g.i(1)
+			g.oMarshalTextMessage("m." + field.GetName())
+			g.i(-1)
+			g.o(`}`)
+
+		default:
+			if flagName, ok := g.msg.PresenceFlagName[field]; ok && hasExplicitPresence(field) {
+				g.o(`if m._flags&%s != 0 {`, flagName)
+			} else {
+				g.o(`if %s {`, nonZeroValueCheck(field, "m."+field.GetName()))
+			}
+			g.o(`	tw.Name(%q)`, textFieldName(field))
+			g.i(1)
+			g.oMarshalTextValue(field, "m."+field.GetName())
+			g.i(-1)
+			g.o(`}`)
+		}
+	}
+
+	g.o(`return nil`)
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+}
+
+// oMarshalTextValue generates code that writes the non-message value of the field.
+func (g *generator) oMarshalTextValue(field *Field, value string) {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		g.o(`tw.Enum(uint32(%s), %s)`, value, g.enumNamesVar(field))
+		return
+	}
+	method, ok := streamTypeMethod[field.GetType()]
+	if !ok {
+		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+		return
+	}
+	g.o(`tw.%s(%s)`, method, value)
+}
+
+// oMarshalTextMessage generates code that writes the embedded message of the
+// current field, which is stored in the specified variable.
+func (g *generator) oMarshalTextMessage(varName string) {
+	g.o(
+		`
+tw.BeginMessage()
+if m._flags&%[1]s != 0 {
+	if err := %[2]s.MarshalTextStream(tw); err != nil {
+		return err
+	}
+} else if err := %[2]s.$fieldTypeMarshalTextUndecoded(tw); err != nil {
+	return err
+}
+tw.EndMessage()`, g.msg.DecodedFlagName[g.field], varName,
+	)
+}
+
+func (g *generator) oMarshalTextOneofField() {
+	oneofName := g.field.GetOneOf().GetName()
+	typeName := composeOneOfAliasTypeName(g.msg, g.field.GetOneOf())
+
+	g.o(`switch %s(m.%s.FieldIndex()) {`, typeName, oneofName)
+	for _, choice := range g.field.GetOneOf().GetChoices() {
+		choiceField := g.msg.FieldsMap[choice.GetName()]
+		g.setField(choiceField)
+		g.o(`case %s:`, composeOneOfChoiceName(g.msg, choiceField))
+		g.i(1)
+		if isMessageField(choiceField) {
+			g.o(`if ptr := (*$FieldMessageTypeName)(m.%s.PtrVal()); ptr != nil {`, oneofName)
+			g.o(`	tw.Name(%q)`, textFieldName(choiceField))
+			g.i(1)
+			g.oMarshalTextMessage("ptr")
+			g.i(-1)
+			g.o(`}`)
+		} else {
+			g.o(`tw.Name(%q)`, textFieldName(choiceField))
+			g.oMarshalTextValue(choiceField, g.marshalValueExpr())
+		}
+		g.i(-1)
+	}
+	g.o(`}`)
+}
+
+func (g *generator) oMarshalTextMapField() {
+	g.setMapField()
+	_, key, value := g.mapEntry(g.field)
+
+	less := "keys[i] < keys[j]"
+	if key.GetType() == descriptor.FieldDescriptorProto_TYPE_BOOL {
+		less = "!keys[i] && keys[j]"
+	}
+
+	g.o(
+		`
+{
+	src := m
+	if m._flags&$mapDecodedFlag == 0 {
+		// Decode the entries into a temporary message, so that this message
+		// remains undecoded.
+		tmp := $messagePool.Get()
+		defer $messagePool.Release(tmp)
+		tmp.$fieldNameRaw = m.$fieldNameRaw
+		tmp.decode$FieldName()
+		src = tmp
+	}
+
+	// Write the entries ordered by the key, the same way as prototext does.
+	keys := make([]$MapKeyType, 0, len(src.$fieldName))
+	for k := range src.$fieldName {
+		keys = append(keys, k)
+	}
+	sort.Slice(
+		keys, func(i, j int) bool {
+			return %s
+		},
+	)
+	for _, k := range keys {
+		tw.Name(%q)
+		tw.BeginMessage()
+		tw.Name("key")`, less, textFieldName(g.field),
+	)
+
+	g.i(2)
+	g.oMarshalTextValue(key, "k")
+	g.o(`tw.Name("value")`)
+	if isMessageField(value) {
+		g.o(
+			`
+tw.BeginMessage()
+if v := src.$fieldName[k]; v != nil {
+	if err := v.MarshalTextStream(tw); err != nil {
+		return err
+	}
+}
+tw.EndMessage()`,
+		)
+	} else {
+		g.oMarshalTextValue(value, "src."+g.field.GetName()+"[k]")
+	}
+	g.i(-2)
+
+	g.o(
+		`
+		tw.EndMessage()
+	}
+}`,
+	)
+}
+
+func (g *generator) oUnmarshalTextStream() {
+	g.o(
+		`
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *$MessageName) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}`,
+	)
+	g.i(1)
+
+	if len(g.msg.DecodedFlags) > 0 {
+		g.o(``)
+		g.o(`// The message is built from text, there is nothing to decode from wire bytes.`)
+		var flags string
+		for i, bitDef := range g.msg.DecodedFlags {
+			if i > 0 {
+				flags += " | "
+			}
+			flags += bitDef.flagName
+		}
+		g.o(`m._flags = %s`, flags)
+	}
+
+	g.o(
+		`
+for {
+	name, ok, err := r.NextField()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		break
+	}
+	switch name {`,
+	)
+	g.i(1)
+
+	for _, field := range g.msg.Fields {
+		g.setField(field)
+		g.o(`case %q:`, textFieldName(field))
+		g.i(1)
+		// The colon is optional before messages.
+		g.o(`if err := r.Colon(%v); err != nil {`, isMessageField(field) || field.IsMap())
+		g.o(`	return err`)
+		g.o(`}`)
+
+		switch {
+		case field.IsMap():
+			g.oUnmarshalTextMapField()
+		case field.GetOneOf() != nil:
+			g.oUnmarshalTextOneofField()
+		case field.IsRepeated():
+			g.oUnmarshalTextRepeatedField()
+		case isMessageField(field):
+			g.o(
+				`
+if m.$fieldName != nil {
+	// Duplicate field, the last one wins.
+	$fieldTypeMessagePool.Release(m.$fieldName)
+}
+m.$fieldName = $fieldTypeMessagePool.Get()
+m.$fieldName.$fieldTypeProtoMessage.Parent = &m._protoMessage`,
+			)
+			g.oUnmarshalTextMessage("m." + field.GetName())
+		default:
+			g.oUnmarshalTextValue(field, "v")
+			g.o(`m.$fieldName = v`)
+			if flagName, ok := g.msg.PresenceFlagName[field]; ok {
+				g.o(`m._flags |= %s`, flagName)
+			}
+		}
+		g.i(-1)
+	}
+
+	g.o(`default:`)
+	g.o(`	return fmt.Errorf("unknown field %%q in $MessageName", name)`)
+	g.i(-1)
+	g.o(`}`) // switch
+	g.o(`}`) // for
+
+	for _, field := range g.requiredFields() {
+		g.setField(field)
+		if isMessageField(field) {
+			g.o(`if m.$fieldName == nil {`)
+		} else {
+			g.o(`if m._flags&%s == 0 {`, g.msg.PresenceFlagName[field])
+		}
+		g.o(`	return fmt.Errorf("required field $MessageName.$fieldName is missing")`)
+		g.o(`}`)
+	}
+
+	g.o(`return nil`)
+	g.i(-1)
+	g.o(`}`)
+	g.o(``)
+}
+
+// oUnmarshalTextValue generates code that reads the non-message value of the field
+// into the specified variable.
+func (g *generator) oUnmarshalTextValue(field *Field, varName string) {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		g.o(
+			`
+ev, err := r.ReadEnum(%s)
+if err != nil {
+	return err
+}
+%s := %s(ev)`, g.enumValuesVar(field), varName, g.enumTypeName(field.GetEnumType()),
+		)
+		return
+	}
+
+	method, ok := streamTypeMethod[field.GetType()]
+	if !ok {
+		g.lastErr = fmt.Errorf("unsupported field type %v", field.GetType())
+		return
+	}
+	g.o(
+		`
+%s, err := r.Read%s()
+if err != nil {
+	return err
+}`, varName, method,
+	)
+}
+
+// oUnmarshalTextMessage generates code that reads the embedded message into the
+// specified variable, which must already hold a struct taken from the pool.
+func (g *generator) oUnmarshalTextMessage(varName string) {
+	g.o(
+		`
+if err := r.BeginMessage(); err != nil {
+	return err
+}
+if err := %s.UnmarshalTextStream(r); err != nil {
+	return err
+}`, varName,
+	)
+}
+
+func (g *generator) oUnmarshalTextOneofField() {
+	oneofName := g.field.GetOneOf().GetName()
+	choiceName := composeOneOfChoiceName(g.msg, g.field)
+
+	g.o(`if m.%s.FieldIndex() != 0 {`, oneofName)
+	g.o(`	return fmt.Errorf("more than one field of oneof %s in $MessageName is set")`, oneofName)
+	g.o(`}`)
+
+	if isMessageField(g.field) {
+		g.o(
+			`
+elem := $fieldTypeMessagePool.Get()
+elem.$fieldTypeProtoMessage.Parent = &m._protoMessage
+m.%s = oneof.NewPtr(unsafe.Pointer(elem), int(%s))`, oneofName, choiceName,
+		)
+		g.oUnmarshalTextMessage("elem")
+		return
+	}
+
+	g.oUnmarshalTextValue(g.field, "v")
+	g.o(
+		"m.%s = oneof.New%s(v, int(%s))", oneofName,
+		primitiveTypeDecode[g.field.GetType()].oneOfType, choiceName,
+	)
+}
+
+// oUnmarshalTextRepeatedField generates code that reads the values of a repeated
+// field, which are appended to the values that were read for the field before.
+func (g *generator) oUnmarshalTextRepeatedField() {
+	g.o(
+		`
+list := r.BeginList()
+for first := true; ; first = false {
+	if ok, err := r.NextRepeated(list, first); err != nil {
+		return err
+	} else if !ok {
+		break
+	}`,
+	)
+	g.i(1)
+	if isMessageField(g.field) {
+		g.o(
+			`
+elem := $fieldTypeMessagePool.Get()
+elem.$fieldTypeProtoMessage.Parent = &m._protoMessage
+m.$fieldName = append(m.$fieldName, elem)`,
+		)
+		g.oUnmarshalTextMessage("elem")
+	} else {
+		g.oUnmarshalTextValue(g.field, "v")
+		g.o(`m.$fieldName = append(m.$fieldName, v)`)
+	}
+	g.i(-1)
+	g.o(`}`)
+}
+
+func (g *generator) oUnmarshalTextMapField() {
+	g.setMapField()
+	_, key, value := g.mapEntry(g.field)
+
+	g.o(
+		`
+if m.$fieldName == nil {
+	m.$fieldName = map[$MapKeyType]$MapValueType{}
+}
+list := r.BeginList()
+for first := true; ; first = false {
+	if ok, err := r.NextRepeated(list, first); err != nil {
+		return err
+	} else if !ok {
+		break
+	}
+	if err := r.BeginMessage(); err != nil {
+		return err
+	}
+
+	// The entry is a message with key and value fields, both are optional.
+	var k $MapKeyType
+	var v $MapValueType
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "key":
+			if err := r.Colon(false); err != nil {
+				return err
+			}`,
+	)
+	g.i(3)
+	g.oUnmarshalTextValue(key, "kv")
+	g.o(`k = kv`)
+	g.i(-1)
+	g.o(`case "value":`)
+	g.i(1)
+	if isMessageField(value) {
+		g.o(
+			`
+if err := r.Colon(true); err != nil {
+	return err
+}
+if v != nil {
+	// Duplicate value, the last one wins.
+	$mapValuePool.Release(v)
+}
+v = $mapValuePool.Get()
+v.$mapValueProtoMessage.Parent = &m._protoMessage`,
+		)
+		g.oUnmarshalTextMessage("v")
+	} else {
+		g.o(
+			`
+if err := r.Colon(false); err != nil {
+	return err
+}`,
+		)
+		g.oUnmarshalTextValue(value, "vv")
+		g.o(`v = vv`)
+	}
+	g.i(-1)
+	g.o(
+		`
+default:
+	return fmt.Errorf("unknown field %%q in map entry of $MessageName.$fieldName", name)
+}`,
+	)
+	g.i(-2)
+	g.o(`	}`) // for
+
+	if isMessageField(value) {
+		g.o(
+			`
+	if v == nil {
+		// The value is absent, which means it is an empty message.
+		v = $mapValuePool.Get()
+		v.$mapValueProtoMessage.Parent = &m._protoMessage
+	}
+	if old, ok := m.$fieldName[k]; ok && old != nil {
+		// Duplicate key, the last one wins.
+		$mapValuePool.Release(old)
+	}`,
+		)
+	}
+	g.o(`	m.$fieldName[k] = v`)
+	g.o(`}`)
+}
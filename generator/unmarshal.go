@@ -5,7 +5,7 @@ import (
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	_ "github.com/jhump/protoreflect/desc/protoparse"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule/src/codec"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/codec"
 )
 
 func (g *generator) oUnmarshalFunc() error {
@@ -31,11 +31,35 @@ func Unmarshal$MessageName(bytes []byte, opts lazyproto.UnmarshalOpts) (*$Messag
 
 	m := $messagePool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
+
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// Unmarshal$MessageName(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *$MessageName) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validate$MessageName(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
 `,
 	)
 	return g.lastErr
@@ -58,13 +82,20 @@ func (m *$MessageName) decode() error {
 		g.o(``)
 	}
 
-	// Do a pass that only calculates the repeated field counts.
-	// This is needed to be able to allocate slices of the right length
-	// for repeated fields.
-	g.oCalcRepeatedFieldCounts()
+	if g.options.SinglePassDecode {
+		// Repeated fields grow as their elements are decoded.
+		g.oPrepareRepeatedFieldsAppend()
+		g.oMsgDecodeLoop(decodeFull)
+		g.oTrimRepeatedFields()
+	} else {
+		// Do a pass that only calculates the repeated field counts.
+		// This is needed to be able to allocate slices of the right length
+		// for repeated fields.
+		g.oCalcRepeatedFieldCounts()
 
-	// Now do another pass and actually decode the data into struct fields.
-	g.oMsgDecodeLoop(decodeFull)
+		// Now do another pass and actually decode the data into struct fields.
+		g.oMsgDecodeLoop(decodeFull)
+	}
 
 	g.i(-1)
 
@@ -88,7 +119,24 @@ func validate$MessageName(b []byte) error {
 	)
 
 	g.i(1)
+
+	required := g.requiredFields()
+	if len(required) > 0 {
+		g.o(`// Required fields that are seen so far.`)
+		for _, field := range required {
+			g.o(`%s := false`, requiredSeenVarName(field))
+		}
+		g.o(``)
+	}
+
 	g.oMsgDecodeLoop(decodeValidate)
+
+	for _, field := range required {
+		g.setField(field)
+		g.o(`if !%s {`, requiredSeenVarName(field))
+		g.o(`	return fmt.Errorf("required field $MessageName.$fieldName is missing")`)
+		g.o(`}`)
+	}
 	g.i(-1)
 
 	g.o(
@@ -119,6 +167,7 @@ var protoTypeToWireType = map[descriptor.FieldDescriptorProto_Type]codec.WireTyp
 	descriptor.FieldDescriptorProto_TYPE_SFIXED64: codec.WireFixed64,
 	descriptor.FieldDescriptorProto_TYPE_SINT32:   codec.WireVarint,
 	descriptor.FieldDescriptorProto_TYPE_SINT64:   codec.WireVarint,
+	descriptor.FieldDescriptorProto_TYPE_GROUP:    codec.WireStartGroup,
 }
 
 func (g *generator) oMsgDecodeLoop(mode decodeMode) error {
@@ -177,6 +226,19 @@ func (g *generator) oDecodeFastFields(mode decodeMode) (slowFields []*Field) {
 				g.o(`	buf.SkipByteUnsafe()`)
 				g.setField(field)
 				g.oDecodeField(mode, false)
+
+				if isPackable(field) {
+					// Repeated scalars can be also encoded in packed form.
+					g.o(
+						"case 0b0_%04b_%03b: // field number %d (%s), wire type %d (%s), packed",
+						field.GetNumber(), codec.WireBytes,
+						field.GetNumber(), field.GetName(), codec.WireBytes,
+						wireTypeToString[codec.WireBytes],
+					)
+					g.o(`	// Skip the one-byte varint.`)
+					g.o(`	buf.SkipByteUnsafe()`)
+					g.oDecodeFieldPacked(mode)
+				}
 				continue
 			}
 		}
@@ -186,6 +248,10 @@ func (g *generator) oDecodeFastFields(mode decodeMode) (slowFields []*Field) {
 }
 
 func (g *generator) oDecodeSlowFields(mode decodeMode, slowFields []*Field) {
+	if mode == decodeFull {
+		g.o(`// Remember where the field starts in case it is an unknown field.`)
+		g.o(`start := buf.Bytes()`)
+	}
 	g.o(
 		`
 v, err := buf.DecodeVarint()
@@ -205,7 +271,19 @@ if err != nil {
 		g.o(`case %d:`, field.GetNumber())
 		g.i(1)
 		g.o(`// Field %q`, field.GetName())
-		g.oDecodeField(mode, true)
+		if isPackable(field) {
+			g.o(`if wireType == codec.WireBytes {`)
+			g.i(1)
+			g.oDecodeFieldPacked(mode)
+			g.i(-1)
+			g.o(`} else {`)
+			g.i(1)
+			g.oDecodeField(mode, true)
+			g.i(-1)
+			g.o(`}`)
+		} else {
+			g.oDecodeField(mode, true)
+		}
 		g.i(-1)
 	}
 	g.o(`default:`)
@@ -213,6 +291,12 @@ if err != nil {
 	g.o(`	if err := buf.SkipFieldByWireType(wireType); err != nil {`)
 	g.o(`		return err`)
 	g.o(`	}`)
+	if mode == decodeFull {
+		g.o(`	if !m._protoMessage.IsDiscardUnknown() {`)
+		g.o(`		// Preserve the unknown field so that it can be marshalled later.`)
+		g.o(`		m._unknownFields.Add(start[:len(start)-buf.Len()])`)
+		g.o(`	}`)
+	}
 
 	g.o(`}`) // switch fieldNum
 }
@@ -227,13 +311,22 @@ func (g *generator) oDecodeField(mode decodeMode, checkWireType bool) {
 }
 
 func (g *generator) oDecodeFieldValidateOrFull(mode decodeMode, checkWireType bool) {
+	if mode == decodeValidate && g.field.IsRequired() {
+		g.o(`%s = true`, requiredSeenVarName(g.field))
+	}
+
+	if g.field.IsMap() {
+		g.oDecodeFieldMap(mode, checkWireType)
+		return
+	}
+
 	decode, ok := primitiveTypeDecode[g.field.GetType()]
 	if ok {
 		g.oDecodeFieldPrimitive(decode, mode, checkWireType)
 	} else if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
-		enumTypeName := g.enumDescrToEnum[g.field.GetEnumType()].GetName()
+		enumTypeName := g.enumTypeName(g.field.GetEnumType())
 		g.oDecodeFieldEnum(enumTypeName, mode, checkWireType)
-	} else if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+	} else if isMessageField(g.field) {
 		g.oDecodeFieldEmbeddedMessage(mode, checkWireType)
 	} else {
 		g.lastErr = fmt.Errorf("unsupported field type %v", g.field.GetType())
@@ -241,28 +334,102 @@ func (g *generator) oDecodeFieldValidateOrFull(mode decodeMode, checkWireType bo
 }
 
 func (g *generator) oCalcRepeatFieldCount() {
-	if g.field.IsRepeated() {
+	if g.field.IsRepeated() && !g.field.IsMap() {
 		counterName := g.field.GetName() + "Count"
 		g.o(`	%s++`, counterName)
-		g.o(`	buf.SkipRawBytes()`)
-	} else {
-		// We are only interested in repeat fields. Skip any other fields.
-		g.oSkipFieldByWireType()
 	}
+	// We only need to count the fields, skip the value.
+	g.oSkipFieldByWireType()
 }
 
 func (g *generator) oSkipFieldByWireType() {
 	wireType := protoTypeToWireType[g.field.GetType()]
 	switch wireType {
 	case codec.WireVarint:
-		g.o(`buf.SkipVarint()`)
+		g.o(`if err := buf.SkipVarint(); err != nil {`)
 	case codec.WireFixed64:
-		g.o(`buf.SkipFixed64()`)
+		g.o(`if err := buf.SkipFixed64(); err != nil {`)
 	case codec.WireBytes:
-		g.o(`buf.SkipRawBytes()`)
+		g.o(`if err := buf.SkipRawBytes(); err != nil {`)
 	case codec.WireFixed32:
-		g.o(`buf.SkipFixed32()`)
+		g.o(`if err := buf.SkipFixed32(); err != nil {`)
+	case codec.WireStartGroup:
+		g.o(`if err := buf.SkipGroup(); err != nil {`)
 	}
+	g.o(`	return err`)
+	g.o(`}`)
+}
+
+// isPackable returns true if the field is a repeated field of scalar numeric type.
+// Such fields may be encoded in packed form on the wire.
+func isPackable(field *Field) bool {
+	if !field.IsRepeated() {
+		return false
+	}
+	decode, ok := scalarTypeDecode(field)
+	return ok && decode.expectedWireType != codec.WireBytes
+}
+
+// scalarTypeDecode returns the decoding of the non-message field. Enums are decoded
+// as uint32 and converted to the enum type.
+func scalarTypeDecode(field *Field) (decodePrimitive, bool) {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+		return enumDecode, true
+	}
+	decode, ok := primitiveTypeDecode[field.GetType()]
+	return decode, ok
+}
+
+// oDecodeFieldPacked generates code for decoding the current field that is in
+// packed form on the wire.
+func (g *generator) oDecodeFieldPacked(mode decodeMode) {
+	decode, _ := scalarTypeDecode(g.field)
+	counterName := g.field.GetName() + "Count"
+
+	g.o(
+		`
+// Get the bytes of all packed elements.
+v, err := buf.DecodeRawBytes()
+if err != nil {
+	return err
+}`,
+	)
+
+	if mode == decodeCountRepeat {
+		switch decode.expectedWireType {
+		case codec.WireFixed32:
+			g.o(`%s += len(v) / 4`, counterName)
+		case codec.WireFixed64:
+			g.o(`%s += len(v) / 8`, counterName)
+		default:
+			g.o(`%s += codec.CountPackedVarints(v)`, counterName)
+		}
+		return
+	}
+
+	g.o(`packed := codec.NewBuffer(v)`)
+	g.o(`for !packed.EOF() {`)
+	g.i(1)
+	if mode == decodeValidate {
+		g.o(`if _, err := packed.As%s(); err != nil {`, decode.asProtoType)
+		g.o(`	return err`)
+		g.o(`}`)
+	} else {
+		g.o(
+			`
+elem, err := packed.As%s()
+if err != nil {
+	return err
+}`, decode.asProtoType,
+		)
+		if g.field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+			g.oStoreRepeatedElem(g.enumTypeName(g.field.GetEnumType())+"(elem)", counterName)
+		} else {
+			g.oStoreRepeatedElem("elem", counterName)
+		}
+	}
+	g.i(-1)
+	g.o(`}`)
 }
 
 type decodePrimitive struct {
@@ -333,18 +500,12 @@ if err != nil {
 		)
 	} else if g.field.IsRepeated() {
 		// Repeated field. Store at the right index of the slice.
-		counterName := g.field.GetName() + "Count"
-		g.o(
-			`
-// The slice is pre-allocated, assign to the appropriate index.
-m.$fieldName[%[1]s] = v
-%[1]s++`, counterName,
-		)
+		g.oStoreRepeatedElem("v", g.field.GetName()+"Count")
 	} else {
 		// Regular, non-repeated, non-oneof field.
 		g.o(`m.$fieldName = v`)
-		if g.options.WithPresence {
-			g.o(`m._flags |= %s`, g.msg.PresenceFlagName[g.field])
+		if flagName, ok := g.msg.PresenceFlagName[g.field]; ok {
+			g.o(`m._flags |= %s`, flagName)
 		}
 	}
 }
@@ -374,9 +535,14 @@ if err != nil {
 		return
 	}
 
+	if g.field.IsRepeated() {
+		g.oStoreRepeatedElem(enumTypeName+"(v)", g.field.GetName()+"Count")
+		return
+	}
+
 	g.o(`m.$fieldName = %s(v)`, enumTypeName)
-	if g.options.WithPresence {
-		g.o(`m._flags |= %s`, g.msg.PresenceFlagName[g.field])
+	if flagName, ok := g.msg.PresenceFlagName[g.field]; ok {
+		g.o(`m._flags |= %s`, flagName)
 	}
 }
 
@@ -388,6 +554,12 @@ const (
 	decodeCountRepeat decodeMode = 2
 )
 
+// enumDecode is the decoding of the enum fields.
+var enumDecode = decodePrimitive{
+	asProtoType:      "Uint32",
+	expectedWireType: codec.WireVarint,
+}
+
 var primitiveTypeDecode = map[descriptor.FieldDescriptorProto_Type]decodePrimitive{
 	descriptor.FieldDescriptorProto_TYPE_BOOL: {
 		asProtoType:      "Bool",
@@ -397,13 +569,13 @@ var primitiveTypeDecode = map[descriptor.FieldDescriptorProto_Type]decodePrimiti
 
 	descriptor.FieldDescriptorProto_TYPE_FIXED64: {
 		asProtoType:      "Fixed64",
-		oneOfType:        "Int64",
+		oneOfType:        "Uint64",
 		expectedWireType: codec.WireFixed64,
 	},
 
 	descriptor.FieldDescriptorProto_TYPE_UINT64: {
 		asProtoType:      "Uint64",
-		oneOfType:        "Uint32",
+		oneOfType:        "Uint64",
 		expectedWireType: codec.WireVarint,
 	},
 
@@ -419,15 +591,33 @@ var primitiveTypeDecode = map[descriptor.FieldDescriptorProto_Type]decodePrimiti
 		expectedWireType: codec.WireVarint,
 	},
 
+	descriptor.FieldDescriptorProto_TYPE_SINT64: {
+		asProtoType:      "Sint64",
+		oneOfType:        "Int64",
+		expectedWireType: codec.WireVarint,
+	},
+
 	descriptor.FieldDescriptorProto_TYPE_FIXED32: {
 		asProtoType:      "Fixed32",
+		oneOfType:        "Uint32",
+		expectedWireType: codec.WireFixed32,
+	},
+
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: {
+		asProtoType:      "SFixed32",
 		oneOfType:        "Int32",
 		expectedWireType: codec.WireFixed32,
 	},
 
 	descriptor.FieldDescriptorProto_TYPE_SINT32: {
 		asProtoType:      "Sint32",
-		oneOfType:        "Sint32",
+		oneOfType:        "Int32",
+		expectedWireType: codec.WireVarint,
+	},
+
+	descriptor.FieldDescriptorProto_TYPE_INT32: {
+		asProtoType:      "Int32",
+		oneOfType:        "Int32",
 		expectedWireType: codec.WireVarint,
 	},
 
@@ -443,6 +633,12 @@ var primitiveTypeDecode = map[descriptor.FieldDescriptorProto_Type]decodePrimiti
 		expectedWireType: codec.WireFixed64,
 	},
 
+	descriptor.FieldDescriptorProto_TYPE_FLOAT: {
+		asProtoType:      "Float",
+		oneOfType:        "Float",
+		expectedWireType: codec.WireFixed32,
+	},
+
 	descriptor.FieldDescriptorProto_TYPE_STRING: {
 		asProtoType:      "StringUnsafe",
 		oneOfType:        "String",
@@ -460,10 +656,34 @@ func (g *generator) oDecodeFieldEmbeddedMessage(mode decodeMode, checkWireType b
 	if checkWireType {
 		g.o(
 			`
-if wireType != codec.WireBytes {
+if wireType != codec.Wire%s {
 	return fmt.Errorf("invalid wire type %%d for field number %d ($MessageName.$fieldName)", wireType)
-}`, g.field.GetNumber(),
+}`, wireTypeToString[protoTypeToWireType[g.field.GetType()]], g.field.GetNumber(),
+		)
+	}
+
+	if isGroupField(g.field) {
+		// The group content is everything up to the matching end group key.
+		g.o(
+			`
+// Get the bytes of the group.
+v, err := buf.ReadGroup(false)
+if err != nil {
+	return err
+}`,
 		)
+		if mode == decodeValidate {
+			g.o(
+				`
+err = $fieldTypeValidate(v)
+if err != nil {
+	return err
+}`,
+			)
+			return
+		}
+		g.oDecodeEmbeddedMessageBytes()
+		return
 	}
 
 	if mode == decodeValidate {
@@ -474,7 +694,7 @@ v, err := buf.DecodeRawBytes()
 if err != nil {
 	return err
 }
-err = validate$FieldMessageTypeName(v)
+err = $fieldTypeValidate(v)
 if err != nil {
 	return err
 }`,
@@ -489,37 +709,54 @@ if err != nil {
 }
 `,
 		)
+		g.oDecodeEmbeddedMessageBytes()
+	}
+}
 
-		if g.field.IsRepeated() {
-			counterName := g.field.GetName() + "Count"
+// oDecodeEmbeddedMessageBytes generates code that stores the bytes of the embedded
+// message in the variable v into the current field. The message is decoded lazily.
+func (g *generator) oDecodeEmbeddedMessageBytes() {
+	if g.field.IsRepeated() {
+		counterName := g.field.GetName() + "Count"
+		if g.options.SinglePassDecode {
 			g.o(
 				`
-// The slice is pre-allocated, assign to the appropriate index.
-elem := m.$fieldName[%[1]s]
-%[1]s++
-elem._protoMessage.Parent = &m._protoMessage
-elem._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)`, counterName,
+if %[1]s == len(m.$fieldName) {
+	// No structs left in the slice. Grow the slice by append, which amortizes
+	// the growth, and get the structs for all new elements from the pool at once.
+	m.$fieldName = append(m.$fieldName, nil)
+	m.$fieldName = m.$fieldName[:cap(m.$fieldName)]
+	$fieldTypeMessagePool.GetSlice(m.$fieldName[%[1]s:])
+}`, counterName,
 			)
-		} else if g.field.GetOneOf() != nil {
-			choiceName := composeOneOfChoiceName(g.msg, g.field)
-			g.o(
-				`
+		} else {
+			g.o(`// The slice is pre-allocated, assign to the appropriate index.`)
+		}
+		g.o(
+			`elem := m.$fieldName[%[1]s]
+%[1]s++
+elem.$fieldTypeProtoMessage.Parent = &m._protoMessage
+elem.$fieldTypeProtoMessage.Bytes = protomessage.BytesViewFromBytes(v)`, counterName,
+		)
+	} else if g.field.GetOneOf() != nil {
+		choiceName := composeOneOfChoiceName(g.msg, g.field)
+		g.o(
+			`
 // Get a struct for the embedded message from the pool.
 elem := $fieldTypeMessagePool.Get()
-elem._protoMessage.Parent = &m._protoMessage
-elem._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)
+elem.$fieldTypeProtoMessage.Parent = &m._protoMessage
+elem.$fieldTypeProtoMessage.Bytes = protomessage.BytesViewFromBytes(v)
 m.%s = oneof.NewPtr(unsafe.Pointer(elem), int(%s))`,
-				g.field.GetOneOf().GetName(), choiceName,
-			)
-		} else {
-			g.o(
-				`
+			g.field.GetOneOf().GetName(), choiceName,
+		)
+	} else {
+		g.o(
+			`
 // Get a struct for the embedded message from the pool.
 m.$fieldName = $fieldTypeMessagePool.Get()
-m.$fieldName._protoMessage.Parent = &m._protoMessage
-m.$fieldName._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)`,
-			)
-		}
+m.$fieldName.$fieldTypeProtoMessage.Parent = &m._protoMessage
+m.$fieldName.$fieldTypeProtoMessage.Bytes = protomessage.BytesViewFromBytes(v)`,
+		)
 	}
 }
 
@@ -547,7 +784,7 @@ func (g *generator) oCalcRepeatedFieldCounts() {
 		g.setField(field)
 		counterName := field.GetName() + "Count"
 
-		if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if isMessageField(field) {
 			g.o(`if cap(m.$fieldName) < %s {`, counterName)
 			g.o(`	// Need new space.`)
 			g.o(`	m.$fieldName = make(%s, %s)`, g.convertTypeToGo(field), counterName)
@@ -576,10 +813,74 @@ func (g *generator) oCalcRepeatedFieldCounts() {
 	}
 }
 
+// oStoreRepeatedElem generates code that stores the decoded element in the variable
+// elemVar into the current repeated scalar field.
+func (g *generator) oStoreRepeatedElem(elemVar string, counterName string) {
+	if g.options.SinglePassDecode {
+		g.o(`m.$fieldName = append(m.$fieldName, %s)`, elemVar)
+		return
+	}
+	g.o(
+		`
+// The slice is pre-allocated, assign to the appropriate index.
+m.$fieldName[%[1]s] = %[2]s
+%[1]s++`, counterName, elemVar,
+	)
+}
+
+// oPrepareRepeatedFieldsAppend generates code that prepares the repeated fields for
+// appending the elements by the single decoding pass.
+func (g *generator) oPrepareRepeatedFieldsAppend() {
+	fields := g.getRepeatedFields()
+	if len(fields) == 0 {
+		return
+	}
+
+	g.o(``)
+	g.o(`// Prepare repeated fields for appending the decoded elements.`)
+	for _, field := range fields {
+		g.setField(field)
+		if isMessageField(field) {
+			// The structs are taken from the pool in chunks, the counter tracks
+			// how many of them are used.
+			g.o(`%sCount := 0`, field.GetName())
+			g.o(`m.$fieldName = m.$fieldName[:0]`)
+		} else {
+			// Don't reuse the backing array, the previously returned slice may
+			// still be referenced by the user.
+			g.o(`m.$fieldName = nil`)
+		}
+	}
+	g.o(``)
+}
+
+// oTrimRepeatedFields generates code that returns the structs that were taken
+// from the pool but are not used by the repeated message fields.
+func (g *generator) oTrimRepeatedFields() {
+	first := true
+	for _, field := range g.getRepeatedFields() {
+		if !isMessageField(field) {
+			continue
+		}
+		g.setField(field)
+		if first {
+			g.o(``)
+			g.o(`// Return the unused structs to the pool.`)
+			first = false
+		}
+		counterName := field.GetName() + "Count"
+		g.o(`if %s < len(m.$fieldName) {`, counterName)
+		g.o(`	$fieldTypeMessagePool.ReleaseSlice(m.$fieldName[%s:])`, counterName)
+		g.o(`	m.$fieldName = m.$fieldName[:%s]`, counterName)
+		g.o(`}`)
+	}
+}
+
 func (g *generator) getRepeatedFields() []*Field {
 	var r []*Field
 	for _, field := range g.msg.Fields {
-		if field.IsRepeated() {
+		// Map entries are decoded lazily on first access, they don't need pre-allocation.
+		if field.IsRepeated() && !field.IsMap() {
 			r = append(r, field)
 		}
 	}
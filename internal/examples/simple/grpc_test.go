@@ -0,0 +1,119 @@
+//go:build !sizedstream && !backwardstream
+
+package simple
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	gogolib "github.com/gogo/protobuf/proto"
+	googlemsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/google/gen/logs"
+	lazymsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/grpccodec"
+	googlelib "google.golang.org/protobuf/proto"
+)
+
+const exportMethod = "/test.LogsService/Export"
+
+// logsServer echoes the received messages and keeps them to check that they
+// remain readable after the RPCs complete.
+type logsServer struct {
+	mux      sync.Mutex
+	received []*lazymsg.LogsData
+}
+
+func (s *logsServer) export(
+	_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor,
+) (any, error) {
+	req := lazymsg.NewLogsData()
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	s.mux.Lock()
+	s.received = append(s.received, req)
+	s.mux.Unlock()
+	return req, nil
+}
+
+func startLogsServer(t *testing.T, srv *logsServer) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.ForceServerCodecV2(grpccodec.CodecV2{}))
+	s.RegisterService(
+		&grpc.ServiceDesc{
+			ServiceName: "test.LogsService",
+			HandlerType: (*any)(nil),
+			Methods: []grpc.MethodDesc{
+				{MethodName: "Export", Handler: srv.export},
+			},
+		}, srv,
+	)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCCodec(t *testing.T) {
+	srv := &logsServer{}
+	conn := startLogsServer(t, srv)
+	ctx := context.Background()
+
+	var expected []*googlemsg.LogsData
+	for i := 1; i <= 5; i++ {
+		goldenWireBytes, err := gogolib.Marshal(createLogsData(scaleCount, i))
+		require.NoError(t, err)
+		var google googlemsg.LogsData
+		require.NoError(t, googlelib.Unmarshal(goldenWireBytes, &google))
+		expected = append(expected, &google)
+
+		if i%2 == 0 {
+			// The client uses the default codec.
+			var resp googlemsg.LogsData
+			require.NoError(t, conn.Invoke(ctx, exportMethod, &google, &resp))
+			assert.True(t, googlelib.Equal(&google, &resp))
+			continue
+		}
+
+		// The client uses the lazy codec too.
+		req, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+		require.NoError(t, err)
+		resp := lazymsg.NewLogsData()
+		require.NoError(
+			t, conn.Invoke(ctx, exportMethod, req, resp, grpc.ForceCodecV2(grpccodec.CodecV2{})),
+		)
+		assert.False(t, resp.IsModified())
+		assert.EqualValues(t, goldenWireBytes, marshalLazy(t, resp))
+		req.Free()
+		resp.Free()
+	}
+
+	// The messages received by the server still reference valid bytes.
+	require.Len(t, srv.received, len(expected))
+	for i, lazy := range srv.received {
+		var google googlemsg.LogsData
+		require.NoError(t, lazy.ToProto(&google))
+		assert.True(t, googlelib.Equal(expected[i], &google))
+		lazy.Free()
+	}
+}
@@ -1,24 +1,47 @@
 // Code generated by lazyproto. DO NOT EDIT.
 // source: logs.proto
 
-package simple
+package logs
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"unsafe"
 
 	"github.com/tigrannajaryan/exp-lazyproto"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/oneof"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/protomessage"
-
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule/src/codec"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/jsonstream"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/lazyreflect"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/oneof"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protoconv"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/textstream"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/codec"
 )
 
-var _ = oneof.OneOf{}       // To avoid unused import warning.
-var _ = unsafe.Pointer(nil) // To avoid unused import warning.
-var _ = fmt.Errorf          // To avoid unused import warning.
+var _ = oneof.OneOf{}            // To avoid unused import warning.
+var _ = unsafe.Pointer(nil)      // To avoid unused import warning.
+var _ = fmt.Errorf               // To avoid unused import warning.
+var _ = bytes.Equal              // To avoid unused import warning.
+var _ = sort.SliceStable         // To avoid unused import warning.
+var _ = math.Inf                 // To avoid unused import warning.
+var _ = jsonstream.NewWriter     // To avoid unused import warning.
+var _ = textstream.NewWriter     // To avoid unused import warning.
+var _ = lazyreflect.RegisterFile // To avoid unused import warning.
+var _ = protoreflect.ValueOf     // To avoid unused import warning.
+var _ = protoconv.Marshal        // To avoid unused import warning.
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protomessage.EnforceVersion(12 - protomessage.MinVersion)
+	// Verify that the runtime is sufficiently up-to-date.
+	_ = protomessage.EnforceVersion(protomessage.MaxVersion - 12)
+)
 
 // SeverityNumber values
 type SeverityNumber uint32
@@ -52,17 +75,90 @@ const (
 	SeverityNumber_SEVERITY_NUMBER_FATAL4      SeverityNumber = 24
 )
 
+// SeverityNumber_name maps the values of SeverityNumber to the names of the values.
+var SeverityNumber_name = map[uint32]string{
+	0:  "SEVERITY_NUMBER_UNSPECIFIED",
+	1:  "SEVERITY_NUMBER_TRACE",
+	2:  "SEVERITY_NUMBER_TRACE2",
+	3:  "SEVERITY_NUMBER_TRACE3",
+	4:  "SEVERITY_NUMBER_TRACE4",
+	5:  "SEVERITY_NUMBER_DEBUG",
+	6:  "SEVERITY_NUMBER_DEBUG2",
+	7:  "SEVERITY_NUMBER_DEBUG3",
+	8:  "SEVERITY_NUMBER_DEBUG4",
+	9:  "SEVERITY_NUMBER_INFO",
+	10: "SEVERITY_NUMBER_INFO2",
+	11: "SEVERITY_NUMBER_INFO3",
+	12: "SEVERITY_NUMBER_INFO4",
+	13: "SEVERITY_NUMBER_WARN",
+	14: "SEVERITY_NUMBER_WARN2",
+	15: "SEVERITY_NUMBER_WARN3",
+	16: "SEVERITY_NUMBER_WARN4",
+	17: "SEVERITY_NUMBER_ERROR",
+	18: "SEVERITY_NUMBER_ERROR2",
+	19: "SEVERITY_NUMBER_ERROR3",
+	20: "SEVERITY_NUMBER_ERROR4",
+	21: "SEVERITY_NUMBER_FATAL",
+	22: "SEVERITY_NUMBER_FATAL2",
+	23: "SEVERITY_NUMBER_FATAL3",
+	24: "SEVERITY_NUMBER_FATAL4",
+}
+
+// SeverityNumber_value maps the names of the values of SeverityNumber to the values.
+var SeverityNumber_value = map[string]uint32{
+	"SEVERITY_NUMBER_UNSPECIFIED": 0,
+	"SEVERITY_NUMBER_TRACE":       1,
+	"SEVERITY_NUMBER_TRACE2":      2,
+	"SEVERITY_NUMBER_TRACE3":      3,
+	"SEVERITY_NUMBER_TRACE4":      4,
+	"SEVERITY_NUMBER_DEBUG":       5,
+	"SEVERITY_NUMBER_DEBUG2":      6,
+	"SEVERITY_NUMBER_DEBUG3":      7,
+	"SEVERITY_NUMBER_DEBUG4":      8,
+	"SEVERITY_NUMBER_INFO":        9,
+	"SEVERITY_NUMBER_INFO2":       10,
+	"SEVERITY_NUMBER_INFO3":       11,
+	"SEVERITY_NUMBER_INFO4":       12,
+	"SEVERITY_NUMBER_WARN":        13,
+	"SEVERITY_NUMBER_WARN2":       14,
+	"SEVERITY_NUMBER_WARN3":       15,
+	"SEVERITY_NUMBER_WARN4":       16,
+	"SEVERITY_NUMBER_ERROR":       17,
+	"SEVERITY_NUMBER_ERROR2":      18,
+	"SEVERITY_NUMBER_ERROR3":      19,
+	"SEVERITY_NUMBER_ERROR4":      20,
+	"SEVERITY_NUMBER_FATAL":       21,
+	"SEVERITY_NUMBER_FATAL2":      22,
+	"SEVERITY_NUMBER_FATAL3":      23,
+	"SEVERITY_NUMBER_FATAL4":      24,
+}
+
 // ====================== LogsData message implementation ======================
 
 // LogsData contains all log data
 type LogsData struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_LogsData
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_LogsData
+	_unknownFields protomessage.UnknownFields
 
 	// List of ResourceLogs
 	resourceLogs []*ResourceLogs
 }
 
+// NewLogsData returns an empty LogsData message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewLogsData() *LogsData {
+	return logsDataPool.Get()
+}
+
+// NewLogsDataSlice returns a slice of n empty LogsData messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewLogsDataSlice(n int) []*LogsData {
+	r := make([]*LogsData, n)
+	logsDataPool.GetSlice(r)
+	return r
+}
+
 // UnmarshalLogsData unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -83,16 +179,129 @@ func UnmarshalLogsData(bytes []byte, opts lazyproto.UnmarshalOpts) (*LogsData, e
 
 	m := logsDataPool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalLogsData(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *LogsData) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateLogsData(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *LogsData) Free() {
 	logsDataPool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *LogsData) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *LogsData) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*LogsData)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *LogsData) Clone() *LogsData {
+	c := logsDataPool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *LogsData) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *LogsData) cloneInto(c *LogsData) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	// Clone resourceLogs elements into structs taken from the pool all at once.
+	if cap(c.resourceLogs) < len(m.resourceLogs) {
+		c.resourceLogs = make([]*ResourceLogs, len(m.resourceLogs))
+	} else {
+		c.resourceLogs = c.resourceLogs[:len(m.resourceLogs)]
+	}
+	resourceLogsPool.GetSlice(c.resourceLogs)
+	for i, elem := range m.resourceLogs {
+		elem.cloneInto(c.resourceLogs[i])
+		c.resourceLogs[i]._protoMessage.Parent = &c._protoMessage
+	}
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *LogsData) Equal(other *LogsData) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	{
+		a, b := m.ResourceLogs(), other.ResourceLogs()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_LogsData is the type of the bit flags.
 type flags_LogsData uint8
 
@@ -100,30 +309,49 @@ type flags_LogsData uint8
 const flags_LogsData_ResourceLogs_Decoded flags_LogsData = 0x1
 
 // ResourceLogs returns the value of the resourceLogs.
-func (m *LogsData) ResourceLogs() (r []*ResourceLogs) {
+func (m *LogsData) ResourceLogs() (r ResourceLogsSlice) {
 	if m._flags&flags_LogsData_ResourceLogs_Decoded == 0 {
 		m.decodeResourceLogs()
 	}
-	return m.resourceLogs
+	return ResourceLogsSlice{elems: &m.resourceLogs, parent: &m._protoMessage}
 }
 
 // This is noinline, so that ResourceLogs() is inlined instead.
+//
 //go:noinline
 func (m *LogsData) decodeResourceLogs() {
-	// Decode nested message(s).
-	for i := range m.resourceLogs {
-		// TODO: decide how to handle decoding errors.
-		_ = m.resourceLogs[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.resourceLogs {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_LogsData_ResourceLogs_Decoded
 }
 
+// ResourceLogsE is the same as ResourceLogs, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *LogsData) ResourceLogsE() (r ResourceLogsSlice, err error) {
+	r = m.ResourceLogs()
+	for _, elem := range m.resourceLogs {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetResourceLogs sets the value of the resourceLogs.
 func (m *LogsData) SetResourceLogs(v []*ResourceLogs) {
 	m.resourceLogs = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_LogsData_ResourceLogs_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.resourceLogs {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
@@ -131,32 +359,11 @@ func (m *LogsData) SetResourceLogs(v []*ResourceLogs) {
 	m._protoMessage.MarkModified()
 }
 
-func (m *LogsData) ResourceLogsRemoveIf(f func(*ResourceLogs) bool) {
-	// Call getter to load the field.
-	m.ResourceLogs()
-
-	newLen := 0
-	for i := 0; i < len(m.resourceLogs); i++ {
-		if f(m.resourceLogs[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.resourceLogs[newLen] != nil {
-			m.resourceLogs[newLen].Free()
-		}
-		m.resourceLogs[newLen] = m.resourceLogs[i]
-		m.resourceLogs[i] = nil
-		newLen++
-	}
-	if newLen != len(m.resourceLogs) {
-		m.resourceLogs = m.resourceLogs[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
+// UnknownFields returns the wire representation of the fields that are not
+// known to the LogsData schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *LogsData) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
 }
 
 func validateLogsData(b []byte) error {
@@ -221,7 +428,9 @@ func (m *LogsData) decode() error {
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			resourceLogsCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -282,6 +491,8 @@ func (m *LogsData) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -297,25 +508,81 @@ func (m *LogsData) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *LogsData) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *LogsData) calcSize() int {
+	size := 0
+	// Size of "resourceLogs".
+	for _, elem := range m.resourceLogs {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_LogsData_ResourceLogs = molecule.PrepareEmbeddedField(1)
 
 func (m *LogsData) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "resourceLogs".
 		for _, elem := range m.resourceLogs {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_LogsData_ResourceLogs, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_LogsData_ResourceLogs)
 			}
-			ps.EndEmbeddedPrepared(token, prepared_LogsData_ResourceLogs)
 		}
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
 	} else {
 		// We have the original bytes and the message is unchanged. Use the original bytes.
 		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
@@ -323,6 +590,419 @@ func (m *LogsData) Marshal(ps *molecule.ProtoStream) error {
 	return nil
 }
 
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *LogsData) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *LogsData) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *LogsData) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := logsDataPool.Get()
+	defer logsDataPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *LogsData) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if len(m.resourceLogs) > 0 {
+		js.Name("resourceLogs")
+		js.BeginArray()
+		for _, elem := range m.resourceLogs {
+			if m._flags&flags_LogsData_ResourceLogs_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *LogsData) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_LogsData_ResourceLogs_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "resourceLogs", "resource_logs":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := resourceLogsPool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.resourceLogs = append(m.resourceLogs, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown field %q in LogsData", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *LogsData) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *LogsData) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *LogsData) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := logsDataPool.Get()
+	defer logsDataPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *LogsData) MarshalTextStream(tw *textstream.Writer) error {
+	for _, elem := range m.resourceLogs {
+		tw.Name("resource_logs")
+		tw.BeginMessage()
+		if m._flags&flags_LogsData_ResourceLogs_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *LogsData) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_LogsData_ResourceLogs_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "resource_logs":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := resourceLogsPool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.resourceLogs = append(m.resourceLogs, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown field %q in LogsData", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *LogsData) ProtoReflect() protoreflect.Message {
+	return logsDataReflectInfo.MessageOf(m)
+}
+
+// reflectResourceLogs returns the view of the resourceLogs list.
+func (m *LogsData) reflectResourceLogs() protoreflect.List {
+	if m._flags&flags_LogsData_ResourceLogs_Decoded == 0 {
+		m.decodeResourceLogs()
+	}
+	return lazyreflect.NewMessageList(&m.resourceLogs, &m._protoMessage, NewResourceLogs)
+}
+
+var logsDataReflectInfo = &lazyreflect.MessageInfo[LogsData]{
+	File:          file_logs_proto,
+	FullName:      "simple.LogsData",
+	NewMessage:    NewLogsData,
+	UnknownFields: (*LogsData).UnknownFields,
+	SetUnknownFields: func(m *LogsData, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[LogsData]{
+		{
+			// resource_logs
+			Has: func(m *LogsData) bool { return len(m.resourceLogs) > 0 },
+			Get: func(m *LogsData) protoreflect.Value { return protoreflect.ValueOfList(m.reflectResourceLogs()) },
+			Set: func(m *LogsData, v protoreflect.Value) {
+				m.SetResourceLogs(lazyreflect.MessagesFromList(v.List(), NewResourceLogs))
+			},
+			Clear:   func(m *LogsData) { m.SetResourceLogs(nil) },
+			Mutable: func(m *LogsData) protoreflect.Value { return protoreflect.ValueOfList(m.reflectResourceLogs()) },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *LogsData) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *LogsData) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// LogsDataSlice is a repeated field of LogsData messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type LogsDataSlice struct {
+	elems  *[]*LogsData
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s LogsDataSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s LogsDataSlice) At(i int) *LogsData {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s LogsDataSlice) Range(f func(i int, elem *LogsData) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s LogsDataSlice) Append(elems ...*LogsData) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s LogsDataSlice) AppendNew() *LogsData {
+	elem := logsDataPool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s LogsDataSlice) InsertAt(i int, elem *LogsData) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s LogsDataSlice) RemoveAt(i int) {
+	elems := *s.elems
+	logsDataPool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s LogsDataSlice) RemoveIf(f func(elem *LogsData) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			logsDataPool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s LogsDataSlice) Sort(less func(a, b *LogsData) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *LogsData) reset() {
+	elem := m
+
+	// Release nested resourceLogs recursively to their pool.
+	resourceLogsPool.ReleaseSlice(elem.resourceLogs)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.resourceLogs = elem.resourceLogs[:0]
+}
+
 // Pool of LogsData structs.
 type logsDataPoolType struct {
 	pool []*LogsData
@@ -385,13 +1065,7 @@ func (p *logsDataPoolType) GetSlice(r []*LogsData) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *logsDataPoolType) ReleaseSlice(slice []*LogsData) {
 	for _, elem := range slice {
-		// Release nested resourceLogs recursively to their pool.
-		resourceLogsPool.ReleaseSlice(elem.resourceLogs)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.resourceLogs = elem.resourceLogs[:0]
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -403,13 +1077,7 @@ func (p *logsDataPoolType) ReleaseSlice(slice []*LogsData) {
 
 // Release an element back to the pool.
 func (p *logsDataPoolType) Release(elem *LogsData) {
-	// Release nested resourceLogs recursively to their pool.
-	resourceLogsPool.ReleaseSlice(elem.resourceLogs)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.resourceLogs = elem.resourceLogs[:0]
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -418,11 +1086,50 @@ func (p *logsDataPoolType) Release(elem *LogsData) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *LogsData) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *LogsData) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *LogsData) XXX_CloneInto(c *LogsData) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *LogsData) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *LogsData) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateLogsData is for use by the code generated for other packages only.
+func XXX_ValidateLogsData(b []byte) error {
+	return validateLogsData(b)
+}
+
+// XXX_NewLogsDataSlice is for use by the code generated for other packages only.
+func XXX_NewLogsDataSlice(elems *[]*LogsData, parent *protomessage.ProtoMessage) LogsDataSlice {
+	return LogsDataSlice{elems: elems, parent: parent}
+}
+
+// XXX_LogsDataPool is for use by the code generated for other packages only.
+var XXX_LogsDataPool = &logsDataPool
+
 // ====================== ResourceLogs message implementation ======================
 
 type ResourceLogs struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_ResourceLogs
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_ResourceLogs
+	_unknownFields protomessage.UnknownFields
 
 	// The Resource
 	resource *Resource
@@ -432,6 +1139,20 @@ type ResourceLogs struct {
 	schemaUrl string
 }
 
+// NewResourceLogs returns an empty ResourceLogs message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewResourceLogs() *ResourceLogs {
+	return resourceLogsPool.Get()
+}
+
+// NewResourceLogsSlice returns a slice of n empty ResourceLogs messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewResourceLogsSlice(n int) []*ResourceLogs {
+	r := make([]*ResourceLogs, n)
+	resourceLogsPool.GetSlice(r)
+	return r
+}
+
 // UnmarshalResourceLogs unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -452,15 +1173,140 @@ func UnmarshalResourceLogs(bytes []byte, opts lazyproto.UnmarshalOpts) (*Resourc
 
 	m := resourceLogsPool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (m *ResourceLogs) Free() {
-	resourceLogsPool.Release(m)
-}
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalResourceLogs(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *ResourceLogs) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateResourceLogs(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
+func (m *ResourceLogs) Free() {
+	resourceLogsPool.Release(m)
+}
+
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *ResourceLogs) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *ResourceLogs) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*ResourceLogs)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *ResourceLogs) Clone() *ResourceLogs {
+	c := resourceLogsPool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *ResourceLogs) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *ResourceLogs) cloneInto(c *ResourceLogs) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	if m.resource != nil {
+		c.resource = resourcePool.Get()
+		m.resource.cloneInto(c.resource)
+		c.resource._protoMessage.Parent = &c._protoMessage
+	}
+	// Clone scopeLogs elements into structs taken from the pool all at once.
+	if cap(c.scopeLogs) < len(m.scopeLogs) {
+		c.scopeLogs = make([]*ScopeLogs, len(m.scopeLogs))
+	} else {
+		c.scopeLogs = c.scopeLogs[:len(m.scopeLogs)]
+	}
+	scopeLogsPool.GetSlice(c.scopeLogs)
+	for i, elem := range m.scopeLogs {
+		elem.cloneInto(c.scopeLogs[i])
+		c.scopeLogs[i]._protoMessage.Parent = &c._protoMessage
+	}
+	c.schemaUrl = m.schemaUrl
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *ResourceLogs) Equal(other *ResourceLogs) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if !m.Resource().Equal(other.Resource()) {
+		return false
+	}
+	{
+		a, b := m.ScopeLogs(), other.ScopeLogs()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	if m.SchemaUrl() != other.SchemaUrl() {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
 
 // flags_ResourceLogs is the type of the bit flags.
 type flags_ResourceLogs uint8
@@ -478,53 +1324,91 @@ func (m *ResourceLogs) Resource() (r *Resource) {
 }
 
 // This is noinline, so that Resource() is inlined instead.
+//
 //go:noinline
 func (m *ResourceLogs) decodeResource() {
-	// Decode nested message(s).
+	// Decode nested message(s). The errors are recorded on the nested messages.
 	resource := m.resource
 	if resource != nil {
-		// TODO: decide how to handle decoding errors.
-		_ = resource.decode()
+		if err := resource.decode(); err != nil {
+			resource._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_ResourceLogs_Resource_Decoded
 }
 
+// ResourceE is the same as Resource, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *ResourceLogs) ResourceE() (r *Resource, err error) {
+	r = m.Resource()
+	if r == nil {
+		return r, nil
+	}
+	return r, r.DecodeErr()
+}
+
 // SetResource sets the value of the resource.
 func (m *ResourceLogs) SetResource(v *Resource) {
 	m.resource = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_ResourceLogs_Resource_Decoded
+
 	// Make sure the field's Parent points to this message.
-	v._protoMessage.Parent = &m._protoMessage
+	if v != nil {
+		v._protoMessage.Parent = &m._protoMessage
+	}
 
 	// Mark this message modified, if not already.
 	m._protoMessage.MarkModified()
 }
 
 // ScopeLogs returns the value of the scopeLogs.
-func (m *ResourceLogs) ScopeLogs() (r []*ScopeLogs) {
+func (m *ResourceLogs) ScopeLogs() (r ScopeLogsSlice) {
 	if m._flags&flags_ResourceLogs_ScopeLogs_Decoded == 0 {
 		m.decodeScopeLogs()
 	}
-	return m.scopeLogs
+	return ScopeLogsSlice{elems: &m.scopeLogs, parent: &m._protoMessage}
 }
 
 // This is noinline, so that ScopeLogs() is inlined instead.
+//
 //go:noinline
 func (m *ResourceLogs) decodeScopeLogs() {
-	// Decode nested message(s).
-	for i := range m.scopeLogs {
-		// TODO: decide how to handle decoding errors.
-		_ = m.scopeLogs[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.scopeLogs {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_ResourceLogs_ScopeLogs_Decoded
 }
 
+// ScopeLogsE is the same as ScopeLogs, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *ResourceLogs) ScopeLogsE() (r ScopeLogsSlice, err error) {
+	r = m.ScopeLogs()
+	for _, elem := range m.scopeLogs {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetScopeLogs sets the value of the scopeLogs.
 func (m *ResourceLogs) SetScopeLogs(v []*ScopeLogs) {
 	m.scopeLogs = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_ResourceLogs_ScopeLogs_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.scopeLogs {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
@@ -532,34 +1416,6 @@ func (m *ResourceLogs) SetScopeLogs(v []*ScopeLogs) {
 	m._protoMessage.MarkModified()
 }
 
-func (m *ResourceLogs) ScopeLogsRemoveIf(f func(*ScopeLogs) bool) {
-	// Call getter to load the field.
-	m.ScopeLogs()
-
-	newLen := 0
-	for i := 0; i < len(m.scopeLogs); i++ {
-		if f(m.scopeLogs[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.scopeLogs[newLen] != nil {
-			m.scopeLogs[newLen].Free()
-		}
-		m.scopeLogs[newLen] = m.scopeLogs[i]
-		m.scopeLogs[i] = nil
-		newLen++
-	}
-	if newLen != len(m.scopeLogs) {
-		m.scopeLogs = m.scopeLogs[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
-}
-
 // SchemaUrl returns the value of the schemaUrl.
 func (m *ResourceLogs) SchemaUrl() (r string) {
 	return m.schemaUrl
@@ -573,6 +1429,13 @@ func (m *ResourceLogs) SetSchemaUrl(v string) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the ResourceLogs schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *ResourceLogs) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateResourceLogs(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -652,16 +1515,22 @@ func (m *ResourceLogs) decode() error {
 		case 0b0_0001_010: // field number 1 (resource), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0010_010: // field number 2 (scopeLogs), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			scopeLogsCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0011_010: // field number 3 (schemaUrl), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -743,6 +1612,8 @@ func (m *ResourceLogs) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -758,12 +1629,52 @@ func (m *ResourceLogs) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *ResourceLogs) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *ResourceLogs) calcSize() int {
+	size := 0
+	// Size of "resource".
+	resource := m.resource
+	if resource != nil {
+		size += 1 + molecule.SizeBytes(resource.Size())
+	}
+	// Size of "scopeLogs".
+	for _, elem := range m.scopeLogs {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Size of "schemaUrl".
+	if m.schemaUrl != "" {
+		size += 1 + molecule.SizeBytes(len(m.schemaUrl))
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_ResourceLogs_Resource = molecule.PrepareEmbeddedField(1)
 var prepared_ResourceLogs_ScopeLogs = molecule.PrepareEmbeddedField(2)
 var prepared_ResourceLogs_SchemaUrl = molecule.PrepareStringField(3)
@@ -771,25 +1682,57 @@ var prepared_ResourceLogs_SchemaUrl = molecule.PrepareStringField(3)
 func (m *ResourceLogs) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "resource".
 		resource := m.resource
 		if resource != nil {
-			token := ps.BeginEmbedded()
-			if err := resource.Marshal(ps); err != nil {
-				return err
+			if size, ok := resource._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_ResourceLogs_Resource, size)
+				if err := resource.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := resource.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_ResourceLogs_Resource)
 			}
-			ps.EndEmbeddedPrepared(token, prepared_ResourceLogs_Resource)
 		}
 		// Marshal "scopeLogs".
 		for _, elem := range m.scopeLogs {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_ResourceLogs_ScopeLogs, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_ResourceLogs_ScopeLogs)
 			}
-			ps.EndEmbeddedPrepared(token, prepared_ResourceLogs_ScopeLogs)
 		}
 		// Marshal "schemaUrl".
 		ps.StringPrepared(prepared_ResourceLogs_SchemaUrl, m.schemaUrl)
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
 	} else {
 		// We have the original bytes and the message is unchanged. Use the original bytes.
 		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
@@ -797,217 +1740,874 @@ func (m *ResourceLogs) Marshal(ps *molecule.ProtoStream) error {
 	return nil
 }
 
-// Pool of ResourceLogs structs.
-type resourceLogsPoolType struct {
-	pool []*ResourceLogs
-	mux  sync.Mutex
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *ResourceLogs) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
 }
 
-var resourceLogsPool = resourceLogsPoolType{}
-
-// Get one element from the pool. Creates a new element if the pool is empty.
-func (p *resourceLogsPoolType) Get() *ResourceLogs {
-	p.mux.Lock()
-	defer p.mux.Unlock()
-
-	// Have elements in the pool?
-	if len(p.pool) >= 1 {
-		// Get the last element.
-		r := p.pool[len(p.pool)-1]
-		// Shrink the pool.
-		p.pool = p.pool[:len(p.pool)-1]
-		return r
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *ResourceLogs) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
 	}
-
-	// Pool is empty, create a new element.
-	return &ResourceLogs{}
+	return r.End()
 }
 
-func (p *resourceLogsPoolType) GetSlice(r []*ResourceLogs) {
-	// Create a new slice.
-	count := len(r)
-
-	p.mux.Lock()
-	defer p.mux.Unlock()
-
-	// Have enough elements in the pool?
-	if len(p.pool) >= count {
-		// Copy the elements from the end of the pool.
-		copy(r, p.pool[len(p.pool)-count:])
-
-		// Shrink the pool.
-		p.pool = p.pool[:len(p.pool)-count]
-
-		return
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *ResourceLogs) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := resourceLogsPool.Get()
+	defer resourceLogsPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
 	}
+	return tmp.MarshalJSONStream(js)
+}
 
-	// Initialize with what remains in the pool.
-	copied := copy(r, p.pool)
-	p.pool = nil
-
-	if copied < count {
-		// Create remaining elements.
-		storage := make([]ResourceLogs, count-copied)
-		j := 0
-		for ; copied < count; copied++ {
-			r[copied] = &storage[j]
-			j++
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *ResourceLogs) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if m.resource != nil {
+		js.Name("resource")
+		if m._flags&flags_ResourceLogs_Resource_Decoded != 0 {
+			if err := m.resource.MarshalJSONStream(js); err != nil {
+				return err
+			}
+		} else if err := m.resource.marshalJSONUndecoded(js); err != nil {
+			return err
 		}
 	}
-}
-
-// ReleaseSlice releases a slice of elements back to the pool.
-func (p *resourceLogsPoolType) ReleaseSlice(slice []*ResourceLogs) {
-	for _, elem := range slice {
-		// Release nested resource recursively to their pool.
-		if elem.resource != nil {
-			resourcePool.Release(elem.resource)
+	if len(m.scopeLogs) > 0 {
+		js.Name("scopeLogs")
+		js.BeginArray()
+		for _, elem := range m.scopeLogs {
+			if m._flags&flags_ResourceLogs_ScopeLogs_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
 		}
-		// Release nested scopeLogs recursively to their pool.
-		scopeLogsPool.ReleaseSlice(elem.scopeLogs)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.resource = nil
-		elem.scopeLogs = elem.scopeLogs[:0]
-		elem.schemaUrl = ""
+		js.EndArray()
 	}
-
-	p.mux.Lock()
-	defer p.mux.Unlock()
-
-	// Add the slice to the end of the pool.
-	p.pool = append(p.pool, slice...)
-}
-
-// Release an element back to the pool.
-func (p *resourceLogsPoolType) Release(elem *ResourceLogs) {
-	// Release nested resource recursively to their pool.
-	if elem.resource != nil {
-		resourcePool.Release(elem.resource)
+	if m.schemaUrl != "" {
+		js.Name("schemaUrl")
+		js.String(m.schemaUrl)
 	}
-	// Release nested scopeLogs recursively to their pool.
-	scopeLogsPool.ReleaseSlice(elem.scopeLogs)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.resource = nil
-	elem.scopeLogs = elem.scopeLogs[:0]
-	elem.schemaUrl = ""
-
-	p.mux.Lock()
-	defer p.mux.Unlock()
-
-	// Add the slice to the end of the pool.
-	p.pool = append(p.pool, elem)
+	js.EndObject()
+	return nil
 }
 
-// ====================== Resource message implementation ======================
-
-type Resource struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_Resource
-
-	attributes             []*KeyValue
-	droppedAttributesCount uint32
-}
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *ResourceLogs) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
 
-// UnmarshalResource unmarshals from the Protobuf wire bytes into a struct
-// representing the message.
-// If WithValidate option is provided the wire bytes will be validated to make sure
-// the contain a valid representation of a Resource message.
-// If WithValidate option is not provided the validation will not be performed and
-// subsequent access of the fields of the message can return missing values if
-// the values happen to be invalid on the wire.
-// The returned message can be freed using Free() method when it is known that there
-// no remaining pointers to the message and it can be safely discarded. This places
-// the message struct into a pool from which it can be reused by future unmarshal
-// operations.
-func UnmarshalResource(bytes []byte, opts lazyproto.UnmarshalOpts) (*Resource, error) {
-	if opts.WithValidate {
-		if err := validateResource(bytes); err != nil {
-			return nil, err
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_ResourceLogs_Resource_Decoded | flags_ResourceLogs_ScopeLogs_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "resource":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.resource != nil {
+				// Duplicate field, the last one wins.
+				resourcePool.Release(m.resource)
+			}
+			m.resource = resourcePool.Get()
+			m.resource._protoMessage.Parent = &m._protoMessage
+			if err := m.resource.UnmarshalJSONStream(r); err != nil {
+				return err
+			}
+		case "scopeLogs", "scope_logs":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := scopeLogsPool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.scopeLogs = append(m.scopeLogs, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		case "schemaUrl", "schema_url":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.schemaUrl = v
+		default:
+			return fmt.Errorf("unknown field %q in ResourceLogs", name)
 		}
 	}
+	return nil
+}
 
-	m := resourcePool.Get()
-	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
-	if err := m.decode(); err != nil {
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *ResourceLogs) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
 		return nil, err
 	}
-	return m, nil
+	tw.End()
+	return tw.BufferBytes()
 }
 
-func (m *Resource) Free() {
-	resourcePool.Release(m)
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *ResourceLogs) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
 }
 
-// flags_Resource is the type of the bit flags.
-type flags_Resource uint8
-
-// Bitmasks that indicate that the particular nested message is decoded.
-const flags_Resource_Attributes_Decoded flags_Resource = 0x1
-
-// Attributes returns the value of the attributes.
-func (m *Resource) Attributes() (r []*KeyValue) {
-	if m._flags&flags_Resource_Attributes_Decoded == 0 {
-		m.decodeAttributes()
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *ResourceLogs) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := resourceLogsPool.Get()
+	defer resourceLogsPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
 	}
-	return m.attributes
+	return tmp.MarshalTextStream(tw)
 }
 
-// This is noinline, so that Attributes() is inlined instead.
-//go:noinline
-func (m *Resource) decodeAttributes() {
-	// Decode nested message(s).
-	for i := range m.attributes {
-		// TODO: decide how to handle decoding errors.
-		_ = m.attributes[i].decode()
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *ResourceLogs) MarshalTextStream(tw *textstream.Writer) error {
+	if m.resource != nil {
+		tw.Name("resource")
+		tw.BeginMessage()
+		if m._flags&flags_ResourceLogs_Resource_Decoded != 0 {
+			if err := m.resource.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := m.resource.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
 	}
-	m._flags |= flags_Resource_Attributes_Decoded
+	for _, elem := range m.scopeLogs {
+		tw.Name("scope_logs")
+		tw.BeginMessage()
+		if m._flags&flags_ResourceLogs_ScopeLogs_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	if m.schemaUrl != "" {
+		tw.Name("schema_url")
+		tw.String(m.schemaUrl)
+	}
+	return nil
 }
 
-// SetAttributes sets the value of the attributes.
-func (m *Resource) SetAttributes(v []*KeyValue) {
-	m.attributes = v
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *ResourceLogs) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
 
-	// Make sure the field's Parent points to this message.
-	for _, elem := range m.attributes {
-		elem._protoMessage.Parent = &m._protoMessage
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_ResourceLogs_Resource_Decoded | flags_ResourceLogs_ScopeLogs_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "resource":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			if m.resource != nil {
+				// Duplicate field, the last one wins.
+				resourcePool.Release(m.resource)
+			}
+			m.resource = resourcePool.Get()
+			m.resource._protoMessage.Parent = &m._protoMessage
+			if err := r.BeginMessage(); err != nil {
+				return err
+			}
+			if err := m.resource.UnmarshalTextStream(r); err != nil {
+				return err
+			}
+		case "scope_logs":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := scopeLogsPool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.scopeLogs = append(m.scopeLogs, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
+			}
+		case "schema_url":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.schemaUrl = v
+		default:
+			return fmt.Errorf("unknown field %q in ResourceLogs", name)
+		}
 	}
+	return nil
+}
 
-	// Mark this message modified, if not already.
-	m._protoMessage.MarkModified()
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *ResourceLogs) ProtoReflect() protoreflect.Message {
+	return resourceLogsReflectInfo.MessageOf(m)
+}
+
+// reflectScopeLogs returns the view of the scopeLogs list.
+func (m *ResourceLogs) reflectScopeLogs() protoreflect.List {
+	if m._flags&flags_ResourceLogs_ScopeLogs_Decoded == 0 {
+		m.decodeScopeLogs()
+	}
+	return lazyreflect.NewMessageList(&m.scopeLogs, &m._protoMessage, NewScopeLogs)
+}
+
+var resourceLogsReflectInfo = &lazyreflect.MessageInfo[ResourceLogs]{
+	File:          file_logs_proto,
+	FullName:      "simple.ResourceLogs",
+	NewMessage:    NewResourceLogs,
+	UnknownFields: (*ResourceLogs).UnknownFields,
+	SetUnknownFields: func(m *ResourceLogs, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[ResourceLogs]{
+		{
+			// resource
+			Has: func(m *ResourceLogs) bool { return m.resource != nil },
+			Get: func(m *ResourceLogs) protoreflect.Value { return lazyreflect.ValueOfMessage(m.Resource()) },
+			Set: func(m *ResourceLogs, v protoreflect.Value) {
+				m.SetResource(lazyreflect.MessageFromValue(v, NewResource))
+			},
+			Clear: func(m *ResourceLogs) { m.SetResource(nil) },
+			Mutable: func(m *ResourceLogs) protoreflect.Value {
+				if m.Resource() == nil {
+					m.SetResource(NewResource())
+				}
+				return lazyreflect.ValueOfMessage(m.Resource())
+			},
+		},
+		{
+			// scope_logs
+			Has: func(m *ResourceLogs) bool { return len(m.scopeLogs) > 0 },
+			Get: func(m *ResourceLogs) protoreflect.Value { return protoreflect.ValueOfList(m.reflectScopeLogs()) },
+			Set: func(m *ResourceLogs, v protoreflect.Value) {
+				m.SetScopeLogs(lazyreflect.MessagesFromList(v.List(), NewScopeLogs))
+			},
+			Clear:   func(m *ResourceLogs) { m.SetScopeLogs(nil) },
+			Mutable: func(m *ResourceLogs) protoreflect.Value { return protoreflect.ValueOfList(m.reflectScopeLogs()) },
+		},
+		{
+			// schema_url
+			Has:   func(m *ResourceLogs) bool { return m.schemaUrl != "" },
+			Get:   func(m *ResourceLogs) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.SchemaUrl()) },
+			Set:   func(m *ResourceLogs, v protoreflect.Value) { m.SetSchemaUrl(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *ResourceLogs) { m.SetSchemaUrl("") },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *ResourceLogs) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *ResourceLogs) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// ResourceLogsSlice is a repeated field of ResourceLogs messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type ResourceLogsSlice struct {
+	elems  *[]*ResourceLogs
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s ResourceLogsSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s ResourceLogsSlice) At(i int) *ResourceLogs {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s ResourceLogsSlice) Range(f func(i int, elem *ResourceLogs) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s ResourceLogsSlice) Append(elems ...*ResourceLogs) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
 }
 
-func (m *Resource) AttributesRemoveIf(f func(*KeyValue) bool) {
-	// Call getter to load the field.
-	m.Attributes()
+// AppendNew appends a new element taken from the pool and returns it.
+func (s ResourceLogsSlice) AppendNew() *ResourceLogs {
+	elem := resourceLogsPool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
 
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s ResourceLogsSlice) InsertAt(i int, elem *ResourceLogs) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s ResourceLogsSlice) RemoveAt(i int) {
+	elems := *s.elems
+	resourceLogsPool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s ResourceLogsSlice) RemoveIf(f func(elem *ResourceLogs) bool) {
+	elems := *s.elems
 	newLen := 0
-	for i := 0; i < len(m.attributes); i++ {
-		if f(m.attributes[i]) {
+	for _, elem := range elems {
+		if f(elem) {
+			resourceLogsPool.Release(elem)
 			continue
 		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s ResourceLogsSlice) Sort(less func(a, b *ResourceLogs) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *ResourceLogs) reset() {
+	elem := m
+
+	// Release nested resource recursively to their pool.
+	if elem.resource != nil {
+		resourcePool.Release(elem.resource)
+	}
+	// Release nested scopeLogs recursively to their pool.
+	scopeLogsPool.ReleaseSlice(elem.scopeLogs)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.resource = nil
+	elem.scopeLogs = elem.scopeLogs[:0]
+	elem.schemaUrl = ""
+}
+
+// Pool of ResourceLogs structs.
+type resourceLogsPoolType struct {
+	pool []*ResourceLogs
+	mux  sync.Mutex
+}
+
+var resourceLogsPool = resourceLogsPoolType{}
+
+// Get one element from the pool. Creates a new element if the pool is empty.
+func (p *resourceLogsPoolType) Get() *ResourceLogs {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	// Have elements in the pool?
+	if len(p.pool) >= 1 {
+		// Get the last element.
+		r := p.pool[len(p.pool)-1]
+		// Shrink the pool.
+		p.pool = p.pool[:len(p.pool)-1]
+		return r
+	}
+
+	// Pool is empty, create a new element.
+	return &ResourceLogs{}
+}
+
+func (p *resourceLogsPoolType) GetSlice(r []*ResourceLogs) {
+	// Create a new slice.
+	count := len(r)
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	// Have enough elements in the pool?
+	if len(p.pool) >= count {
+		// Copy the elements from the end of the pool.
+		copy(r, p.pool[len(p.pool)-count:])
+
+		// Shrink the pool.
+		p.pool = p.pool[:len(p.pool)-count]
+
+		return
+	}
+
+	// Initialize with what remains in the pool.
+	copied := copy(r, p.pool)
+	p.pool = nil
+
+	if copied < count {
+		// Create remaining elements.
+		storage := make([]ResourceLogs, count-copied)
+		j := 0
+		for ; copied < count; copied++ {
+			r[copied] = &storage[j]
+			j++
 		}
-		if m.attributes[newLen] != nil {
-			m.attributes[newLen].Free()
+	}
+}
+
+// ReleaseSlice releases a slice of elements back to the pool.
+func (p *resourceLogsPoolType) ReleaseSlice(slice []*ResourceLogs) {
+	for _, elem := range slice {
+		elem.reset()
+	}
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	// Add the slice to the end of the pool.
+	p.pool = append(p.pool, slice...)
+}
+
+// Release an element back to the pool.
+func (p *resourceLogsPoolType) Release(elem *ResourceLogs) {
+	elem.reset()
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	// Add the slice to the end of the pool.
+	p.pool = append(p.pool, elem)
+}
+
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *ResourceLogs) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *ResourceLogs) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *ResourceLogs) XXX_CloneInto(c *ResourceLogs) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *ResourceLogs) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *ResourceLogs) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateResourceLogs is for use by the code generated for other packages only.
+func XXX_ValidateResourceLogs(b []byte) error {
+	return validateResourceLogs(b)
+}
+
+// XXX_NewResourceLogsSlice is for use by the code generated for other packages only.
+func XXX_NewResourceLogsSlice(elems *[]*ResourceLogs, parent *protomessage.ProtoMessage) ResourceLogsSlice {
+	return ResourceLogsSlice{elems: elems, parent: parent}
+}
+
+// XXX_ResourceLogsPool is for use by the code generated for other packages only.
+var XXX_ResourceLogsPool = &resourceLogsPool
+
+// ====================== Resource message implementation ======================
+
+type Resource struct {
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_Resource
+	_unknownFields protomessage.UnknownFields
+
+	attributes             []*KeyValue
+	droppedAttributesCount uint32
+}
+
+// NewResource returns an empty Resource message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewResource() *Resource {
+	return resourcePool.Get()
+}
+
+// NewResourceSlice returns a slice of n empty Resource messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewResourceSlice(n int) []*Resource {
+	r := make([]*Resource, n)
+	resourcePool.GetSlice(r)
+	return r
+}
+
+// UnmarshalResource unmarshals from the Protobuf wire bytes into a struct
+// representing the message.
+// If WithValidate option is provided the wire bytes will be validated to make sure
+// the contain a valid representation of a Resource message.
+// If WithValidate option is not provided the validation will not be performed and
+// subsequent access of the fields of the message can return missing values if
+// the values happen to be invalid on the wire.
+// The returned message can be freed using Free() method when it is known that there
+// no remaining pointers to the message and it can be safely discarded. This places
+// the message struct into a pool from which it can be reused by future unmarshal
+// operations.
+func UnmarshalResource(bytes []byte, opts lazyproto.UnmarshalOpts) (*Resource, error) {
+	if opts.WithValidate {
+		if err := validateResource(bytes); err != nil {
+			return nil, err
 		}
-		m.attributes[newLen] = m.attributes[i]
-		m.attributes[i] = nil
-		newLen++
 	}
-	if newLen != len(m.attributes) {
-		m.attributes = m.attributes[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
+
+	m := resourcePool.Get()
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if err := m.decode(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalResource(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *Resource) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateResource(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
+func (m *Resource) Free() {
+	resourcePool.Release(m)
+}
+
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *Resource) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *Resource) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*Resource)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *Resource) Clone() *Resource {
+	c := resourcePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *Resource) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *Resource) cloneInto(c *Resource) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
 	}
+	c._flags = m._flags
+	// Clone attributes elements into structs taken from the pool all at once.
+	if cap(c.attributes) < len(m.attributes) {
+		c.attributes = make([]*KeyValue, len(m.attributes))
+	} else {
+		c.attributes = c.attributes[:len(m.attributes)]
+	}
+	keyValuePool.GetSlice(c.attributes)
+	for i, elem := range m.attributes {
+		elem.cloneInto(c.attributes[i])
+		c.attributes[i]._protoMessage.Parent = &c._protoMessage
+	}
+	c.droppedAttributesCount = m.droppedAttributesCount
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *Resource) Equal(other *Resource) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	{
+		a, b := m.Attributes(), other.Attributes()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	if m.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
+// flags_Resource is the type of the bit flags.
+type flags_Resource uint8
+
+// Bitmasks that indicate that the particular nested message is decoded.
+const flags_Resource_Attributes_Decoded flags_Resource = 0x1
+
+// Attributes returns the value of the attributes.
+func (m *Resource) Attributes() (r KeyValueSlice) {
+	if m._flags&flags_Resource_Attributes_Decoded == 0 {
+		m.decodeAttributes()
+	}
+	return KeyValueSlice{elems: &m.attributes, parent: &m._protoMessage}
+}
+
+// This is noinline, so that Attributes() is inlined instead.
+//
+//go:noinline
+func (m *Resource) decodeAttributes() {
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.attributes {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
+	}
+	m._flags |= flags_Resource_Attributes_Decoded
+}
+
+// AttributesE is the same as Attributes, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *Resource) AttributesE() (r KeyValueSlice, err error) {
+	r = m.Attributes()
+	for _, elem := range m.attributes {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// SetAttributes sets the value of the attributes.
+func (m *Resource) SetAttributes(v []*KeyValue) {
+	m.attributes = v
+
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_Resource_Attributes_Decoded
+
+	// Make sure the field's Parent points to this message.
+	for _, elem := range v {
+		elem._protoMessage.Parent = &m._protoMessage
+	}
+
+	// Mark this message modified, if not already.
+	m._protoMessage.MarkModified()
 }
 
 // DroppedAttributesCount returns the value of the droppedAttributesCount.
@@ -1023,6 +2623,13 @@ func (m *Resource) SetDroppedAttributesCount(v uint32) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the Resource schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *Resource) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateResource(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -1092,11 +2699,15 @@ func (m *Resource) decode() error {
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			attributesCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0010_000: // field number 2 (droppedAttributesCount), wire type 0 (Varint)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipVarint()
+			if err := buf.SkipVarint(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -1165,6 +2776,8 @@ func (m *Resource) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -1180,28 +2793,88 @@ func (m *Resource) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *Resource) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *Resource) calcSize() int {
+	size := 0
+	// Size of "attributes".
+	for _, elem := range m.attributes {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Size of "droppedAttributesCount".
+	if m.droppedAttributesCount != 0 {
+		size += 1 + molecule.SizeVarint(uint64(m.droppedAttributesCount))
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_Resource_Attributes = molecule.PrepareEmbeddedField(1)
 var prepared_Resource_DroppedAttributesCount = molecule.PrepareUint32Field(2)
 
 func (m *Resource) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "attributes".
 		for _, elem := range m.attributes {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_Resource_Attributes, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_Resource_Attributes)
 			}
-			ps.EndEmbeddedPrepared(token, prepared_Resource_Attributes)
 		}
 		// Marshal "droppedAttributesCount".
 		ps.Uint32Prepared(prepared_Resource_DroppedAttributesCount, m.droppedAttributesCount)
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
 	} else {
 		// We have the original bytes and the message is unchanged. Use the original bytes.
 		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
@@ -1209,6 +2882,458 @@ func (m *Resource) Marshal(ps *molecule.ProtoStream) error {
 	return nil
 }
 
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *Resource) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *Resource) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *Resource) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := resourcePool.Get()
+	defer resourcePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *Resource) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if len(m.attributes) > 0 {
+		js.Name("attributes")
+		js.BeginArray()
+		for _, elem := range m.attributes {
+			if m._flags&flags_Resource_Attributes_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	if m.droppedAttributesCount != 0 {
+		js.Name("droppedAttributesCount")
+		js.Uint32(m.droppedAttributesCount)
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *Resource) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_Resource_Attributes_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "attributes":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.attributes = append(m.attributes, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		case "droppedAttributesCount", "dropped_attributes_count":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.droppedAttributesCount = v
+		default:
+			return fmt.Errorf("unknown field %q in Resource", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *Resource) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *Resource) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *Resource) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := resourcePool.Get()
+	defer resourcePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *Resource) MarshalTextStream(tw *textstream.Writer) error {
+	for _, elem := range m.attributes {
+		tw.Name("attributes")
+		tw.BeginMessage()
+		if m._flags&flags_Resource_Attributes_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	if m.droppedAttributesCount != 0 {
+		tw.Name("dropped_attributes_count")
+		tw.Uint32(m.droppedAttributesCount)
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *Resource) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_Resource_Attributes_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "attributes":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.attributes = append(m.attributes, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
+			}
+		case "dropped_attributes_count":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.droppedAttributesCount = v
+		default:
+			return fmt.Errorf("unknown field %q in Resource", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *Resource) ProtoReflect() protoreflect.Message {
+	return resourceReflectInfo.MessageOf(m)
+}
+
+// reflectAttributes returns the view of the attributes list.
+func (m *Resource) reflectAttributes() protoreflect.List {
+	if m._flags&flags_Resource_Attributes_Decoded == 0 {
+		m.decodeAttributes()
+	}
+	return lazyreflect.NewMessageList(&m.attributes, &m._protoMessage, NewKeyValue)
+}
+
+var resourceReflectInfo = &lazyreflect.MessageInfo[Resource]{
+	File:          file_logs_proto,
+	FullName:      "simple.Resource",
+	NewMessage:    NewResource,
+	UnknownFields: (*Resource).UnknownFields,
+	SetUnknownFields: func(m *Resource, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[Resource]{
+		{
+			// attributes
+			Has: func(m *Resource) bool { return len(m.attributes) > 0 },
+			Get: func(m *Resource) protoreflect.Value { return protoreflect.ValueOfList(m.reflectAttributes()) },
+			Set: func(m *Resource, v protoreflect.Value) {
+				m.SetAttributes(lazyreflect.MessagesFromList(v.List(), NewKeyValue))
+			},
+			Clear:   func(m *Resource) { m.SetAttributes(nil) },
+			Mutable: func(m *Resource) protoreflect.Value { return protoreflect.ValueOfList(m.reflectAttributes()) },
+		},
+		{
+			// dropped_attributes_count
+			Has: func(m *Resource) bool { return m.droppedAttributesCount != 0 },
+			Get: func(m *Resource) protoreflect.Value {
+				return lazyreflect.Uint32Conv.ToValue(m.DroppedAttributesCount())
+			},
+			Set: func(m *Resource, v protoreflect.Value) {
+				m.SetDroppedAttributesCount(lazyreflect.Uint32Conv.FromValue(v))
+			},
+			Clear: func(m *Resource) { m.SetDroppedAttributesCount(0) },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *Resource) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *Resource) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// ResourceSlice is a repeated field of Resource messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type ResourceSlice struct {
+	elems  *[]*Resource
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s ResourceSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s ResourceSlice) At(i int) *Resource {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s ResourceSlice) Range(f func(i int, elem *Resource) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s ResourceSlice) Append(elems ...*Resource) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s ResourceSlice) AppendNew() *Resource {
+	elem := resourcePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s ResourceSlice) InsertAt(i int, elem *Resource) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s ResourceSlice) RemoveAt(i int) {
+	elems := *s.elems
+	resourcePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s ResourceSlice) RemoveIf(f func(elem *Resource) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			resourcePool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s ResourceSlice) Sort(less func(a, b *Resource) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *Resource) reset() {
+	elem := m
+
+	// Release nested attributes recursively to their pool.
+	keyValuePool.ReleaseSlice(elem.attributes)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.attributes = elem.attributes[:0]
+	elem.droppedAttributesCount = 0
+}
+
 // Pool of Resource structs.
 type resourcePoolType struct {
 	pool []*Resource
@@ -1271,14 +3396,7 @@ func (p *resourcePoolType) GetSlice(r []*Resource) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *resourcePoolType) ReleaseSlice(slice []*Resource) {
 	for _, elem := range slice {
-		// Release nested attributes recursively to their pool.
-		keyValuePool.ReleaseSlice(elem.attributes)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.attributes = elem.attributes[:0]
-		elem.droppedAttributesCount = 0
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -1290,14 +3408,7 @@ func (p *resourcePoolType) ReleaseSlice(slice []*Resource) {
 
 // Release an element back to the pool.
 func (p *resourcePoolType) Release(elem *Resource) {
-	// Release nested attributes recursively to their pool.
-	keyValuePool.ReleaseSlice(elem.attributes)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.attributes = elem.attributes[:0]
-	elem.droppedAttributesCount = 0
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -1306,12 +3417,51 @@ func (p *resourcePoolType) Release(elem *Resource) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *Resource) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *Resource) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *Resource) XXX_CloneInto(c *Resource) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *Resource) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *Resource) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateResource is for use by the code generated for other packages only.
+func XXX_ValidateResource(b []byte) error {
+	return validateResource(b)
+}
+
+// XXX_NewResourceSlice is for use by the code generated for other packages only.
+func XXX_NewResourceSlice(elems *[]*Resource, parent *protomessage.ProtoMessage) ResourceSlice {
+	return ResourceSlice{elems: elems, parent: parent}
+}
+
+// XXX_ResourcePool is for use by the code generated for other packages only.
+var XXX_ResourcePool = &resourcePool
+
 // ====================== ScopeLogs message implementation ======================
 
 // A collection of Logs produced by a Scope.
 type ScopeLogs struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_ScopeLogs
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_ScopeLogs
+	_unknownFields protomessage.UnknownFields
 
 	scope *InstrumentationScope
 
@@ -1322,6 +3472,20 @@ type ScopeLogs struct {
 	schemaUrl string
 }
 
+// NewScopeLogs returns an empty ScopeLogs message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewScopeLogs() *ScopeLogs {
+	return scopeLogsPool.Get()
+}
+
+// NewScopeLogsSlice returns a slice of n empty ScopeLogs messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewScopeLogsSlice(n int) []*ScopeLogs {
+	r := make([]*ScopeLogs, n)
+	scopeLogsPool.GetSlice(r)
+	return r
+}
+
 // UnmarshalScopeLogs unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -1342,16 +3506,141 @@ func UnmarshalScopeLogs(bytes []byte, opts lazyproto.UnmarshalOpts) (*ScopeLogs,
 
 	m := scopeLogsPool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalScopeLogs(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *ScopeLogs) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateScopeLogs(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *ScopeLogs) Free() {
 	scopeLogsPool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *ScopeLogs) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *ScopeLogs) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*ScopeLogs)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *ScopeLogs) Clone() *ScopeLogs {
+	c := scopeLogsPool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *ScopeLogs) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *ScopeLogs) cloneInto(c *ScopeLogs) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	if m.scope != nil {
+		c.scope = instrumentationScopePool.Get()
+		m.scope.cloneInto(c.scope)
+		c.scope._protoMessage.Parent = &c._protoMessage
+	}
+	// Clone logRecords elements into structs taken from the pool all at once.
+	if cap(c.logRecords) < len(m.logRecords) {
+		c.logRecords = make([]*LogRecord, len(m.logRecords))
+	} else {
+		c.logRecords = c.logRecords[:len(m.logRecords)]
+	}
+	logRecordPool.GetSlice(c.logRecords)
+	for i, elem := range m.logRecords {
+		elem.cloneInto(c.logRecords[i])
+		c.logRecords[i]._protoMessage.Parent = &c._protoMessage
+	}
+	c.schemaUrl = m.schemaUrl
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *ScopeLogs) Equal(other *ScopeLogs) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if !m.Scope().Equal(other.Scope()) {
+		return false
+	}
+	{
+		a, b := m.LogRecords(), other.LogRecords()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	if m.SchemaUrl() != other.SchemaUrl() {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_ScopeLogs is the type of the bit flags.
 type flags_ScopeLogs uint8
 
@@ -1368,86 +3657,96 @@ func (m *ScopeLogs) Scope() (r *InstrumentationScope) {
 }
 
 // This is noinline, so that Scope() is inlined instead.
+//
 //go:noinline
 func (m *ScopeLogs) decodeScope() {
-	// Decode nested message(s).
+	// Decode nested message(s). The errors are recorded on the nested messages.
 	scope := m.scope
 	if scope != nil {
-		// TODO: decide how to handle decoding errors.
-		_ = scope.decode()
+		if err := scope.decode(); err != nil {
+			scope._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_ScopeLogs_Scope_Decoded
 }
 
+// ScopeE is the same as Scope, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *ScopeLogs) ScopeE() (r *InstrumentationScope, err error) {
+	r = m.Scope()
+	if r == nil {
+		return r, nil
+	}
+	return r, r.DecodeErr()
+}
+
 // SetScope sets the value of the scope.
 func (m *ScopeLogs) SetScope(v *InstrumentationScope) {
 	m.scope = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_ScopeLogs_Scope_Decoded
+
 	// Make sure the field's Parent points to this message.
-	v._protoMessage.Parent = &m._protoMessage
+	if v != nil {
+		v._protoMessage.Parent = &m._protoMessage
+	}
 
 	// Mark this message modified, if not already.
 	m._protoMessage.MarkModified()
 }
 
 // LogRecords returns the value of the logRecords.
-func (m *ScopeLogs) LogRecords() (r []*LogRecord) {
+func (m *ScopeLogs) LogRecords() (r LogRecordSlice) {
 	if m._flags&flags_ScopeLogs_LogRecords_Decoded == 0 {
 		m.decodeLogRecords()
 	}
-	return m.logRecords
+	return LogRecordSlice{elems: &m.logRecords, parent: &m._protoMessage}
 }
 
 // This is noinline, so that LogRecords() is inlined instead.
+//
 //go:noinline
 func (m *ScopeLogs) decodeLogRecords() {
-	// Decode nested message(s).
-	for i := range m.logRecords {
-		// TODO: decide how to handle decoding errors.
-		_ = m.logRecords[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.logRecords {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_ScopeLogs_LogRecords_Decoded
 }
 
+// LogRecordsE is the same as LogRecords, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *ScopeLogs) LogRecordsE() (r LogRecordSlice, err error) {
+	r = m.LogRecords()
+	for _, elem := range m.logRecords {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetLogRecords sets the value of the logRecords.
 func (m *ScopeLogs) SetLogRecords(v []*LogRecord) {
 	m.logRecords = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_ScopeLogs_LogRecords_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.logRecords {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
-	// Mark this message modified, if not already.
-	m._protoMessage.MarkModified()
-}
-
-func (m *ScopeLogs) LogRecordsRemoveIf(f func(*LogRecord) bool) {
-	// Call getter to load the field.
-	m.LogRecords()
-
-	newLen := 0
-	for i := 0; i < len(m.logRecords); i++ {
-		if f(m.logRecords[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.logRecords[newLen] != nil {
-			m.logRecords[newLen].Free()
-		}
-		m.logRecords[newLen] = m.logRecords[i]
-		m.logRecords[i] = nil
-		newLen++
-	}
-	if newLen != len(m.logRecords) {
-		m.logRecords = m.logRecords[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
+	// Mark this message modified, if not already.
+	m._protoMessage.MarkModified()
 }
 
 // SchemaUrl returns the value of the schemaUrl.
@@ -1463,6 +3762,13 @@ func (m *ScopeLogs) SetSchemaUrl(v string) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the ScopeLogs schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *ScopeLogs) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateScopeLogs(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -1542,16 +3848,22 @@ func (m *ScopeLogs) decode() error {
 		case 0b0_0001_010: // field number 1 (scope), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0010_010: // field number 2 (logRecords), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			logRecordsCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0011_010: // field number 3 (schemaUrl), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -1633,6 +3945,8 @@ func (m *ScopeLogs) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -1648,12 +3962,52 @@ func (m *ScopeLogs) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *ScopeLogs) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *ScopeLogs) calcSize() int {
+	size := 0
+	// Size of "scope".
+	scope := m.scope
+	if scope != nil {
+		size += 1 + molecule.SizeBytes(scope.Size())
+	}
+	// Size of "logRecords".
+	for _, elem := range m.logRecords {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Size of "schemaUrl".
+	if m.schemaUrl != "" {
+		size += 1 + molecule.SizeBytes(len(m.schemaUrl))
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_ScopeLogs_Scope = molecule.PrepareEmbeddedField(1)
 var prepared_ScopeLogs_LogRecords = molecule.PrepareEmbeddedField(2)
 var prepared_ScopeLogs_SchemaUrl = molecule.PrepareStringField(3)
@@ -1661,25 +4015,57 @@ var prepared_ScopeLogs_SchemaUrl = molecule.PrepareStringField(3)
 func (m *ScopeLogs) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "scope".
 		scope := m.scope
 		if scope != nil {
-			token := ps.BeginEmbedded()
-			if err := scope.Marshal(ps); err != nil {
-				return err
+			if size, ok := scope._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_ScopeLogs_Scope, size)
+				if err := scope.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := scope.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_ScopeLogs_Scope)
 			}
-			ps.EndEmbeddedPrepared(token, prepared_ScopeLogs_Scope)
 		}
 		// Marshal "logRecords".
 		for _, elem := range m.logRecords {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_ScopeLogs_LogRecords, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_ScopeLogs_LogRecords)
 			}
-			ps.EndEmbeddedPrepared(token, prepared_ScopeLogs_LogRecords)
 		}
 		// Marshal "schemaUrl".
 		ps.StringPrepared(prepared_ScopeLogs_SchemaUrl, m.schemaUrl)
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
 	} else {
 		// We have the original bytes and the message is unchanged. Use the original bytes.
 		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
@@ -1687,6 +4073,526 @@ func (m *ScopeLogs) Marshal(ps *molecule.ProtoStream) error {
 	return nil
 }
 
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *ScopeLogs) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *ScopeLogs) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *ScopeLogs) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := scopeLogsPool.Get()
+	defer scopeLogsPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *ScopeLogs) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if m.scope != nil {
+		js.Name("scope")
+		if m._flags&flags_ScopeLogs_Scope_Decoded != 0 {
+			if err := m.scope.MarshalJSONStream(js); err != nil {
+				return err
+			}
+		} else if err := m.scope.marshalJSONUndecoded(js); err != nil {
+			return err
+		}
+	}
+	if len(m.logRecords) > 0 {
+		js.Name("logRecords")
+		js.BeginArray()
+		for _, elem := range m.logRecords {
+			if m._flags&flags_ScopeLogs_LogRecords_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	if m.schemaUrl != "" {
+		js.Name("schemaUrl")
+		js.String(m.schemaUrl)
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *ScopeLogs) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_ScopeLogs_Scope_Decoded | flags_ScopeLogs_LogRecords_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "scope":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.scope != nil {
+				// Duplicate field, the last one wins.
+				instrumentationScopePool.Release(m.scope)
+			}
+			m.scope = instrumentationScopePool.Get()
+			m.scope._protoMessage.Parent = &m._protoMessage
+			if err := m.scope.UnmarshalJSONStream(r); err != nil {
+				return err
+			}
+		case "logRecords", "log_records":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := logRecordPool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.logRecords = append(m.logRecords, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		case "schemaUrl", "schema_url":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.schemaUrl = v
+		default:
+			return fmt.Errorf("unknown field %q in ScopeLogs", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *ScopeLogs) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *ScopeLogs) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *ScopeLogs) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := scopeLogsPool.Get()
+	defer scopeLogsPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *ScopeLogs) MarshalTextStream(tw *textstream.Writer) error {
+	if m.scope != nil {
+		tw.Name("scope")
+		tw.BeginMessage()
+		if m._flags&flags_ScopeLogs_Scope_Decoded != 0 {
+			if err := m.scope.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := m.scope.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	for _, elem := range m.logRecords {
+		tw.Name("log_records")
+		tw.BeginMessage()
+		if m._flags&flags_ScopeLogs_LogRecords_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	if m.schemaUrl != "" {
+		tw.Name("schema_url")
+		tw.String(m.schemaUrl)
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *ScopeLogs) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_ScopeLogs_Scope_Decoded | flags_ScopeLogs_LogRecords_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "scope":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			if m.scope != nil {
+				// Duplicate field, the last one wins.
+				instrumentationScopePool.Release(m.scope)
+			}
+			m.scope = instrumentationScopePool.Get()
+			m.scope._protoMessage.Parent = &m._protoMessage
+			if err := r.BeginMessage(); err != nil {
+				return err
+			}
+			if err := m.scope.UnmarshalTextStream(r); err != nil {
+				return err
+			}
+		case "log_records":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := logRecordPool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.logRecords = append(m.logRecords, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
+			}
+		case "schema_url":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.schemaUrl = v
+		default:
+			return fmt.Errorf("unknown field %q in ScopeLogs", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *ScopeLogs) ProtoReflect() protoreflect.Message {
+	return scopeLogsReflectInfo.MessageOf(m)
+}
+
+// reflectLogRecords returns the view of the logRecords list.
+func (m *ScopeLogs) reflectLogRecords() protoreflect.List {
+	if m._flags&flags_ScopeLogs_LogRecords_Decoded == 0 {
+		m.decodeLogRecords()
+	}
+	return lazyreflect.NewMessageList(&m.logRecords, &m._protoMessage, NewLogRecord)
+}
+
+var scopeLogsReflectInfo = &lazyreflect.MessageInfo[ScopeLogs]{
+	File:          file_logs_proto,
+	FullName:      "simple.ScopeLogs",
+	NewMessage:    NewScopeLogs,
+	UnknownFields: (*ScopeLogs).UnknownFields,
+	SetUnknownFields: func(m *ScopeLogs, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[ScopeLogs]{
+		{
+			// scope
+			Has: func(m *ScopeLogs) bool { return m.scope != nil },
+			Get: func(m *ScopeLogs) protoreflect.Value { return lazyreflect.ValueOfMessage(m.Scope()) },
+			Set: func(m *ScopeLogs, v protoreflect.Value) {
+				m.SetScope(lazyreflect.MessageFromValue(v, NewInstrumentationScope))
+			},
+			Clear: func(m *ScopeLogs) { m.SetScope(nil) },
+			Mutable: func(m *ScopeLogs) protoreflect.Value {
+				if m.Scope() == nil {
+					m.SetScope(NewInstrumentationScope())
+				}
+				return lazyreflect.ValueOfMessage(m.Scope())
+			},
+		},
+		{
+			// log_records
+			Has: func(m *ScopeLogs) bool { return len(m.logRecords) > 0 },
+			Get: func(m *ScopeLogs) protoreflect.Value { return protoreflect.ValueOfList(m.reflectLogRecords()) },
+			Set: func(m *ScopeLogs, v protoreflect.Value) {
+				m.SetLogRecords(lazyreflect.MessagesFromList(v.List(), NewLogRecord))
+			},
+			Clear:   func(m *ScopeLogs) { m.SetLogRecords(nil) },
+			Mutable: func(m *ScopeLogs) protoreflect.Value { return protoreflect.ValueOfList(m.reflectLogRecords()) },
+		},
+		{
+			// schema_url
+			Has:   func(m *ScopeLogs) bool { return m.schemaUrl != "" },
+			Get:   func(m *ScopeLogs) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.SchemaUrl()) },
+			Set:   func(m *ScopeLogs, v protoreflect.Value) { m.SetSchemaUrl(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *ScopeLogs) { m.SetSchemaUrl("") },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *ScopeLogs) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *ScopeLogs) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// ScopeLogsSlice is a repeated field of ScopeLogs messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type ScopeLogsSlice struct {
+	elems  *[]*ScopeLogs
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s ScopeLogsSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s ScopeLogsSlice) At(i int) *ScopeLogs {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s ScopeLogsSlice) Range(f func(i int, elem *ScopeLogs) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s ScopeLogsSlice) Append(elems ...*ScopeLogs) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s ScopeLogsSlice) AppendNew() *ScopeLogs {
+	elem := scopeLogsPool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s ScopeLogsSlice) InsertAt(i int, elem *ScopeLogs) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s ScopeLogsSlice) RemoveAt(i int) {
+	elems := *s.elems
+	scopeLogsPool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s ScopeLogsSlice) RemoveIf(f func(elem *ScopeLogs) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			scopeLogsPool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s ScopeLogsSlice) Sort(less func(a, b *ScopeLogs) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *ScopeLogs) reset() {
+	elem := m
+
+	// Release nested scope recursively to their pool.
+	if elem.scope != nil {
+		instrumentationScopePool.Release(elem.scope)
+	}
+	// Release nested logRecords recursively to their pool.
+	logRecordPool.ReleaseSlice(elem.logRecords)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.scope = nil
+	elem.logRecords = elem.logRecords[:0]
+	elem.schemaUrl = ""
+}
+
 // Pool of ScopeLogs structs.
 type scopeLogsPoolType struct {
 	pool []*ScopeLogs
@@ -1749,19 +4655,7 @@ func (p *scopeLogsPoolType) GetSlice(r []*ScopeLogs) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *scopeLogsPoolType) ReleaseSlice(slice []*ScopeLogs) {
 	for _, elem := range slice {
-		// Release nested scope recursively to their pool.
-		if elem.scope != nil {
-			instrumentationScopePool.Release(elem.scope)
-		}
-		// Release nested logRecords recursively to their pool.
-		logRecordPool.ReleaseSlice(elem.logRecords)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.scope = nil
-		elem.logRecords = elem.logRecords[:0]
-		elem.schemaUrl = ""
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -1773,19 +4667,7 @@ func (p *scopeLogsPoolType) ReleaseSlice(slice []*ScopeLogs) {
 
 // Release an element back to the pool.
 func (p *scopeLogsPoolType) Release(elem *ScopeLogs) {
-	// Release nested scope recursively to their pool.
-	if elem.scope != nil {
-		instrumentationScopePool.Release(elem.scope)
-	}
-	// Release nested logRecords recursively to their pool.
-	logRecordPool.ReleaseSlice(elem.logRecords)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.scope = nil
-	elem.logRecords = elem.logRecords[:0]
-	elem.schemaUrl = ""
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -1794,11 +4676,50 @@ func (p *scopeLogsPoolType) Release(elem *ScopeLogs) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *ScopeLogs) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *ScopeLogs) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *ScopeLogs) XXX_CloneInto(c *ScopeLogs) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *ScopeLogs) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *ScopeLogs) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateScopeLogs is for use by the code generated for other packages only.
+func XXX_ValidateScopeLogs(b []byte) error {
+	return validateScopeLogs(b)
+}
+
+// XXX_NewScopeLogsSlice is for use by the code generated for other packages only.
+func XXX_NewScopeLogsSlice(elems *[]*ScopeLogs, parent *protomessage.ProtoMessage) ScopeLogsSlice {
+	return ScopeLogsSlice{elems: elems, parent: parent}
+}
+
+// XXX_ScopeLogsPool is for use by the code generated for other packages only.
+var XXX_ScopeLogsPool = &scopeLogsPool
+
 // ====================== InstrumentationScope message implementation ======================
 
 type InstrumentationScope struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_InstrumentationScope
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_InstrumentationScope
+	_unknownFields protomessage.UnknownFields
 
 	name                   string
 	version                string
@@ -1806,6 +4727,20 @@ type InstrumentationScope struct {
 	droppedAttributesCount uint32
 }
 
+// NewInstrumentationScope returns an empty InstrumentationScope message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewInstrumentationScope() *InstrumentationScope {
+	return instrumentationScopePool.Get()
+}
+
+// NewInstrumentationScopeSlice returns a slice of n empty InstrumentationScope messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewInstrumentationScopeSlice(n int) []*InstrumentationScope {
+	r := make([]*InstrumentationScope, n)
+	instrumentationScopePool.GetSlice(r)
+	return r
+}
+
 // UnmarshalInstrumentationScope unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -1824,18 +4759,143 @@ func UnmarshalInstrumentationScope(bytes []byte, opts lazyproto.UnmarshalOpts) (
 		}
 	}
 
-	m := instrumentationScopePool.Get()
+	m := instrumentationScopePool.Get()
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if err := m.decode(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalInstrumentationScope(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *InstrumentationScope) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateInstrumentationScope(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
-	if err := m.decode(); err != nil {
-		return nil, err
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
 	}
-	return m, nil
+	return m.decode()
 }
 
 func (m *InstrumentationScope) Free() {
 	instrumentationScopePool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *InstrumentationScope) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *InstrumentationScope) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*InstrumentationScope)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *InstrumentationScope) Clone() *InstrumentationScope {
+	c := instrumentationScopePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *InstrumentationScope) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *InstrumentationScope) cloneInto(c *InstrumentationScope) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	c.name = m.name
+	c.version = m.version
+	// Clone attributes elements into structs taken from the pool all at once.
+	if cap(c.attributes) < len(m.attributes) {
+		c.attributes = make([]*KeyValue, len(m.attributes))
+	} else {
+		c.attributes = c.attributes[:len(m.attributes)]
+	}
+	keyValuePool.GetSlice(c.attributes)
+	for i, elem := range m.attributes {
+		elem.cloneInto(c.attributes[i])
+		c.attributes[i]._protoMessage.Parent = &c._protoMessage
+	}
+	c.droppedAttributesCount = m.droppedAttributesCount
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *InstrumentationScope) Equal(other *InstrumentationScope) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if m.Name() != other.Name() {
+		return false
+	}
+	if m.Version() != other.Version() {
+		return false
+	}
+	{
+		a, b := m.Attributes(), other.Attributes()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	if m.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_InstrumentationScope is the type of the bit flags.
 type flags_InstrumentationScope uint8
 
@@ -1869,30 +4929,49 @@ func (m *InstrumentationScope) SetVersion(v string) {
 }
 
 // Attributes returns the value of the attributes.
-func (m *InstrumentationScope) Attributes() (r []*KeyValue) {
+func (m *InstrumentationScope) Attributes() (r KeyValueSlice) {
 	if m._flags&flags_InstrumentationScope_Attributes_Decoded == 0 {
 		m.decodeAttributes()
 	}
-	return m.attributes
+	return KeyValueSlice{elems: &m.attributes, parent: &m._protoMessage}
 }
 
 // This is noinline, so that Attributes() is inlined instead.
+//
 //go:noinline
 func (m *InstrumentationScope) decodeAttributes() {
-	// Decode nested message(s).
-	for i := range m.attributes {
-		// TODO: decide how to handle decoding errors.
-		_ = m.attributes[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.attributes {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_InstrumentationScope_Attributes_Decoded
 }
 
+// AttributesE is the same as Attributes, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *InstrumentationScope) AttributesE() (r KeyValueSlice, err error) {
+	r = m.Attributes()
+	for _, elem := range m.attributes {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetAttributes sets the value of the attributes.
 func (m *InstrumentationScope) SetAttributes(v []*KeyValue) {
 	m.attributes = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_InstrumentationScope_Attributes_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.attributes {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
@@ -1900,34 +4979,6 @@ func (m *InstrumentationScope) SetAttributes(v []*KeyValue) {
 	m._protoMessage.MarkModified()
 }
 
-func (m *InstrumentationScope) AttributesRemoveIf(f func(*KeyValue) bool) {
-	// Call getter to load the field.
-	m.Attributes()
-
-	newLen := 0
-	for i := 0; i < len(m.attributes); i++ {
-		if f(m.attributes[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.attributes[newLen] != nil {
-			m.attributes[newLen].Free()
-		}
-		m.attributes[newLen] = m.attributes[i]
-		m.attributes[i] = nil
-		newLen++
-	}
-	if newLen != len(m.attributes) {
-		m.attributes = m.attributes[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
-}
-
 // DroppedAttributesCount returns the value of the droppedAttributesCount.
 func (m *InstrumentationScope) DroppedAttributesCount() (r uint32) {
 	return m.droppedAttributesCount
@@ -1941,6 +4992,13 @@ func (m *InstrumentationScope) SetDroppedAttributesCount(v uint32) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the InstrumentationScope schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *InstrumentationScope) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateInstrumentationScope(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -2023,152 +5081,756 @@ func (m *InstrumentationScope) decode() error {
 		case 0b0_0001_010: // field number 1 (name), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
+		case 0b0_0010_010: // field number 2 (version), wire type 2 (Bytes)
+			// Skip the one-byte varint.
+			buf.SkipByteUnsafe()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
+		case 0b0_0011_010: // field number 3 (attributes), wire type 2 (Bytes)
+			// Skip the one-byte varint.
+			buf.SkipByteUnsafe()
+			attributesCount++
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
+		case 0b0_0100_000: // field number 4 (droppedAttributesCount), wire type 0 (Varint)
+			// Skip the one-byte varint.
+			buf.SkipByteUnsafe()
+			if err := buf.SkipVarint(); err != nil {
+				return err
+			}
+		default:
+			// Our speculation was wrong, the varint is more than one byte long.
+			// Do the full slow decoding.
+			v, err := buf.DecodeVarint()
+			if err != nil {
+				return err
+			}
+			fieldNum, wireType, err := codec.AsTagAndWireType(v)
+			if err != nil {
+				return err
+			}
+
+			switch fieldNum {
+			default:
+				// Unknown field number.
+				if err := buf.SkipFieldByWireType(wireType); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Pre-allocate slices for repeated fields.
+	if cap(m.attributes) < attributesCount {
+		// Need new space.
+		m.attributes = make([]*KeyValue, attributesCount)
+	} else {
+		// Existing capacity is enough.
+		m.attributes = m.attributes[0:attributesCount]
+	}
+	keyValuePool.GetSlice(m.attributes)
+
+	// Reset the buffer to start iterating over the fields again
+	buf.Reset(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+
+	// Set slice indexes to 0 to begin iterating over repeated fields.
+	attributesCount = 0
+	for !buf.EOF() {
+		// We need to read a varint that represents the key that encodes the field number
+		// and wire type. Speculate that the varint is one byte length and switch on it.
+		// This is the hot path that is most common when field number is <= 15.
+		b := buf.PeekByteUnsafe()
+		switch b {
+		case 0b0_0001_010: // field number 1 (name), wire type 2 (Bytes)
+			// Skip the one-byte varint.
+			buf.SkipByteUnsafe()
+			v, err := buf.AsStringUnsafe()
+			if err != nil {
+				return err
+			}
+			m.name = v
 		case 0b0_0010_010: // field number 2 (version), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			v, err := buf.AsStringUnsafe()
+			if err != nil {
+				return err
+			}
+			m.version = v
 		case 0b0_0011_010: // field number 3 (attributes), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
+			// Get the bytes for the embedded message.
+			v, err := buf.AsBytesUnsafe()
+			if err != nil {
+				return err
+			}
+
+			// The slice is pre-allocated, assign to the appropriate index.
+			elem := m.attributes[attributesCount]
 			attributesCount++
-			buf.SkipRawBytes()
+			elem._protoMessage.Parent = &m._protoMessage
+			elem._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)
 		case 0b0_0100_000: // field number 4 (droppedAttributesCount), wire type 0 (Varint)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipVarint()
+			v, err := buf.AsUint32()
+			if err != nil {
+				return err
+			}
+			m.droppedAttributesCount = v
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
 			}
-			fieldNum, wireType, err := codec.AsTagAndWireType(v)
+			fieldNum, wireType, err := codec.AsTagAndWireType(v)
+			if err != nil {
+				return err
+			}
+
+			switch fieldNum {
+			default:
+				// Unknown field number.
+				if err := buf.SkipFieldByWireType(wireType); err != nil {
+					return err
+				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *InstrumentationScope) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *InstrumentationScope) calcSize() int {
+	size := 0
+	// Size of "name".
+	if m.name != "" {
+		size += 1 + molecule.SizeBytes(len(m.name))
+	}
+	// Size of "version".
+	if m.version != "" {
+		size += 1 + molecule.SizeBytes(len(m.version))
+	}
+	// Size of "attributes".
+	for _, elem := range m.attributes {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Size of "droppedAttributesCount".
+	if m.droppedAttributesCount != 0 {
+		size += 1 + molecule.SizeVarint(uint64(m.droppedAttributesCount))
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
+var prepared_InstrumentationScope_Name = molecule.PrepareStringField(1)
+var prepared_InstrumentationScope_Version = molecule.PrepareStringField(2)
+var prepared_InstrumentationScope_Attributes = molecule.PrepareEmbeddedField(3)
+var prepared_InstrumentationScope_DroppedAttributesCount = molecule.PrepareUint32Field(4)
+
+func (m *InstrumentationScope) Marshal(ps *molecule.ProtoStream) error {
+	if m._protoMessage.IsModified() {
+		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
+		// Marshal "name".
+		ps.StringPrepared(prepared_InstrumentationScope_Name, m.name)
+		// Marshal "version".
+		ps.StringPrepared(prepared_InstrumentationScope_Version, m.version)
+		// Marshal "attributes".
+		for _, elem := range m.attributes {
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_InstrumentationScope_Attributes, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_InstrumentationScope_Attributes)
+			}
+		}
+		// Marshal "droppedAttributesCount".
+		ps.Uint32Prepared(prepared_InstrumentationScope_DroppedAttributesCount, m.droppedAttributesCount)
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
+	} else {
+		// We have the original bytes and the message is unchanged. Use the original bytes.
+		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *InstrumentationScope) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *InstrumentationScope) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *InstrumentationScope) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := instrumentationScopePool.Get()
+	defer instrumentationScopePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *InstrumentationScope) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if m.name != "" {
+		js.Name("name")
+		js.String(m.name)
+	}
+	if m.version != "" {
+		js.Name("version")
+		js.String(m.version)
+	}
+	if len(m.attributes) > 0 {
+		js.Name("attributes")
+		js.BeginArray()
+		for _, elem := range m.attributes {
+			if m._flags&flags_InstrumentationScope_Attributes_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	if m.droppedAttributesCount != 0 {
+		js.Name("droppedAttributesCount")
+		js.Uint32(m.droppedAttributesCount)
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *InstrumentationScope) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_InstrumentationScope_Attributes_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "name":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.name = v
+		case "version":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.version = v
+		case "attributes":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.attributes = append(m.attributes, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		case "droppedAttributesCount", "dropped_attributes_count":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadUint32()
 			if err != nil {
 				return err
 			}
-
-			switch fieldNum {
-			default:
-				// Unknown field number.
-				if err := buf.SkipFieldByWireType(wireType); err != nil {
-					return err
-				}
-			}
+			m.droppedAttributesCount = v
+		default:
+			return fmt.Errorf("unknown field %q in InstrumentationScope", name)
 		}
 	}
+	return nil
+}
 
-	// Pre-allocate slices for repeated fields.
-	if cap(m.attributes) < attributesCount {
-		// Need new space.
-		m.attributes = make([]*KeyValue, attributesCount)
-	} else {
-		// Existing capacity is enough.
-		m.attributes = m.attributes[0:attributesCount]
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *InstrumentationScope) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
 	}
-	keyValuePool.GetSlice(m.attributes)
+	tw.End()
+	return tw.BufferBytes()
+}
 
-	// Reset the buffer to start iterating over the fields again
-	buf.Reset(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *InstrumentationScope) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
 
-	// Set slice indexes to 0 to begin iterating over repeated fields.
-	attributesCount = 0
-	for !buf.EOF() {
-		// We need to read a varint that represents the key that encodes the field number
-		// and wire type. Speculate that the varint is one byte length and switch on it.
-		// This is the hot path that is most common when field number is <= 15.
-		b := buf.PeekByteUnsafe()
-		switch b {
-		case 0b0_0001_010: // field number 1 (name), wire type 2 (Bytes)
-			// Skip the one-byte varint.
-			buf.SkipByteUnsafe()
-			v, err := buf.AsStringUnsafe()
-			if err != nil {
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *InstrumentationScope) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := instrumentationScopePool.Get()
+	defer instrumentationScopePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *InstrumentationScope) MarshalTextStream(tw *textstream.Writer) error {
+	if m.name != "" {
+		tw.Name("name")
+		tw.String(m.name)
+	}
+	if m.version != "" {
+		tw.Name("version")
+		tw.String(m.version)
+	}
+	for _, elem := range m.attributes {
+		tw.Name("attributes")
+		tw.BeginMessage()
+		if m._flags&flags_InstrumentationScope_Attributes_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
 				return err
 			}
-			m.name = v
-		case 0b0_0010_010: // field number 2 (version), wire type 2 (Bytes)
-			// Skip the one-byte varint.
-			buf.SkipByteUnsafe()
-			v, err := buf.AsStringUnsafe()
-			if err != nil {
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	if m.droppedAttributesCount != 0 {
+		tw.Name("dropped_attributes_count")
+		tw.Uint32(m.droppedAttributesCount)
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *InstrumentationScope) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_InstrumentationScope_Attributes_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "name":
+			if err := r.Colon(false); err != nil {
 				return err
 			}
-			m.version = v
-		case 0b0_0011_010: // field number 3 (attributes), wire type 2 (Bytes)
-			// Skip the one-byte varint.
-			buf.SkipByteUnsafe()
-			// Get the bytes for the embedded message.
-			v, err := buf.AsBytesUnsafe()
+			v, err := r.ReadString()
 			if err != nil {
 				return err
 			}
-
-			// The slice is pre-allocated, assign to the appropriate index.
-			elem := m.attributes[attributesCount]
-			attributesCount++
-			elem._protoMessage.Parent = &m._protoMessage
-			elem._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)
-		case 0b0_0100_000: // field number 4 (droppedAttributesCount), wire type 0 (Varint)
-			// Skip the one-byte varint.
-			buf.SkipByteUnsafe()
-			v, err := buf.AsUint32()
-			if err != nil {
+			m.name = v
+		case "version":
+			if err := r.Colon(false); err != nil {
 				return err
 			}
-			m.droppedAttributesCount = v
-		default:
-			// Our speculation was wrong, the varint is more than one byte long.
-			// Do the full slow decoding.
-			v, err := buf.DecodeVarint()
+			v, err := r.ReadString()
 			if err != nil {
 				return err
 			}
-			fieldNum, wireType, err := codec.AsTagAndWireType(v)
-			if err != nil {
+			m.version = v
+		case "attributes":
+			if err := r.Colon(true); err != nil {
 				return err
 			}
-
-			switch fieldNum {
-			default:
-				// Unknown field number.
-				if err := buf.SkipFieldByWireType(wireType); err != nil {
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.attributes = append(m.attributes, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
 					return err
 				}
 			}
+		case "dropped_attributes_count":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.droppedAttributesCount = v
+		default:
+			return fmt.Errorf("unknown field %q in InstrumentationScope", name)
 		}
 	}
 	return nil
 }
 
-var prepared_InstrumentationScope_Name = molecule.PrepareStringField(1)
-var prepared_InstrumentationScope_Version = molecule.PrepareStringField(2)
-var prepared_InstrumentationScope_Attributes = molecule.PrepareEmbeddedField(3)
-var prepared_InstrumentationScope_DroppedAttributesCount = molecule.PrepareUint32Field(4)
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *InstrumentationScope) ProtoReflect() protoreflect.Message {
+	return instrumentationScopeReflectInfo.MessageOf(m)
+}
 
-func (m *InstrumentationScope) Marshal(ps *molecule.ProtoStream) error {
-	if m._protoMessage.IsModified() {
-		// The struct is modified, marshal from the struct fields.
-		// Marshal "name".
-		ps.StringPrepared(prepared_InstrumentationScope_Name, m.name)
-		// Marshal "version".
-		ps.StringPrepared(prepared_InstrumentationScope_Version, m.version)
-		// Marshal "attributes".
-		for _, elem := range m.attributes {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
-			}
-			ps.EndEmbeddedPrepared(token, prepared_InstrumentationScope_Attributes)
+// reflectAttributes returns the view of the attributes list.
+func (m *InstrumentationScope) reflectAttributes() protoreflect.List {
+	if m._flags&flags_InstrumentationScope_Attributes_Decoded == 0 {
+		m.decodeAttributes()
+	}
+	return lazyreflect.NewMessageList(&m.attributes, &m._protoMessage, NewKeyValue)
+}
+
+var instrumentationScopeReflectInfo = &lazyreflect.MessageInfo[InstrumentationScope]{
+	File:          file_logs_proto,
+	FullName:      "simple.InstrumentationScope",
+	NewMessage:    NewInstrumentationScope,
+	UnknownFields: (*InstrumentationScope).UnknownFields,
+	SetUnknownFields: func(m *InstrumentationScope, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[InstrumentationScope]{
+		{
+			// name
+			Has:   func(m *InstrumentationScope) bool { return m.name != "" },
+			Get:   func(m *InstrumentationScope) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.Name()) },
+			Set:   func(m *InstrumentationScope, v protoreflect.Value) { m.SetName(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *InstrumentationScope) { m.SetName("") },
+		},
+		{
+			// version
+			Has:   func(m *InstrumentationScope) bool { return m.version != "" },
+			Get:   func(m *InstrumentationScope) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.Version()) },
+			Set:   func(m *InstrumentationScope, v protoreflect.Value) { m.SetVersion(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *InstrumentationScope) { m.SetVersion("") },
+		},
+		{
+			// attributes
+			Has: func(m *InstrumentationScope) bool { return len(m.attributes) > 0 },
+			Get: func(m *InstrumentationScope) protoreflect.Value {
+				return protoreflect.ValueOfList(m.reflectAttributes())
+			},
+			Set: func(m *InstrumentationScope, v protoreflect.Value) {
+				m.SetAttributes(lazyreflect.MessagesFromList(v.List(), NewKeyValue))
+			},
+			Clear: func(m *InstrumentationScope) { m.SetAttributes(nil) },
+			Mutable: func(m *InstrumentationScope) protoreflect.Value {
+				return protoreflect.ValueOfList(m.reflectAttributes())
+			},
+		},
+		{
+			// dropped_attributes_count
+			Has: func(m *InstrumentationScope) bool { return m.droppedAttributesCount != 0 },
+			Get: func(m *InstrumentationScope) protoreflect.Value {
+				return lazyreflect.Uint32Conv.ToValue(m.DroppedAttributesCount())
+			},
+			Set: func(m *InstrumentationScope, v protoreflect.Value) {
+				m.SetDroppedAttributesCount(lazyreflect.Uint32Conv.FromValue(v))
+			},
+			Clear: func(m *InstrumentationScope) { m.SetDroppedAttributesCount(0) },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *InstrumentationScope) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *InstrumentationScope) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// InstrumentationScopeSlice is a repeated field of InstrumentationScope messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type InstrumentationScopeSlice struct {
+	elems  *[]*InstrumentationScope
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s InstrumentationScopeSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s InstrumentationScopeSlice) At(i int) *InstrumentationScope {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s InstrumentationScopeSlice) Range(f func(i int, elem *InstrumentationScope) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
 		}
-		// Marshal "droppedAttributesCount".
-		ps.Uint32Prepared(prepared_InstrumentationScope_DroppedAttributesCount, m.droppedAttributesCount)
-	} else {
-		// We have the original bytes and the message is unchanged. Use the original bytes.
-		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
 	}
-	return nil
+}
+
+// Append appends the elements to the end of the slice.
+func (s InstrumentationScopeSlice) Append(elems ...*InstrumentationScope) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s InstrumentationScopeSlice) AppendNew() *InstrumentationScope {
+	elem := instrumentationScopePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s InstrumentationScopeSlice) InsertAt(i int, elem *InstrumentationScope) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s InstrumentationScopeSlice) RemoveAt(i int) {
+	elems := *s.elems
+	instrumentationScopePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s InstrumentationScopeSlice) RemoveIf(f func(elem *InstrumentationScope) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			instrumentationScopePool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s InstrumentationScopeSlice) Sort(less func(a, b *InstrumentationScope) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *InstrumentationScope) reset() {
+	elem := m
+
+	// Release nested attributes recursively to their pool.
+	keyValuePool.ReleaseSlice(elem.attributes)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.name = ""
+	elem.version = ""
+	elem.attributes = elem.attributes[:0]
+	elem.droppedAttributesCount = 0
 }
 
 // Pool of InstrumentationScope structs.
@@ -2233,16 +5895,7 @@ func (p *instrumentationScopePoolType) GetSlice(r []*InstrumentationScope) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *instrumentationScopePoolType) ReleaseSlice(slice []*InstrumentationScope) {
 	for _, elem := range slice {
-		// Release nested attributes recursively to their pool.
-		keyValuePool.ReleaseSlice(elem.attributes)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.name = ""
-		elem.version = ""
-		elem.attributes = elem.attributes[:0]
-		elem.droppedAttributesCount = 0
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -2254,16 +5907,7 @@ func (p *instrumentationScopePoolType) ReleaseSlice(slice []*InstrumentationScop
 
 // Release an element back to the pool.
 func (p *instrumentationScopePoolType) Release(elem *InstrumentationScope) {
-	// Release nested attributes recursively to their pool.
-	keyValuePool.ReleaseSlice(elem.attributes)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.name = ""
-	elem.version = ""
-	elem.attributes = elem.attributes[:0]
-	elem.droppedAttributesCount = 0
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -2272,11 +5916,50 @@ func (p *instrumentationScopePoolType) Release(elem *InstrumentationScope) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *InstrumentationScope) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *InstrumentationScope) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *InstrumentationScope) XXX_CloneInto(c *InstrumentationScope) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *InstrumentationScope) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *InstrumentationScope) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateInstrumentationScope is for use by the code generated for other packages only.
+func XXX_ValidateInstrumentationScope(b []byte) error {
+	return validateInstrumentationScope(b)
+}
+
+// XXX_NewInstrumentationScopeSlice is for use by the code generated for other packages only.
+func XXX_NewInstrumentationScopeSlice(elems *[]*InstrumentationScope, parent *protomessage.ProtoMessage) InstrumentationScopeSlice {
+	return InstrumentationScopeSlice{elems: elems, parent: parent}
+}
+
+// XXX_InstrumentationScopePool is for use by the code generated for other packages only.
+var XXX_InstrumentationScopePool = &instrumentationScopePool
+
 // ====================== LogRecord message implementation ======================
 
 type LogRecord struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_LogRecord
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_LogRecord
+	_unknownFields protomessage.UnknownFields
 
 	timeUnixNano           uint64
 	observedTimeUnixNano   uint64
@@ -2289,6 +5972,20 @@ type LogRecord struct {
 	spanId                 []byte
 }
 
+// NewLogRecord returns an empty LogRecord message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewLogRecord() *LogRecord {
+	return logRecordPool.Get()
+}
+
+// NewLogRecordSlice returns a slice of n empty LogRecord messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewLogRecordSlice(n int) []*LogRecord {
+	r := make([]*LogRecord, n)
+	logRecordPool.GetSlice(r)
+	return r
+}
+
 // UnmarshalLogRecord unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -2309,16 +6006,161 @@ func UnmarshalLogRecord(bytes []byte, opts lazyproto.UnmarshalOpts) (*LogRecord,
 
 	m := logRecordPool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalLogRecord(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *LogRecord) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateLogRecord(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *LogRecord) Free() {
 	logRecordPool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *LogRecord) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *LogRecord) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*LogRecord)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *LogRecord) Clone() *LogRecord {
+	c := logRecordPool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *LogRecord) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *LogRecord) cloneInto(c *LogRecord) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	c.timeUnixNano = m.timeUnixNano
+	c.observedTimeUnixNano = m.observedTimeUnixNano
+	c.severityNumber = m.severityNumber
+	c.severityText = m.severityText
+	// Clone attributes elements into structs taken from the pool all at once.
+	if cap(c.attributes) < len(m.attributes) {
+		c.attributes = make([]*KeyValue, len(m.attributes))
+	} else {
+		c.attributes = c.attributes[:len(m.attributes)]
+	}
+	keyValuePool.GetSlice(c.attributes)
+	for i, elem := range m.attributes {
+		elem.cloneInto(c.attributes[i])
+		c.attributes[i]._protoMessage.Parent = &c._protoMessage
+	}
+	c.droppedAttributesCount = m.droppedAttributesCount
+	c.flags = m.flags
+	c.traceId = m.traceId
+	c.spanId = m.spanId
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *LogRecord) Equal(other *LogRecord) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if m.TimeUnixNano() != other.TimeUnixNano() {
+		return false
+	}
+	if m.ObservedTimeUnixNano() != other.ObservedTimeUnixNano() {
+		return false
+	}
+	if m.SeverityNumber() != other.SeverityNumber() {
+		return false
+	}
+	if m.SeverityText() != other.SeverityText() {
+		return false
+	}
+	{
+		a, b := m.Attributes(), other.Attributes()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	if m.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		return false
+	}
+	if m.Flags() != other.Flags() {
+		return false
+	}
+	if !bytes.Equal(m.TraceId(), other.TraceId()) {
+		return false
+	}
+	if !bytes.Equal(m.SpanId(), other.SpanId()) {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_LogRecord is the type of the bit flags.
 type flags_LogRecord uint8
 
@@ -2378,30 +6220,49 @@ func (m *LogRecord) SetSeverityText(v string) {
 }
 
 // Attributes returns the value of the attributes.
-func (m *LogRecord) Attributes() (r []*KeyValue) {
+func (m *LogRecord) Attributes() (r KeyValueSlice) {
 	if m._flags&flags_LogRecord_Attributes_Decoded == 0 {
 		m.decodeAttributes()
 	}
-	return m.attributes
+	return KeyValueSlice{elems: &m.attributes, parent: &m._protoMessage}
 }
 
 // This is noinline, so that Attributes() is inlined instead.
+//
 //go:noinline
 func (m *LogRecord) decodeAttributes() {
-	// Decode nested message(s).
-	for i := range m.attributes {
-		// TODO: decide how to handle decoding errors.
-		_ = m.attributes[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.attributes {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_LogRecord_Attributes_Decoded
 }
 
+// AttributesE is the same as Attributes, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *LogRecord) AttributesE() (r KeyValueSlice, err error) {
+	r = m.Attributes()
+	for _, elem := range m.attributes {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetAttributes sets the value of the attributes.
 func (m *LogRecord) SetAttributes(v []*KeyValue) {
 	m.attributes = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_LogRecord_Attributes_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.attributes {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
@@ -2409,34 +6270,6 @@ func (m *LogRecord) SetAttributes(v []*KeyValue) {
 	m._protoMessage.MarkModified()
 }
 
-func (m *LogRecord) AttributesRemoveIf(f func(*KeyValue) bool) {
-	// Call getter to load the field.
-	m.Attributes()
-
-	newLen := 0
-	for i := 0; i < len(m.attributes); i++ {
-		if f(m.attributes[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.attributes[newLen] != nil {
-			m.attributes[newLen].Free()
-		}
-		m.attributes[newLen] = m.attributes[i]
-		m.attributes[i] = nil
-		newLen++
-	}
-	if newLen != len(m.attributes) {
-		m.attributes = m.attributes[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
-}
-
 // DroppedAttributesCount returns the value of the droppedAttributesCount.
 func (m *LogRecord) DroppedAttributesCount() (r uint32) {
 	return m.droppedAttributesCount
@@ -2489,6 +6322,13 @@ func (m *LogRecord) SetSpanId(v []byte) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the LogRecord schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *LogRecord) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateLogRecord(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -2607,40 +6447,58 @@ func (m *LogRecord) decode() error {
 		case 0b0_0001_001: // field number 1 (timeUnixNano), wire type 1 (Fixed64)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipFixed64()
+			if err := buf.SkipFixed64(); err != nil {
+				return err
+			}
 		case 0b0_1011_001: // field number 11 (observedTimeUnixNano), wire type 1 (Fixed64)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipFixed64()
+			if err := buf.SkipFixed64(); err != nil {
+				return err
+			}
 		case 0b0_0010_000: // field number 2 (severityNumber), wire type 0 (Varint)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipVarint()
+			if err := buf.SkipVarint(); err != nil {
+				return err
+			}
 		case 0b0_0011_010: // field number 3 (severityText), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0110_010: // field number 6 (attributes), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			attributesCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_0111_000: // field number 7 (droppedAttributesCount), wire type 0 (Varint)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipVarint()
+			if err := buf.SkipVarint(); err != nil {
+				return err
+			}
 		case 0b0_1000_101: // field number 8 (flags), wire type 5 (Fixed32)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipFixed32()
+			if err := buf.SkipFixed32(); err != nil {
+				return err
+			}
 		case 0b0_1001_010: // field number 9 (traceId), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		case 0b0_1010_010: // field number 10 (spanId), wire type 2 (Bytes)
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -2765,6 +6623,8 @@ func (m *LogRecord) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -2780,12 +6640,75 @@ func (m *LogRecord) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *LogRecord) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *LogRecord) calcSize() int {
+	size := 0
+	// Size of "timeUnixNano".
+	if m.timeUnixNano != 0 {
+		size += 9
+	}
+	// Size of "severityNumber".
+	if m.severityNumber != 0 {
+		size += 1 + molecule.SizeVarint(uint64(uint32(m.severityNumber)))
+	}
+	// Size of "severityText".
+	if m.severityText != "" {
+		size += 1 + molecule.SizeBytes(len(m.severityText))
+	}
+	// Size of "attributes".
+	for _, elem := range m.attributes {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Size of "droppedAttributesCount".
+	if m.droppedAttributesCount != 0 {
+		size += 1 + molecule.SizeVarint(uint64(m.droppedAttributesCount))
+	}
+	// Size of "flags".
+	if m.flags != 0 {
+		size += 5
+	}
+	// Size of "traceId".
+	if len(m.traceId) != 0 {
+		size += 1 + molecule.SizeBytes(len(m.traceId))
+	}
+	// Size of "spanId".
+	if len(m.spanId) != 0 {
+		size += 1 + molecule.SizeBytes(len(m.spanId))
+	}
+	// Size of "observedTimeUnixNano".
+	if m.observedTimeUnixNano != 0 {
+		size += 9
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_LogRecord_TimeUnixNano = molecule.PrepareFixed64Field(1)
 var prepared_LogRecord_ObservedTimeUnixNano = molecule.PrepareFixed64Field(11)
 var prepared_LogRecord_SeverityNumber = molecule.PrepareUint32Field(2)
@@ -2799,6 +6722,19 @@ var prepared_LogRecord_SpanId = molecule.PrepareBytesField(10)
 func (m *LogRecord) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "timeUnixNano".
 		ps.Fixed64Prepared(prepared_LogRecord_TimeUnixNano, m.timeUnixNano)
 		// Marshal "severityNumber".
@@ -2807,27 +6743,744 @@ func (m *LogRecord) Marshal(ps *molecule.ProtoStream) error {
 		ps.StringPrepared(prepared_LogRecord_SeverityText, m.severityText)
 		// Marshal "attributes".
 		for _, elem := range m.attributes {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_LogRecord_Attributes, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_LogRecord_Attributes)
+			}
+		}
+		// Marshal "droppedAttributesCount".
+		ps.Uint32Prepared(prepared_LogRecord_DroppedAttributesCount, m.droppedAttributesCount)
+		// Marshal "flags".
+		ps.Fixed32Prepared(prepared_LogRecord_Flags, m.flags)
+		// Marshal "traceId".
+		ps.BytesPrepared(prepared_LogRecord_TraceId, m.traceId)
+		// Marshal "spanId".
+		ps.BytesPrepared(prepared_LogRecord_SpanId, m.spanId)
+		// Marshal "observedTimeUnixNano".
+		ps.Fixed64Prepared(prepared_LogRecord_ObservedTimeUnixNano, m.observedTimeUnixNano)
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
+	} else {
+		// We have the original bytes and the message is unchanged. Use the original bytes.
+		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *LogRecord) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *LogRecord) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *LogRecord) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := logRecordPool.Get()
+	defer logRecordPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *LogRecord) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if m.timeUnixNano != 0 {
+		js.Name("timeUnixNano")
+		js.Uint64(m.timeUnixNano)
+	}
+	if m.observedTimeUnixNano != 0 {
+		js.Name("observedTimeUnixNano")
+		js.Uint64(m.observedTimeUnixNano)
+	}
+	if m.severityNumber != 0 {
+		js.Name("severityNumber")
+		js.Enum(uint32(m.severityNumber), SeverityNumber_name)
+	}
+	if m.severityText != "" {
+		js.Name("severityText")
+		js.String(m.severityText)
+	}
+	if len(m.attributes) > 0 {
+		js.Name("attributes")
+		js.BeginArray()
+		for _, elem := range m.attributes {
+			if m._flags&flags_LogRecord_Attributes_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	if m.droppedAttributesCount != 0 {
+		js.Name("droppedAttributesCount")
+		js.Uint32(m.droppedAttributesCount)
+	}
+	if m.flags != 0 {
+		js.Name("flags")
+		js.Uint32(m.flags)
+	}
+	if len(m.traceId) > 0 {
+		js.Name("traceId")
+		js.Bytes(m.traceId)
+	}
+	if len(m.spanId) > 0 {
+		js.Name("spanId")
+		js.Bytes(m.spanId)
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *LogRecord) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_LogRecord_Attributes_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "timeUnixNano", "time_unix_nano":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadUint64()
+			if err != nil {
+				return err
+			}
+			m.timeUnixNano = v
+		case "observedTimeUnixNano", "observed_time_unix_nano":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadUint64()
+			if err != nil {
+				return err
+			}
+			m.observedTimeUnixNano = v
+		case "severityNumber", "severity_number":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			ev, err := r.ReadEnum(SeverityNumber_value)
+			if err != nil {
+				return err
+			}
+			v := SeverityNumber(ev)
+			m.severityNumber = v
+		case "severityText", "severity_text":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.severityText = v
+		case "attributes":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.attributes = append(m.attributes, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		case "droppedAttributesCount", "dropped_attributes_count":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.droppedAttributesCount = v
+		case "flags":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.flags = v
+		case "traceId", "trace_id":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadBytes()
+			if err != nil {
+				return err
+			}
+			m.traceId = v
+		case "spanId", "span_id":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadBytes()
+			if err != nil {
+				return err
+			}
+			m.spanId = v
+		default:
+			return fmt.Errorf("unknown field %q in LogRecord", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *LogRecord) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *LogRecord) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *LogRecord) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := logRecordPool.Get()
+	defer logRecordPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *LogRecord) MarshalTextStream(tw *textstream.Writer) error {
+	if m.timeUnixNano != 0 {
+		tw.Name("time_unix_nano")
+		tw.Uint64(m.timeUnixNano)
+	}
+	if m.observedTimeUnixNano != 0 {
+		tw.Name("observed_time_unix_nano")
+		tw.Uint64(m.observedTimeUnixNano)
+	}
+	if m.severityNumber != 0 {
+		tw.Name("severity_number")
+		tw.Enum(uint32(m.severityNumber), SeverityNumber_name)
+	}
+	if m.severityText != "" {
+		tw.Name("severity_text")
+		tw.String(m.severityText)
+	}
+	for _, elem := range m.attributes {
+		tw.Name("attributes")
+		tw.BeginMessage()
+		if m._flags&flags_LogRecord_Attributes_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	if m.droppedAttributesCount != 0 {
+		tw.Name("dropped_attributes_count")
+		tw.Uint32(m.droppedAttributesCount)
+	}
+	if m.flags != 0 {
+		tw.Name("flags")
+		tw.Uint32(m.flags)
+	}
+	if len(m.traceId) > 0 {
+		tw.Name("trace_id")
+		tw.Bytes(m.traceId)
+	}
+	if len(m.spanId) > 0 {
+		tw.Name("span_id")
+		tw.Bytes(m.spanId)
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *LogRecord) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_LogRecord_Attributes_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "time_unix_nano":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadUint64()
+			if err != nil {
+				return err
+			}
+			m.timeUnixNano = v
+		case "observed_time_unix_nano":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadUint64()
+			if err != nil {
+				return err
+			}
+			m.observedTimeUnixNano = v
+		case "severity_number":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			ev, err := r.ReadEnum(SeverityNumber_value)
+			if err != nil {
+				return err
+			}
+			v := SeverityNumber(ev)
+			m.severityNumber = v
+		case "severity_text":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.severityText = v
+		case "attributes":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.attributes = append(m.attributes, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
+			}
+		case "dropped_attributes_count":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.droppedAttributesCount = v
+		case "flags":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadUint32()
+			if err != nil {
+				return err
+			}
+			m.flags = v
+		case "trace_id":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadBytes()
+			if err != nil {
+				return err
+			}
+			m.traceId = v
+		case "span_id":
+			if err := r.Colon(false); err != nil {
 				return err
 			}
-			ps.EndEmbeddedPrepared(token, prepared_LogRecord_Attributes)
+			v, err := r.ReadBytes()
+			if err != nil {
+				return err
+			}
+			m.spanId = v
+		default:
+			return fmt.Errorf("unknown field %q in LogRecord", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *LogRecord) ProtoReflect() protoreflect.Message {
+	return logRecordReflectInfo.MessageOf(m)
+}
+
+// reflectAttributes returns the view of the attributes list.
+func (m *LogRecord) reflectAttributes() protoreflect.List {
+	if m._flags&flags_LogRecord_Attributes_Decoded == 0 {
+		m.decodeAttributes()
+	}
+	return lazyreflect.NewMessageList(&m.attributes, &m._protoMessage, NewKeyValue)
+}
+
+var logRecordReflectInfo = &lazyreflect.MessageInfo[LogRecord]{
+	File:          file_logs_proto,
+	FullName:      "simple.LogRecord",
+	NewMessage:    NewLogRecord,
+	UnknownFields: (*LogRecord).UnknownFields,
+	SetUnknownFields: func(m *LogRecord, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[LogRecord]{
+		{
+			// time_unix_nano
+			Has:   func(m *LogRecord) bool { return m.timeUnixNano != 0 },
+			Get:   func(m *LogRecord) protoreflect.Value { return lazyreflect.Uint64Conv.ToValue(m.TimeUnixNano()) },
+			Set:   func(m *LogRecord, v protoreflect.Value) { m.SetTimeUnixNano(lazyreflect.Uint64Conv.FromValue(v)) },
+			Clear: func(m *LogRecord) { m.SetTimeUnixNano(0) },
+		},
+		{
+			// observed_time_unix_nano
+			Has: func(m *LogRecord) bool { return m.observedTimeUnixNano != 0 },
+			Get: func(m *LogRecord) protoreflect.Value { return lazyreflect.Uint64Conv.ToValue(m.ObservedTimeUnixNano()) },
+			Set: func(m *LogRecord, v protoreflect.Value) {
+				m.SetObservedTimeUnixNano(lazyreflect.Uint64Conv.FromValue(v))
+			},
+			Clear: func(m *LogRecord) { m.SetObservedTimeUnixNano(0) },
+		},
+		{
+			// severity_number
+			Has: func(m *LogRecord) bool { return m.severityNumber != 0 },
+			Get: func(m *LogRecord) protoreflect.Value {
+				return lazyreflect.EnumConv[SeverityNumber]().ToValue(m.SeverityNumber())
+			},
+			Set: func(m *LogRecord, v protoreflect.Value) {
+				m.SetSeverityNumber(lazyreflect.EnumConv[SeverityNumber]().FromValue(v))
+			},
+			Clear: func(m *LogRecord) { m.SetSeverityNumber(0) },
+		},
+		{
+			// severity_text
+			Has:   func(m *LogRecord) bool { return m.severityText != "" },
+			Get:   func(m *LogRecord) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.SeverityText()) },
+			Set:   func(m *LogRecord, v protoreflect.Value) { m.SetSeverityText(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *LogRecord) { m.SetSeverityText("") },
+		},
+		{
+			// attributes
+			Has: func(m *LogRecord) bool { return len(m.attributes) > 0 },
+			Get: func(m *LogRecord) protoreflect.Value { return protoreflect.ValueOfList(m.reflectAttributes()) },
+			Set: func(m *LogRecord, v protoreflect.Value) {
+				m.SetAttributes(lazyreflect.MessagesFromList(v.List(), NewKeyValue))
+			},
+			Clear:   func(m *LogRecord) { m.SetAttributes(nil) },
+			Mutable: func(m *LogRecord) protoreflect.Value { return protoreflect.ValueOfList(m.reflectAttributes()) },
+		},
+		{
+			// dropped_attributes_count
+			Has: func(m *LogRecord) bool { return m.droppedAttributesCount != 0 },
+			Get: func(m *LogRecord) protoreflect.Value {
+				return lazyreflect.Uint32Conv.ToValue(m.DroppedAttributesCount())
+			},
+			Set: func(m *LogRecord, v protoreflect.Value) {
+				m.SetDroppedAttributesCount(lazyreflect.Uint32Conv.FromValue(v))
+			},
+			Clear: func(m *LogRecord) { m.SetDroppedAttributesCount(0) },
+		},
+		{
+			// flags
+			Has:   func(m *LogRecord) bool { return m.flags != 0 },
+			Get:   func(m *LogRecord) protoreflect.Value { return lazyreflect.Uint32Conv.ToValue(m.Flags()) },
+			Set:   func(m *LogRecord, v protoreflect.Value) { m.SetFlags(lazyreflect.Uint32Conv.FromValue(v)) },
+			Clear: func(m *LogRecord) { m.SetFlags(0) },
+		},
+		{
+			// trace_id
+			Has:   func(m *LogRecord) bool { return len(m.traceId) > 0 },
+			Get:   func(m *LogRecord) protoreflect.Value { return lazyreflect.BytesConv.ToValue(m.TraceId()) },
+			Set:   func(m *LogRecord, v protoreflect.Value) { m.SetTraceId(lazyreflect.BytesConv.FromValue(v)) },
+			Clear: func(m *LogRecord) { m.SetTraceId(nil) },
+		},
+		{
+			// span_id
+			Has:   func(m *LogRecord) bool { return len(m.spanId) > 0 },
+			Get:   func(m *LogRecord) protoreflect.Value { return lazyreflect.BytesConv.ToValue(m.SpanId()) },
+			Set:   func(m *LogRecord, v protoreflect.Value) { m.SetSpanId(lazyreflect.BytesConv.FromValue(v)) },
+			Clear: func(m *LogRecord) { m.SetSpanId(nil) },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *LogRecord) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *LogRecord) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// LogRecordSlice is a repeated field of LogRecord messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type LogRecordSlice struct {
+	elems  *[]*LogRecord
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s LogRecordSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s LogRecordSlice) At(i int) *LogRecord {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s LogRecordSlice) Range(f func(i int, elem *LogRecord) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s LogRecordSlice) Append(elems ...*LogRecord) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s LogRecordSlice) AppendNew() *LogRecord {
+	elem := logRecordPool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s LogRecordSlice) InsertAt(i int, elem *LogRecord) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s LogRecordSlice) RemoveAt(i int) {
+	elems := *s.elems
+	logRecordPool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s LogRecordSlice) RemoveIf(f func(elem *LogRecord) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			logRecordPool.Release(elem)
+			continue
 		}
-		// Marshal "droppedAttributesCount".
-		ps.Uint32Prepared(prepared_LogRecord_DroppedAttributesCount, m.droppedAttributesCount)
-		// Marshal "flags".
-		ps.Fixed32Prepared(prepared_LogRecord_Flags, m.flags)
-		// Marshal "traceId".
-		ps.BytesPrepared(prepared_LogRecord_TraceId, m.traceId)
-		// Marshal "spanId".
-		ps.BytesPrepared(prepared_LogRecord_SpanId, m.spanId)
-		// Marshal "observedTimeUnixNano".
-		ps.Fixed64Prepared(prepared_LogRecord_ObservedTimeUnixNano, m.observedTimeUnixNano)
-	} else {
-		// We have the original bytes and the message is unchanged. Use the original bytes.
-		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+		elems[newLen] = elem
+		newLen++
 	}
-	return nil
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s LogRecordSlice) Sort(less func(a, b *LogRecord) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *LogRecord) reset() {
+	elem := m
+
+	// Release nested attributes recursively to their pool.
+	keyValuePool.ReleaseSlice(elem.attributes)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.timeUnixNano = 0
+	elem.observedTimeUnixNano = 0
+	elem.severityNumber = SeverityNumber(0)
+	elem.severityText = ""
+	elem.attributes = elem.attributes[:0]
+	elem.droppedAttributesCount = 0
+	elem.flags = 0
+	elem.traceId = nil
+	elem.spanId = nil
 }
 
 // Pool of LogRecord structs.
@@ -2892,21 +7545,7 @@ func (p *logRecordPoolType) GetSlice(r []*LogRecord) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *logRecordPoolType) ReleaseSlice(slice []*LogRecord) {
 	for _, elem := range slice {
-		// Release nested attributes recursively to their pool.
-		keyValuePool.ReleaseSlice(elem.attributes)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.timeUnixNano = 0
-		elem.observedTimeUnixNano = 0
-		elem.severityNumber = SeverityNumber(0)
-		elem.severityText = ""
-		elem.attributes = elem.attributes[:0]
-		elem.droppedAttributesCount = 0
-		elem.flags = 0
-		elem.traceId = nil
-		elem.spanId = nil
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -2918,21 +7557,7 @@ func (p *logRecordPoolType) ReleaseSlice(slice []*LogRecord) {
 
 // Release an element back to the pool.
 func (p *logRecordPoolType) Release(elem *LogRecord) {
-	// Release nested attributes recursively to their pool.
-	keyValuePool.ReleaseSlice(elem.attributes)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.timeUnixNano = 0
-	elem.observedTimeUnixNano = 0
-	elem.severityNumber = SeverityNumber(0)
-	elem.severityText = ""
-	elem.attributes = elem.attributes[:0]
-	elem.droppedAttributesCount = 0
-	elem.flags = 0
-	elem.traceId = nil
-	elem.spanId = nil
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -2941,16 +7566,69 @@ func (p *logRecordPoolType) Release(elem *LogRecord) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *LogRecord) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *LogRecord) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *LogRecord) XXX_CloneInto(c *LogRecord) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *LogRecord) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *LogRecord) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateLogRecord is for use by the code generated for other packages only.
+func XXX_ValidateLogRecord(b []byte) error {
+	return validateLogRecord(b)
+}
+
+// XXX_NewLogRecordSlice is for use by the code generated for other packages only.
+func XXX_NewLogRecordSlice(elems *[]*LogRecord, parent *protomessage.ProtoMessage) LogRecordSlice {
+	return LogRecordSlice{elems: elems, parent: parent}
+}
+
+// XXX_LogRecordPool is for use by the code generated for other packages only.
+var XXX_LogRecordPool = &logRecordPool
+
 // ====================== KeyValue message implementation ======================
 
 type KeyValue struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_KeyValue
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_KeyValue
+	_unknownFields protomessage.UnknownFields
 
 	key   string
 	value *AnyValue
 }
 
+// NewKeyValue returns an empty KeyValue message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewKeyValue() *KeyValue {
+	return keyValuePool.Get()
+}
+
+// NewKeyValueSlice returns a slice of n empty KeyValue messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewKeyValueSlice(n int) []*KeyValue {
+	r := make([]*KeyValue, n)
+	keyValuePool.GetSlice(r)
+	return r
+}
+
 // UnmarshalKeyValue unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -2971,16 +7649,119 @@ func UnmarshalKeyValue(bytes []byte, opts lazyproto.UnmarshalOpts) (*KeyValue, e
 
 	m := keyValuePool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalKeyValue(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *KeyValue) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateKeyValue(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *KeyValue) Free() {
 	keyValuePool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *KeyValue) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *KeyValue) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*KeyValue)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *KeyValue) Clone() *KeyValue {
+	c := keyValuePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *KeyValue) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *KeyValue) cloneInto(c *KeyValue) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	c.key = m.key
+	if m.value != nil {
+		c.value = anyValuePool.Get()
+		m.value.cloneInto(c.value)
+		c.value._protoMessage.Parent = &c._protoMessage
+	}
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *KeyValue) Equal(other *KeyValue) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if m.Key() != other.Key() {
+		return false
+	}
+	if !m.Value().Equal(other.Value()) {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_KeyValue is the type of the bit flags.
 type flags_KeyValue uint8
 
@@ -3009,28 +7790,54 @@ func (m *KeyValue) Value() (r *AnyValue) {
 }
 
 // This is noinline, so that Value() is inlined instead.
+//
 //go:noinline
 func (m *KeyValue) decodeValue() {
-	// Decode nested message(s).
+	// Decode nested message(s). The errors are recorded on the nested messages.
 	value := m.value
 	if value != nil {
-		// TODO: decide how to handle decoding errors.
-		_ = value.decode()
+		if err := value.decode(); err != nil {
+			value._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_KeyValue_Value_Decoded
 }
 
+// ValueE is the same as Value, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *KeyValue) ValueE() (r *AnyValue, err error) {
+	r = m.Value()
+	if r == nil {
+		return r, nil
+	}
+	return r, r.DecodeErr()
+}
+
 // SetValue sets the value of the value.
 func (m *KeyValue) SetValue(v *AnyValue) {
 	m.value = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_KeyValue_Value_Decoded
+
 	// Make sure the field's Parent points to this message.
-	v._protoMessage.Parent = &m._protoMessage
+	if v != nil {
+		v._protoMessage.Parent = &m._protoMessage
+	}
 
 	// Mark this message modified, if not already.
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the KeyValue schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *KeyValue) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateKeyValue(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -3054,113 +7861,603 @@ func validateKeyValue(b []byte) error {
 			if err != nil {
 				return err
 			}
-			err = validateAnyValue(v)
-			if err != nil {
-				return err
+			err = validateAnyValue(v)
+			if err != nil {
+				return err
+			}
+		default:
+			// Our speculation was wrong, the varint is more than one byte long.
+			// Do the full slow decoding.
+			v, err := buf.DecodeVarint()
+			if err != nil {
+				return err
+			}
+			fieldNum, wireType, err := codec.AsTagAndWireType(v)
+			if err != nil {
+				return err
+			}
+
+			switch fieldNum {
+			default:
+				// Unknown field number.
+				if err := buf.SkipFieldByWireType(wireType); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *KeyValue) decode() error {
+	buf := codec.NewBuffer(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+
+	// Reset all "decoded" and "presence" flags.
+	m._flags = 0
+
+	for !buf.EOF() {
+		// We need to read a varint that represents the key that encodes the field number
+		// and wire type. Speculate that the varint is one byte length and switch on it.
+		// This is the hot path that is most common when field number is <= 15.
+		b := buf.PeekByteUnsafe()
+		switch b {
+		case 0b0_0001_010: // field number 1 (key), wire type 2 (Bytes)
+			// Skip the one-byte varint.
+			buf.SkipByteUnsafe()
+			v, err := buf.AsStringUnsafe()
+			if err != nil {
+				return err
+			}
+			m.key = v
+		case 0b0_0010_010: // field number 2 (value), wire type 2 (Bytes)
+			// Skip the one-byte varint.
+			buf.SkipByteUnsafe()
+			// Get the bytes for the embedded message.
+			v, err := buf.AsBytesUnsafe()
+			if err != nil {
+				return err
+			}
+
+			// Get a struct for the embedded message from the pool.
+			m.value = anyValuePool.Get()
+			m.value._protoMessage.Parent = &m._protoMessage
+			m.value._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)
+		default:
+			// Our speculation was wrong, the varint is more than one byte long.
+			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
+			v, err := buf.DecodeVarint()
+			if err != nil {
+				return err
+			}
+			fieldNum, wireType, err := codec.AsTagAndWireType(v)
+			if err != nil {
+				return err
+			}
+
+			switch fieldNum {
+			default:
+				// Unknown field number.
+				if err := buf.SkipFieldByWireType(wireType); err != nil {
+					return err
+				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *KeyValue) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *KeyValue) calcSize() int {
+	size := 0
+	// Size of "key".
+	if m.key != "" {
+		size += 1 + molecule.SizeBytes(len(m.key))
+	}
+	// Size of "value".
+	value := m.value
+	if value != nil {
+		size += 1 + molecule.SizeBytes(value.Size())
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
+var prepared_KeyValue_Key = molecule.PrepareStringField(1)
+var prepared_KeyValue_Value = molecule.PrepareEmbeddedField(2)
+
+func (m *KeyValue) Marshal(ps *molecule.ProtoStream) error {
+	if m._protoMessage.IsModified() {
+		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
+		// Marshal "key".
+		ps.StringPrepared(prepared_KeyValue_Key, m.key)
+		// Marshal "value".
+		value := m.value
+		if value != nil {
+			if size, ok := value._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_KeyValue_Value, size)
+				if err := value.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := value.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_KeyValue_Value)
+			}
+		}
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
+	} else {
+		// We have the original bytes and the message is unchanged. Use the original bytes.
+		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *KeyValue) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *KeyValue) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *KeyValue) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := keyValuePool.Get()
+	defer keyValuePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *KeyValue) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if m.key != "" {
+		js.Name("key")
+		js.String(m.key)
+	}
+	if m.value != nil {
+		js.Name("value")
+		if m._flags&flags_KeyValue_Value_Decoded != 0 {
+			if err := m.value.MarshalJSONStream(js); err != nil {
+				return err
+			}
+		} else if err := m.value.marshalJSONUndecoded(js); err != nil {
+			return err
+		}
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *KeyValue) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_KeyValue_Value_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "key":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
 			}
-		default:
-			// Our speculation was wrong, the varint is more than one byte long.
-			// Do the full slow decoding.
-			v, err := buf.DecodeVarint()
+			v, err := r.ReadString()
 			if err != nil {
 				return err
 			}
-			fieldNum, wireType, err := codec.AsTagAndWireType(v)
-			if err != nil {
+			m.key = v
+		case "value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value != nil {
+				// Duplicate field, the last one wins.
+				anyValuePool.Release(m.value)
+			}
+			m.value = anyValuePool.Get()
+			m.value._protoMessage.Parent = &m._protoMessage
+			if err := m.value.UnmarshalJSONStream(r); err != nil {
 				return err
 			}
+		default:
+			return fmt.Errorf("unknown field %q in KeyValue", name)
+		}
+	}
+	return nil
+}
 
-			switch fieldNum {
-			default:
-				// Unknown field number.
-				if err := buf.SkipFieldByWireType(wireType); err != nil {
-					return err
-				}
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *KeyValue) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *KeyValue) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *KeyValue) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := keyValuePool.Get()
+	defer keyValuePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *KeyValue) MarshalTextStream(tw *textstream.Writer) error {
+	if m.key != "" {
+		tw.Name("key")
+		tw.String(m.key)
+	}
+	if m.value != nil {
+		tw.Name("value")
+		tw.BeginMessage()
+		if m._flags&flags_KeyValue_Value_Decoded != 0 {
+			if err := m.value.MarshalTextStream(tw); err != nil {
+				return err
 			}
+		} else if err := m.value.marshalTextUndecoded(tw); err != nil {
+			return err
 		}
+		tw.EndMessage()
 	}
 	return nil
 }
 
-func (m *KeyValue) decode() error {
-	buf := codec.NewBuffer(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
-
-	// Reset all "decoded" and "presence" flags.
-	m._flags = 0
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *KeyValue) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
 
-	for !buf.EOF() {
-		// We need to read a varint that represents the key that encodes the field number
-		// and wire type. Speculate that the varint is one byte length and switch on it.
-		// This is the hot path that is most common when field number is <= 15.
-		b := buf.PeekByteUnsafe()
-		switch b {
-		case 0b0_0001_010: // field number 1 (key), wire type 2 (Bytes)
-			// Skip the one-byte varint.
-			buf.SkipByteUnsafe()
-			v, err := buf.AsStringUnsafe()
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_KeyValue_Value_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "key":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadString()
 			if err != nil {
 				return err
 			}
 			m.key = v
-		case 0b0_0010_010: // field number 2 (value), wire type 2 (Bytes)
-			// Skip the one-byte varint.
-			buf.SkipByteUnsafe()
-			// Get the bytes for the embedded message.
-			v, err := buf.AsBytesUnsafe()
-			if err != nil {
+		case "value":
+			if err := r.Colon(true); err != nil {
 				return err
 			}
-
-			// Get a struct for the embedded message from the pool.
+			if m.value != nil {
+				// Duplicate field, the last one wins.
+				anyValuePool.Release(m.value)
+			}
 			m.value = anyValuePool.Get()
 			m.value._protoMessage.Parent = &m._protoMessage
-			m.value._protoMessage.Bytes = protomessage.BytesViewFromBytes(v)
-		default:
-			// Our speculation was wrong, the varint is more than one byte long.
-			// Do the full slow decoding.
-			v, err := buf.DecodeVarint()
-			if err != nil {
+			if err := r.BeginMessage(); err != nil {
 				return err
 			}
-			fieldNum, wireType, err := codec.AsTagAndWireType(v)
-			if err != nil {
+			if err := m.value.UnmarshalTextStream(r); err != nil {
 				return err
 			}
+		default:
+			return fmt.Errorf("unknown field %q in KeyValue", name)
+		}
+	}
+	return nil
+}
 
-			switch fieldNum {
-			default:
-				// Unknown field number.
-				if err := buf.SkipFieldByWireType(wireType); err != nil {
-					return err
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *KeyValue) ProtoReflect() protoreflect.Message {
+	return keyValueReflectInfo.MessageOf(m)
+}
+
+var keyValueReflectInfo = &lazyreflect.MessageInfo[KeyValue]{
+	File:          file_logs_proto,
+	FullName:      "simple.KeyValue",
+	NewMessage:    NewKeyValue,
+	UnknownFields: (*KeyValue).UnknownFields,
+	SetUnknownFields: func(m *KeyValue, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[KeyValue]{
+		{
+			// key
+			Has:   func(m *KeyValue) bool { return m.key != "" },
+			Get:   func(m *KeyValue) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.Key()) },
+			Set:   func(m *KeyValue, v protoreflect.Value) { m.SetKey(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *KeyValue) { m.SetKey("") },
+		},
+		{
+			// value
+			Has:   func(m *KeyValue) bool { return m.value != nil },
+			Get:   func(m *KeyValue) protoreflect.Value { return lazyreflect.ValueOfMessage(m.Value()) },
+			Set:   func(m *KeyValue, v protoreflect.Value) { m.SetValue(lazyreflect.MessageFromValue(v, NewAnyValue)) },
+			Clear: func(m *KeyValue) { m.SetValue(nil) },
+			Mutable: func(m *KeyValue) protoreflect.Value {
+				if m.Value() == nil {
+					m.SetValue(NewAnyValue())
 				}
-			}
+				return lazyreflect.ValueOfMessage(m.Value())
+			},
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *KeyValue) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *KeyValue) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// KeyValueSlice is a repeated field of KeyValue messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type KeyValueSlice struct {
+	elems  *[]*KeyValue
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s KeyValueSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s KeyValueSlice) At(i int) *KeyValue {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s KeyValueSlice) Range(f func(i int, elem *KeyValue) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
 		}
 	}
-	return nil
 }
 
-var prepared_KeyValue_Key = molecule.PrepareStringField(1)
-var prepared_KeyValue_Value = molecule.PrepareEmbeddedField(2)
+// Append appends the elements to the end of the slice.
+func (s KeyValueSlice) Append(elems ...*KeyValue) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
 
-func (m *KeyValue) Marshal(ps *molecule.ProtoStream) error {
-	if m._protoMessage.IsModified() {
-		// The struct is modified, marshal from the struct fields.
-		// Marshal "key".
-		ps.StringPrepared(prepared_KeyValue_Key, m.key)
-		// Marshal "value".
-		value := m.value
-		if value != nil {
-			token := ps.BeginEmbedded()
-			if err := value.Marshal(ps); err != nil {
-				return err
-			}
-			ps.EndEmbeddedPrepared(token, prepared_KeyValue_Value)
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s KeyValueSlice) AppendNew() *KeyValue {
+	elem := keyValuePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s KeyValueSlice) InsertAt(i int, elem *KeyValue) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s KeyValueSlice) RemoveAt(i int) {
+	elems := *s.elems
+	keyValuePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s KeyValueSlice) RemoveIf(f func(elem *KeyValue) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			keyValuePool.Release(elem)
+			continue
 		}
-	} else {
-		// We have the original bytes and the message is unchanged. Use the original bytes.
-		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+		elems[newLen] = elem
+		newLen++
 	}
-	return nil
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s KeyValueSlice) Sort(less func(a, b *KeyValue) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *KeyValue) reset() {
+	elem := m
+
+	// Release nested value recursively to their pool.
+	if elem.value != nil {
+		anyValuePool.Release(elem.value)
+	}
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.key = ""
+	elem.value = nil
 }
 
 // Pool of KeyValue structs.
@@ -3225,16 +8522,7 @@ func (p *keyValuePoolType) GetSlice(r []*KeyValue) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *keyValuePoolType) ReleaseSlice(slice []*KeyValue) {
 	for _, elem := range slice {
-		// Release nested value recursively to their pool.
-		if elem.value != nil {
-			anyValuePool.Release(elem.value)
-		}
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.key = ""
-		elem.value = nil
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -3246,16 +8534,7 @@ func (p *keyValuePoolType) ReleaseSlice(slice []*KeyValue) {
 
 // Release an element back to the pool.
 func (p *keyValuePoolType) Release(elem *KeyValue) {
-	// Release nested value recursively to their pool.
-	if elem.value != nil {
-		anyValuePool.Release(elem.value)
-	}
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.key = ""
-	elem.value = nil
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -3264,15 +8543,68 @@ func (p *keyValuePoolType) Release(elem *KeyValue) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *KeyValue) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *KeyValue) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *KeyValue) XXX_CloneInto(c *KeyValue) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *KeyValue) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *KeyValue) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateKeyValue is for use by the code generated for other packages only.
+func XXX_ValidateKeyValue(b []byte) error {
+	return validateKeyValue(b)
+}
+
+// XXX_NewKeyValueSlice is for use by the code generated for other packages only.
+func XXX_NewKeyValueSlice(elems *[]*KeyValue, parent *protomessage.ProtoMessage) KeyValueSlice {
+	return KeyValueSlice{elems: elems, parent: parent}
+}
+
+// XXX_KeyValuePool is for use by the code generated for other packages only.
+var XXX_KeyValuePool = &keyValuePool
+
 // ====================== AnyValue message implementation ======================
 
 type AnyValue struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_AnyValue
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_AnyValue
+	_unknownFields protomessage.UnknownFields
 
 	value oneof.OneOf
 }
 
+// NewAnyValue returns an empty AnyValue message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewAnyValue() *AnyValue {
+	return anyValuePool.Get()
+}
+
+// NewAnyValueSlice returns a slice of n empty AnyValue messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewAnyValueSlice(n int) []*AnyValue {
+	r := make([]*AnyValue, n)
+	anyValuePool.GetSlice(r)
+	return r
+}
+
 // UnmarshalAnyValue unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -3293,16 +8625,160 @@ func UnmarshalAnyValue(bytes []byte, opts lazyproto.UnmarshalOpts) (*AnyValue, e
 
 	m := anyValuePool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalAnyValue(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *AnyValue) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateAnyValue(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *AnyValue) Free() {
 	anyValuePool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *AnyValue) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *AnyValue) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*AnyValue)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *AnyValue) Clone() *AnyValue {
+	c := anyValuePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *AnyValue) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *AnyValue) cloneInto(c *AnyValue) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	c.value = m.value
+	// Embedded messages cannot be shared, clone them.
+	switch AnyValueValue(m.value.FieldIndex()) {
+	case AnyValueArrayValue:
+		ptr := (*ArrayValue)(m.value.PtrVal())
+		if ptr != nil {
+			elem := arrayValuePool.Get()
+			ptr.cloneInto(elem)
+			elem._protoMessage.Parent = &c._protoMessage
+			c.value = oneof.NewPtr(unsafe.Pointer(elem), int(AnyValueArrayValue))
+		}
+	case AnyValueKvlistValue:
+		ptr := (*KeyValueList)(m.value.PtrVal())
+		if ptr != nil {
+			elem := keyValueListPool.Get()
+			ptr.cloneInto(elem)
+			elem._protoMessage.Parent = &c._protoMessage
+			c.value = oneof.NewPtr(unsafe.Pointer(elem), int(AnyValueKvlistValue))
+		}
+	}
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *AnyValue) Equal(other *AnyValue) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if m.value.FieldIndex() != other.value.FieldIndex() {
+		return false
+	}
+	switch AnyValueValue(m.value.FieldIndex()) {
+	case AnyValueStringValue:
+		if m.StringValue() != other.StringValue() {
+			return false
+		}
+	case AnyValueBoolValue:
+		if m.BoolValue() != other.BoolValue() {
+			return false
+		}
+	case AnyValueIntValue:
+		if m.IntValue() != other.IntValue() {
+			return false
+		}
+	case AnyValueDoubleValue:
+		if !protomessage.EqualFloat64(m.DoubleValue(), other.DoubleValue()) {
+			return false
+		}
+	case AnyValueArrayValue:
+		if !m.ArrayValue().Equal(other.ArrayValue()) {
+			return false
+		}
+	case AnyValueKvlistValue:
+		if !m.KvlistValue().Equal(other.KvlistValue()) {
+			return false
+		}
+	case AnyValueBytesValue:
+		if !bytes.Equal(m.BytesValue(), other.BytesValue()) {
+			return false
+		}
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // AnyValueValue defines the possible types for oneof field "value".
 type AnyValueValue int
 
@@ -3428,26 +8904,45 @@ func (m *AnyValue) ArrayValue() (r *ArrayValue) {
 }
 
 // This is noinline, so that ArrayValue() is inlined instead.
+//
 //go:noinline
 func (m *AnyValue) decodeArrayValue() {
-	// Decode nested message(s).
+	// Decode nested message(s). The errors are recorded on the nested messages.
 	if m.value.FieldIndex() == int(AnyValueArrayValue) {
 		arrayValue := (*ArrayValue)(m.value.PtrVal())
 		if arrayValue != nil {
-			// TODO: decide how to handle decoding errors.
-			_ = arrayValue.decode()
+			if err := arrayValue.decode(); err != nil {
+				arrayValue._protoMessage.SetDecodeErr(err)
+			}
 		}
 	}
 	m._flags |= flags_AnyValue_ArrayValue_Decoded
 }
 
+// ArrayValueE is the same as ArrayValue, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *AnyValue) ArrayValueE() (r *ArrayValue, err error) {
+	r = m.ArrayValue()
+	if r == nil {
+		return r, nil
+	}
+	return r, r.DecodeErr()
+}
+
 // SetArrayValue sets the value of the arrayValue.
 // The oneof field "value" will be set to "arrayValue".
 func (m *AnyValue) SetArrayValue(v *ArrayValue) {
 	m.value = oneof.NewPtr(unsafe.Pointer(v), int(AnyValueArrayValue))
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_AnyValue_ArrayValue_Decoded
+
 	// Make sure the field's Parent points to this message.
-	v._protoMessage.Parent = &m._protoMessage
+	if v != nil {
+		v._protoMessage.Parent = &m._protoMessage
+	}
 
 	// Mark this message modified, if not already.
 	m._protoMessage.MarkModified()
@@ -3466,26 +8961,45 @@ func (m *AnyValue) KvlistValue() (r *KeyValueList) {
 }
 
 // This is noinline, so that KvlistValue() is inlined instead.
+//
 //go:noinline
 func (m *AnyValue) decodeKvlistValue() {
-	// Decode nested message(s).
+	// Decode nested message(s). The errors are recorded on the nested messages.
 	if m.value.FieldIndex() == int(AnyValueKvlistValue) {
 		kvlistValue := (*KeyValueList)(m.value.PtrVal())
 		if kvlistValue != nil {
-			// TODO: decide how to handle decoding errors.
-			_ = kvlistValue.decode()
+			if err := kvlistValue.decode(); err != nil {
+				kvlistValue._protoMessage.SetDecodeErr(err)
+			}
 		}
 	}
 	m._flags |= flags_AnyValue_KvlistValue_Decoded
 }
 
+// KvlistValueE is the same as KvlistValue, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *AnyValue) KvlistValueE() (r *KeyValueList, err error) {
+	r = m.KvlistValue()
+	if r == nil {
+		return r, nil
+	}
+	return r, r.DecodeErr()
+}
+
 // SetKvlistValue sets the value of the kvlistValue.
 // The oneof field "value" will be set to "kvlistValue".
 func (m *AnyValue) SetKvlistValue(v *KeyValueList) {
 	m.value = oneof.NewPtr(unsafe.Pointer(v), int(AnyValueKvlistValue))
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_AnyValue_KvlistValue_Decoded
+
 	// Make sure the field's Parent points to this message.
-	v._protoMessage.Parent = &m._protoMessage
+	if v != nil {
+		v._protoMessage.Parent = &m._protoMessage
+	}
 
 	// Mark this message modified, if not already.
 	m._protoMessage.MarkModified()
@@ -3509,6 +9023,13 @@ func (m *AnyValue) SetBytesValue(v []byte) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the AnyValue schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *AnyValue) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validateAnyValue(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -3682,6 +9203,8 @@ func (m *AnyValue) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -3697,12 +9220,89 @@ func (m *AnyValue) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *AnyValue) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *AnyValue) calcSize() int {
+	size := 0
+	// Size of "value".
+	switch AnyValueValue(m.value.FieldIndex()) {
+	case AnyValueStringValue:
+		// Size of "stringValue".
+		if m.value.StringVal() == "" {
+			size += 2
+		} else {
+			size += 1 + molecule.SizeBytes(len(m.value.StringVal()))
+		}
+	case AnyValueBoolValue:
+		// Size of "boolValue".
+		if !m.value.BoolVal() {
+			size += 2
+		} else {
+			size += 2
+		}
+	case AnyValueIntValue:
+		// Size of "intValue".
+		if m.value.Int64Val() == 0 {
+			size += 2
+		} else {
+			size += 1 + molecule.SizeVarint(uint64(m.value.Int64Val()))
+		}
+	case AnyValueDoubleValue:
+		// Size of "doubleValue".
+		if m.value.DoubleVal() == 0 {
+			size += 9
+		} else {
+			size += 9
+		}
+	case AnyValueArrayValue:
+		// Size of "arrayValue".
+		arrayValue := (*ArrayValue)(m.value.PtrVal())
+		if arrayValue != nil {
+			size += 1 + molecule.SizeBytes(arrayValue.Size())
+		}
+	case AnyValueKvlistValue:
+		// Size of "kvlistValue".
+		kvlistValue := (*KeyValueList)(m.value.PtrVal())
+		if kvlistValue != nil {
+			size += 1 + molecule.SizeBytes(kvlistValue.Size())
+		}
+	case AnyValueBytesValue:
+		// Size of "bytesValue".
+		if len(m.value.BytesVal()) == 0 {
+			size += 2
+		} else {
+			size += 1 + molecule.SizeBytes(len(m.value.BytesVal()))
+		}
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_AnyValue_StringValue = molecule.PrepareStringField(1)
 var prepared_AnyValue_BoolValue = molecule.PrepareBoolField(2)
 var prepared_AnyValue_IntValue = molecule.PrepareInt64Field(3)
@@ -3714,6 +9314,19 @@ var prepared_AnyValue_BytesValue = molecule.PrepareBytesField(7)
 func (m *AnyValue) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "value".
 		// Switch on the type of the value stored in the oneof field.
 		switch AnyValueValue(m.value.FieldIndex()) {
@@ -3721,45 +9334,790 @@ func (m *AnyValue) Marshal(ps *molecule.ProtoStream) error {
 			// Nothing to do, oneof is unset.
 		case AnyValueStringValue:
 			// Marshal "stringValue".
-			ps.StringPrepared(prepared_AnyValue_StringValue, m.value.StringVal())
+			if m.value.StringVal() == "" {
+				ps.ZeroPrepared(prepared_AnyValue_StringValue)
+			} else {
+				ps.StringPrepared(prepared_AnyValue_StringValue, m.value.StringVal())
+			}
 		case AnyValueBoolValue:
 			// Marshal "boolValue".
-			ps.BoolPrepared(prepared_AnyValue_BoolValue, m.value.BoolVal())
+			if !m.value.BoolVal() {
+				ps.ZeroPrepared(prepared_AnyValue_BoolValue)
+			} else {
+				ps.BoolPrepared(prepared_AnyValue_BoolValue, m.value.BoolVal())
+			}
 		case AnyValueIntValue:
 			// Marshal "intValue".
-			ps.Int64Prepared(prepared_AnyValue_IntValue, m.value.Int64Val())
+			if m.value.Int64Val() == 0 {
+				ps.ZeroPrepared(prepared_AnyValue_IntValue)
+			} else {
+				ps.Int64Prepared(prepared_AnyValue_IntValue, m.value.Int64Val())
+			}
 		case AnyValueDoubleValue:
 			// Marshal "doubleValue".
-			ps.DoublePrepared(prepared_AnyValue_DoubleValue, m.value.DoubleVal())
+			if m.value.DoubleVal() == 0 {
+				ps.ZeroPrepared(prepared_AnyValue_DoubleValue)
+			} else {
+				ps.DoublePrepared(prepared_AnyValue_DoubleValue, m.value.DoubleVal())
+			}
 		case AnyValueArrayValue:
 			// Marshal "arrayValue".
 			arrayValue := (*ArrayValue)(m.value.PtrVal())
 			if arrayValue != nil {
-				token := ps.BeginEmbedded()
-				if err := arrayValue.Marshal(ps); err != nil {
-					return err
+				if size, ok := arrayValue._protoMessage.KnownSize(); ok {
+					ps.EmbeddedSizePrepared(prepared_AnyValue_ArrayValue, size)
+					if err := arrayValue.Marshal(ps); err != nil {
+						return err
+					}
+				} else {
+					token := ps.BeginEmbedded()
+					if err := arrayValue.Marshal(ps); err != nil {
+						return err
+					}
+					ps.EndEmbeddedPrepared(token, prepared_AnyValue_ArrayValue)
 				}
-				ps.EndEmbeddedPrepared(token, prepared_AnyValue_ArrayValue)
 			}
 		case AnyValueKvlistValue:
 			// Marshal "kvlistValue".
 			kvlistValue := (*KeyValueList)(m.value.PtrVal())
 			if kvlistValue != nil {
-				token := ps.BeginEmbedded()
-				if err := kvlistValue.Marshal(ps); err != nil {
-					return err
+				if size, ok := kvlistValue._protoMessage.KnownSize(); ok {
+					ps.EmbeddedSizePrepared(prepared_AnyValue_KvlistValue, size)
+					if err := kvlistValue.Marshal(ps); err != nil {
+						return err
+					}
+				} else {
+					token := ps.BeginEmbedded()
+					if err := kvlistValue.Marshal(ps); err != nil {
+						return err
+					}
+					ps.EndEmbeddedPrepared(token, prepared_AnyValue_KvlistValue)
 				}
-				ps.EndEmbeddedPrepared(token, prepared_AnyValue_KvlistValue)
 			}
 		case AnyValueBytesValue:
 			// Marshal "bytesValue".
-			ps.BytesPrepared(prepared_AnyValue_BytesValue, m.value.BytesVal())
+			if len(m.value.BytesVal()) == 0 {
+				ps.ZeroPrepared(prepared_AnyValue_BytesValue)
+			} else {
+				ps.BytesPrepared(prepared_AnyValue_BytesValue, m.value.BytesVal())
+			}
+		}
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
+	} else {
+		// We have the original bytes and the message is unchanged. Use the original bytes.
+		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *AnyValue) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *AnyValue) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *AnyValue) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := anyValuePool.Get()
+	defer anyValuePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *AnyValue) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	switch AnyValueValue(m.value.FieldIndex()) {
+	case AnyValueStringValue:
+		js.Name("stringValue")
+		js.String(m.value.StringVal())
+	case AnyValueBoolValue:
+		js.Name("boolValue")
+		js.Bool(m.value.BoolVal())
+	case AnyValueIntValue:
+		js.Name("intValue")
+		js.Int64(m.value.Int64Val())
+	case AnyValueDoubleValue:
+		js.Name("doubleValue")
+		js.Double(m.value.DoubleVal())
+	case AnyValueArrayValue:
+		if ptr := (*ArrayValue)(m.value.PtrVal()); ptr != nil {
+			js.Name("arrayValue")
+			if m._flags&flags_AnyValue_ArrayValue_Decoded != 0 {
+				if err := ptr.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := ptr.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+	case AnyValueKvlistValue:
+		if ptr := (*KeyValueList)(m.value.PtrVal()); ptr != nil {
+			js.Name("kvlistValue")
+			if m._flags&flags_AnyValue_KvlistValue_Decoded != 0 {
+				if err := ptr.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := ptr.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+	case AnyValueBytesValue:
+		js.Name("bytesValue")
+		js.Bytes(m.value.BytesVal())
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *AnyValue) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_AnyValue_ArrayValue_Decoded | flags_AnyValue_KvlistValue_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "stringValue", "string_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewString(v, int(AnyValueStringValue))
+		case "boolValue", "bool_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadBool()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewBool(v, int(AnyValueBoolValue))
+		case "intValue", "int_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadInt64()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewInt64(v, int(AnyValueIntValue))
+		case "doubleValue", "double_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadDouble()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewDouble(v, int(AnyValueDoubleValue))
+		case "arrayValue", "array_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			elem := arrayValuePool.Get()
+			elem._protoMessage.Parent = &m._protoMessage
+			m.value = oneof.NewPtr(unsafe.Pointer(elem), int(AnyValueArrayValue))
+			if err := elem.UnmarshalJSONStream(r); err != nil {
+				return err
+			}
+		case "kvlistValue", "kvlist_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			elem := keyValueListPool.Get()
+			elem._protoMessage.Parent = &m._protoMessage
+			m.value = oneof.NewPtr(unsafe.Pointer(elem), int(AnyValueKvlistValue))
+			if err := elem.UnmarshalJSONStream(r); err != nil {
+				return err
+			}
+		case "bytesValue", "bytes_value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadBytes()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewBytes(v, int(AnyValueBytesValue))
+		default:
+			return fmt.Errorf("unknown field %q in AnyValue", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *AnyValue) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *AnyValue) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *AnyValue) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := anyValuePool.Get()
+	defer anyValuePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *AnyValue) MarshalTextStream(tw *textstream.Writer) error {
+	switch AnyValueValue(m.value.FieldIndex()) {
+	case AnyValueStringValue:
+		tw.Name("string_value")
+		tw.String(m.value.StringVal())
+	case AnyValueBoolValue:
+		tw.Name("bool_value")
+		tw.Bool(m.value.BoolVal())
+	case AnyValueIntValue:
+		tw.Name("int_value")
+		tw.Int64(m.value.Int64Val())
+	case AnyValueDoubleValue:
+		tw.Name("double_value")
+		tw.Double(m.value.DoubleVal())
+	case AnyValueArrayValue:
+		if ptr := (*ArrayValue)(m.value.PtrVal()); ptr != nil {
+			tw.Name("array_value")
+			tw.BeginMessage()
+			if m._flags&flags_AnyValue_ArrayValue_Decoded != 0 {
+				if err := ptr.MarshalTextStream(tw); err != nil {
+					return err
+				}
+			} else if err := ptr.marshalTextUndecoded(tw); err != nil {
+				return err
+			}
+			tw.EndMessage()
+		}
+	case AnyValueKvlistValue:
+		if ptr := (*KeyValueList)(m.value.PtrVal()); ptr != nil {
+			tw.Name("kvlist_value")
+			tw.BeginMessage()
+			if m._flags&flags_AnyValue_KvlistValue_Decoded != 0 {
+				if err := ptr.MarshalTextStream(tw); err != nil {
+					return err
+				}
+			} else if err := ptr.marshalTextUndecoded(tw); err != nil {
+				return err
+			}
+			tw.EndMessage()
+		}
+	case AnyValueBytesValue:
+		tw.Name("bytes_value")
+		tw.Bytes(m.value.BytesVal())
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *AnyValue) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_AnyValue_ArrayValue_Decoded | flags_AnyValue_KvlistValue_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "string_value":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewString(v, int(AnyValueStringValue))
+		case "bool_value":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadBool()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewBool(v, int(AnyValueBoolValue))
+		case "int_value":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadInt64()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewInt64(v, int(AnyValueIntValue))
+		case "double_value":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadDouble()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewDouble(v, int(AnyValueDoubleValue))
+		case "array_value":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			elem := arrayValuePool.Get()
+			elem._protoMessage.Parent = &m._protoMessage
+			m.value = oneof.NewPtr(unsafe.Pointer(elem), int(AnyValueArrayValue))
+			if err := r.BeginMessage(); err != nil {
+				return err
+			}
+			if err := elem.UnmarshalTextStream(r); err != nil {
+				return err
+			}
+		case "kvlist_value":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			elem := keyValueListPool.Get()
+			elem._protoMessage.Parent = &m._protoMessage
+			m.value = oneof.NewPtr(unsafe.Pointer(elem), int(AnyValueKvlistValue))
+			if err := r.BeginMessage(); err != nil {
+				return err
+			}
+			if err := elem.UnmarshalTextStream(r); err != nil {
+				return err
+			}
+		case "bytes_value":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			if m.value.FieldIndex() != 0 {
+				return fmt.Errorf("more than one field of oneof value in AnyValue is set")
+			}
+			v, err := r.ReadBytes()
+			if err != nil {
+				return err
+			}
+			m.value = oneof.NewBytes(v, int(AnyValueBytesValue))
+		default:
+			return fmt.Errorf("unknown field %q in AnyValue", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *AnyValue) ProtoReflect() protoreflect.Message {
+	return anyValueReflectInfo.MessageOf(m)
+}
+
+var anyValueReflectInfo = &lazyreflect.MessageInfo[AnyValue]{
+	File:          file_logs_proto,
+	FullName:      "simple.AnyValue",
+	NewMessage:    NewAnyValue,
+	UnknownFields: (*AnyValue).UnknownFields,
+	SetUnknownFields: func(m *AnyValue, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[AnyValue]{
+		{
+			// string_value
+			Has: func(m *AnyValue) bool { return m.value.FieldIndex() == int(AnyValueStringValue) },
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.StringValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) { m.SetStringValue(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueStringValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+		},
+		{
+			// bool_value
+			Has: func(m *AnyValue) bool { return m.value.FieldIndex() == int(AnyValueBoolValue) },
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.BoolConv.ToValue(m.BoolValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) { m.SetBoolValue(lazyreflect.BoolConv.FromValue(v)) },
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueBoolValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+		},
+		{
+			// int_value
+			Has: func(m *AnyValue) bool { return m.value.FieldIndex() == int(AnyValueIntValue) },
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.Int64Conv.ToValue(m.IntValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) { m.SetIntValue(lazyreflect.Int64Conv.FromValue(v)) },
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueIntValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+		},
+		{
+			// double_value
+			Has: func(m *AnyValue) bool { return m.value.FieldIndex() == int(AnyValueDoubleValue) },
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.DoubleConv.ToValue(m.DoubleValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) { m.SetDoubleValue(lazyreflect.DoubleConv.FromValue(v)) },
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueDoubleValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+		},
+		{
+			// array_value
+			Has: func(m *AnyValue) bool {
+				return m.value.FieldIndex() == int(AnyValueArrayValue) && m.value.PtrVal() != nil
+			},
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.ValueOfMessage(m.ArrayValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) {
+				m.SetArrayValue(lazyreflect.MessageFromValue(v, NewArrayValue))
+			},
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueArrayValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+			Mutable: func(m *AnyValue) protoreflect.Value {
+				if m.ArrayValue() == nil {
+					m.SetArrayValue(NewArrayValue())
+				}
+				return lazyreflect.ValueOfMessage(m.ArrayValue())
+			},
+		},
+		{
+			// kvlist_value
+			Has: func(m *AnyValue) bool {
+				return m.value.FieldIndex() == int(AnyValueKvlistValue) && m.value.PtrVal() != nil
+			},
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.ValueOfMessage(m.KvlistValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) {
+				m.SetKvlistValue(lazyreflect.MessageFromValue(v, NewKeyValueList))
+			},
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueKvlistValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+			Mutable: func(m *AnyValue) protoreflect.Value {
+				if m.KvlistValue() == nil {
+					m.SetKvlistValue(NewKeyValueList())
+				}
+				return lazyreflect.ValueOfMessage(m.KvlistValue())
+			},
+		},
+		{
+			// bytes_value
+			Has: func(m *AnyValue) bool { return m.value.FieldIndex() == int(AnyValueBytesValue) },
+			Get: func(m *AnyValue) protoreflect.Value { return lazyreflect.BytesConv.ToValue(m.BytesValue()) },
+			Set: func(m *AnyValue, v protoreflect.Value) { m.SetBytesValue(lazyreflect.BytesConv.FromValue(v)) },
+			Clear: func(m *AnyValue) {
+				if m.value.FieldIndex() == int(AnyValueBytesValue) {
+					m.ValueUnset()
+					m._protoMessage.MarkModified()
+				}
+			},
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *AnyValue) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *AnyValue) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// AnyValueSlice is a repeated field of AnyValue messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type AnyValueSlice struct {
+	elems  *[]*AnyValue
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s AnyValueSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s AnyValueSlice) At(i int) *AnyValue {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s AnyValueSlice) Range(f func(i int, elem *AnyValue) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s AnyValueSlice) Append(elems ...*AnyValue) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s AnyValueSlice) AppendNew() *AnyValue {
+	elem := anyValuePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s AnyValueSlice) InsertAt(i int, elem *AnyValue) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s AnyValueSlice) RemoveAt(i int) {
+	elems := *s.elems
+	anyValuePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s AnyValueSlice) RemoveIf(f func(elem *AnyValue) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			anyValuePool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s AnyValueSlice) Sort(less func(a, b *AnyValue) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *AnyValue) reset() {
+	elem := m
+
+	switch AnyValueValue(elem.value.FieldIndex()) {
+	case AnyValueArrayValue:
+		ptr := (*ArrayValue)(elem.value.PtrVal())
+		if ptr != nil {
+			arrayValuePool.Release(ptr)
+		}
+	case AnyValueKvlistValue:
+		ptr := (*KeyValueList)(elem.value.PtrVal())
+		if ptr != nil {
+			keyValueListPool.Release(ptr)
 		}
-	} else {
-		// We have the original bytes and the message is unchanged. Use the original bytes.
-		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
 	}
-	return nil
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.value = oneof.NewNone()
 }
 
 // Pool of AnyValue structs.
@@ -3824,23 +10182,7 @@ func (p *anyValuePoolType) GetSlice(r []*AnyValue) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *anyValuePoolType) ReleaseSlice(slice []*AnyValue) {
 	for _, elem := range slice {
-		switch AnyValueValue(elem.value.FieldIndex()) {
-		case AnyValueArrayValue:
-			ptr := (*ArrayValue)(elem.value.PtrVal())
-			if ptr != nil {
-				arrayValuePool.Release(ptr)
-			}
-		case AnyValueKvlistValue:
-			ptr := (*KeyValueList)(elem.value.PtrVal())
-			if ptr != nil {
-				keyValueListPool.Release(ptr)
-			}
-		}
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.value = oneof.NewNone()
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -3852,23 +10194,7 @@ func (p *anyValuePoolType) ReleaseSlice(slice []*AnyValue) {
 
 // Release an element back to the pool.
 func (p *anyValuePoolType) Release(elem *AnyValue) {
-	switch AnyValueValue(elem.value.FieldIndex()) {
-	case AnyValueArrayValue:
-		ptr := (*ArrayValue)(elem.value.PtrVal())
-		if ptr != nil {
-			arrayValuePool.Release(ptr)
-		}
-	case AnyValueKvlistValue:
-		ptr := (*KeyValueList)(elem.value.PtrVal())
-		if ptr != nil {
-			keyValueListPool.Release(ptr)
-		}
-	}
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.value = oneof.NewNone()
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -3877,15 +10203,68 @@ func (p *anyValuePoolType) Release(elem *AnyValue) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *AnyValue) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *AnyValue) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *AnyValue) XXX_CloneInto(c *AnyValue) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *AnyValue) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *AnyValue) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateAnyValue is for use by the code generated for other packages only.
+func XXX_ValidateAnyValue(b []byte) error {
+	return validateAnyValue(b)
+}
+
+// XXX_NewAnyValueSlice is for use by the code generated for other packages only.
+func XXX_NewAnyValueSlice(elems *[]*AnyValue, parent *protomessage.ProtoMessage) AnyValueSlice {
+	return AnyValueSlice{elems: elems, parent: parent}
+}
+
+// XXX_AnyValuePool is for use by the code generated for other packages only.
+var XXX_AnyValuePool = &anyValuePool
+
 // ====================== ArrayValue message implementation ======================
 
 type ArrayValue struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_ArrayValue
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_ArrayValue
+	_unknownFields protomessage.UnknownFields
 
 	values []*AnyValue
 }
 
+// NewArrayValue returns an empty ArrayValue message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewArrayValue() *ArrayValue {
+	return arrayValuePool.Get()
+}
+
+// NewArrayValueSlice returns a slice of n empty ArrayValue messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewArrayValueSlice(n int) []*ArrayValue {
+	r := make([]*ArrayValue, n)
+	arrayValuePool.GetSlice(r)
+	return r
+}
+
 // UnmarshalArrayValue unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -3906,16 +10285,129 @@ func UnmarshalArrayValue(bytes []byte, opts lazyproto.UnmarshalOpts) (*ArrayValu
 
 	m := arrayValuePool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalArrayValue(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *ArrayValue) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateArrayValue(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *ArrayValue) Free() {
 	arrayValuePool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *ArrayValue) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *ArrayValue) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*ArrayValue)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *ArrayValue) Clone() *ArrayValue {
+	c := arrayValuePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *ArrayValue) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *ArrayValue) cloneInto(c *ArrayValue) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	// Clone values elements into structs taken from the pool all at once.
+	if cap(c.values) < len(m.values) {
+		c.values = make([]*AnyValue, len(m.values))
+	} else {
+		c.values = c.values[:len(m.values)]
+	}
+	anyValuePool.GetSlice(c.values)
+	for i, elem := range m.values {
+		elem.cloneInto(c.values[i])
+		c.values[i]._protoMessage.Parent = &c._protoMessage
+	}
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *ArrayValue) Equal(other *ArrayValue) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	{
+		a, b := m.Values(), other.Values()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_ArrayValue is the type of the bit flags.
 type flags_ArrayValue uint8
 
@@ -3923,30 +10415,49 @@ type flags_ArrayValue uint8
 const flags_ArrayValue_Values_Decoded flags_ArrayValue = 0x1
 
 // Values returns the value of the values.
-func (m *ArrayValue) Values() (r []*AnyValue) {
+func (m *ArrayValue) Values() (r AnyValueSlice) {
 	if m._flags&flags_ArrayValue_Values_Decoded == 0 {
 		m.decodeValues()
 	}
-	return m.values
+	return AnyValueSlice{elems: &m.values, parent: &m._protoMessage}
 }
 
 // This is noinline, so that Values() is inlined instead.
+//
 //go:noinline
 func (m *ArrayValue) decodeValues() {
-	// Decode nested message(s).
-	for i := range m.values {
-		// TODO: decide how to handle decoding errors.
-		_ = m.values[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.values {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_ArrayValue_Values_Decoded
 }
 
+// ValuesE is the same as Values, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *ArrayValue) ValuesE() (r AnyValueSlice, err error) {
+	r = m.Values()
+	for _, elem := range m.values {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetValues sets the value of the values.
 func (m *ArrayValue) SetValues(v []*AnyValue) {
 	m.values = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_ArrayValue_Values_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.values {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
@@ -3954,32 +10465,11 @@ func (m *ArrayValue) SetValues(v []*AnyValue) {
 	m._protoMessage.MarkModified()
 }
 
-func (m *ArrayValue) ValuesRemoveIf(f func(*AnyValue) bool) {
-	// Call getter to load the field.
-	m.Values()
-
-	newLen := 0
-	for i := 0; i < len(m.values); i++ {
-		if f(m.values[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.values[newLen] != nil {
-			m.values[newLen].Free()
-		}
-		m.values[newLen] = m.values[i]
-		m.values[i] = nil
-		newLen++
-	}
-	if newLen != len(m.values) {
-		m.values = m.values[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
+// UnknownFields returns the wire representation of the fields that are not
+// known to the ArrayValue schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *ArrayValue) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
 }
 
 func validateArrayValue(b []byte) error {
@@ -4044,7 +10534,9 @@ func (m *ArrayValue) decode() error {
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			valuesCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -4105,6 +10597,8 @@ func (m *ArrayValue) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -4120,30 +10614,499 @@ func (m *ArrayValue) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *ArrayValue) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *ArrayValue) calcSize() int {
+	size := 0
+	// Size of "values".
+	for _, elem := range m.values {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
+var prepared_ArrayValue_Values = molecule.PrepareEmbeddedField(1)
+
+func (m *ArrayValue) Marshal(ps *molecule.ProtoStream) error {
+	if m._protoMessage.IsModified() {
+		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
+		// Marshal "values".
+		for _, elem := range m.values {
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_ArrayValue_Values, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_ArrayValue_Values)
+			}
+		}
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
+	} else {
+		// We have the original bytes and the message is unchanged. Use the original bytes.
+		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *ArrayValue) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *ArrayValue) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *ArrayValue) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := arrayValuePool.Get()
+	defer arrayValuePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *ArrayValue) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if len(m.values) > 0 {
+		js.Name("values")
+		js.BeginArray()
+		for _, elem := range m.values {
+			if m._flags&flags_ArrayValue_Values_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *ArrayValue) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_ArrayValue_Values_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "values":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := anyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.values = append(m.values, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown field %q in ArrayValue", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *ArrayValue) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *ArrayValue) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *ArrayValue) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := arrayValuePool.Get()
+	defer arrayValuePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *ArrayValue) MarshalTextStream(tw *textstream.Writer) error {
+	for _, elem := range m.values {
+		tw.Name("values")
+		tw.BeginMessage()
+		if m._flags&flags_ArrayValue_Values_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *ArrayValue) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_ArrayValue_Values_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "values":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := anyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.values = append(m.values, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
 			}
+		default:
+			return fmt.Errorf("unknown field %q in ArrayValue", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *ArrayValue) ProtoReflect() protoreflect.Message {
+	return arrayValueReflectInfo.MessageOf(m)
+}
+
+// reflectValues returns the view of the values list.
+func (m *ArrayValue) reflectValues() protoreflect.List {
+	if m._flags&flags_ArrayValue_Values_Decoded == 0 {
+		m.decodeValues()
+	}
+	return lazyreflect.NewMessageList(&m.values, &m._protoMessage, NewAnyValue)
+}
+
+var arrayValueReflectInfo = &lazyreflect.MessageInfo[ArrayValue]{
+	File:          file_logs_proto,
+	FullName:      "simple.ArrayValue",
+	NewMessage:    NewArrayValue,
+	UnknownFields: (*ArrayValue).UnknownFields,
+	SetUnknownFields: func(m *ArrayValue, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[ArrayValue]{
+		{
+			// values
+			Has: func(m *ArrayValue) bool { return len(m.values) > 0 },
+			Get: func(m *ArrayValue) protoreflect.Value { return protoreflect.ValueOfList(m.reflectValues()) },
+			Set: func(m *ArrayValue, v protoreflect.Value) {
+				m.SetValues(lazyreflect.MessagesFromList(v.List(), NewAnyValue))
+			},
+			Clear:   func(m *ArrayValue) { m.SetValues(nil) },
+			Mutable: func(m *ArrayValue) protoreflect.Value { return protoreflect.ValueOfList(m.reflectValues()) },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *ArrayValue) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *ArrayValue) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// ArrayValueSlice is a repeated field of ArrayValue messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type ArrayValueSlice struct {
+	elems  *[]*ArrayValue
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s ArrayValueSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s ArrayValueSlice) At(i int) *ArrayValue {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s ArrayValueSlice) Range(f func(i int, elem *ArrayValue) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
 		}
 	}
-	return nil
 }
 
-var prepared_ArrayValue_Values = molecule.PrepareEmbeddedField(1)
+// Append appends the elements to the end of the slice.
+func (s ArrayValueSlice) Append(elems ...*ArrayValue) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
 
-func (m *ArrayValue) Marshal(ps *molecule.ProtoStream) error {
-	if m._protoMessage.IsModified() {
-		// The struct is modified, marshal from the struct fields.
-		// Marshal "values".
-		for _, elem := range m.values {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
-			}
-			ps.EndEmbeddedPrepared(token, prepared_ArrayValue_Values)
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s ArrayValueSlice) AppendNew() *ArrayValue {
+	elem := arrayValuePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s ArrayValueSlice) InsertAt(i int, elem *ArrayValue) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s ArrayValueSlice) RemoveAt(i int) {
+	elems := *s.elems
+	arrayValuePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s ArrayValueSlice) RemoveIf(f func(elem *ArrayValue) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			arrayValuePool.Release(elem)
+			continue
 		}
-	} else {
-		// We have the original bytes and the message is unchanged. Use the original bytes.
-		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+		elems[newLen] = elem
+		newLen++
 	}
-	return nil
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s ArrayValueSlice) Sort(less func(a, b *ArrayValue) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *ArrayValue) reset() {
+	elem := m
+
+	// Release nested values recursively to their pool.
+	anyValuePool.ReleaseSlice(elem.values)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.values = elem.values[:0]
 }
 
 // Pool of ArrayValue structs.
@@ -4208,13 +11171,7 @@ func (p *arrayValuePoolType) GetSlice(r []*ArrayValue) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *arrayValuePoolType) ReleaseSlice(slice []*ArrayValue) {
 	for _, elem := range slice {
-		// Release nested values recursively to their pool.
-		anyValuePool.ReleaseSlice(elem.values)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.values = elem.values[:0]
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -4226,13 +11183,7 @@ func (p *arrayValuePoolType) ReleaseSlice(slice []*ArrayValue) {
 
 // Release an element back to the pool.
 func (p *arrayValuePoolType) Release(elem *ArrayValue) {
-	// Release nested values recursively to their pool.
-	anyValuePool.ReleaseSlice(elem.values)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.values = elem.values[:0]
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -4241,15 +11192,68 @@ func (p *arrayValuePoolType) Release(elem *ArrayValue) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *ArrayValue) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *ArrayValue) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *ArrayValue) XXX_CloneInto(c *ArrayValue) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *ArrayValue) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *ArrayValue) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateArrayValue is for use by the code generated for other packages only.
+func XXX_ValidateArrayValue(b []byte) error {
+	return validateArrayValue(b)
+}
+
+// XXX_NewArrayValueSlice is for use by the code generated for other packages only.
+func XXX_NewArrayValueSlice(elems *[]*ArrayValue, parent *protomessage.ProtoMessage) ArrayValueSlice {
+	return ArrayValueSlice{elems: elems, parent: parent}
+}
+
+// XXX_ArrayValuePool is for use by the code generated for other packages only.
+var XXX_ArrayValuePool = &arrayValuePool
+
 // ====================== KeyValueList message implementation ======================
 
 type KeyValueList struct {
-	_protoMessage protomessage.ProtoMessage
-	_flags        flags_KeyValueList
+	_protoMessage  protomessage.ProtoMessage
+	_flags         flags_KeyValueList
+	_unknownFields protomessage.UnknownFields
 
 	values []*KeyValue
 }
 
+// NewKeyValueList returns an empty KeyValueList message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewKeyValueList() *KeyValueList {
+	return keyValueListPool.Get()
+}
+
+// NewKeyValueListSlice returns a slice of n empty KeyValueList messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewKeyValueListSlice(n int) []*KeyValueList {
+	r := make([]*KeyValueList, n)
+	keyValueListPool.GetSlice(r)
+	return r
+}
+
 // UnmarshalKeyValueList unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -4270,16 +11274,129 @@ func UnmarshalKeyValueList(bytes []byte, opts lazyproto.UnmarshalOpts) (*KeyValu
 
 	m := keyValueListPool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalKeyValueList(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *KeyValueList) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validateKeyValueList(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *KeyValueList) Free() {
 	keyValueListPool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *KeyValueList) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *KeyValueList) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*KeyValueList)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *KeyValueList) Clone() *KeyValueList {
+	c := keyValueListPool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *KeyValueList) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *KeyValueList) cloneInto(c *KeyValueList) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c._flags = m._flags
+	// Clone values elements into structs taken from the pool all at once.
+	if cap(c.values) < len(m.values) {
+		c.values = make([]*KeyValue, len(m.values))
+	} else {
+		c.values = c.values[:len(m.values)]
+	}
+	keyValuePool.GetSlice(c.values)
+	for i, elem := range m.values {
+		elem.cloneInto(c.values[i])
+		c.values[i]._protoMessage.Parent = &c._protoMessage
+	}
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *KeyValueList) Equal(other *KeyValueList) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	{
+		a, b := m.Values(), other.Values()
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !a.At(i).Equal(b.At(i)) {
+				return false
+			}
+		}
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // flags_KeyValueList is the type of the bit flags.
 type flags_KeyValueList uint8
 
@@ -4287,30 +11404,49 @@ type flags_KeyValueList uint8
 const flags_KeyValueList_Values_Decoded flags_KeyValueList = 0x1
 
 // Values returns the value of the values.
-func (m *KeyValueList) Values() (r []*KeyValue) {
+func (m *KeyValueList) Values() (r KeyValueSlice) {
 	if m._flags&flags_KeyValueList_Values_Decoded == 0 {
 		m.decodeValues()
 	}
-	return m.values
+	return KeyValueSlice{elems: &m.values, parent: &m._protoMessage}
 }
 
 // This is noinline, so that Values() is inlined instead.
+//
 //go:noinline
 func (m *KeyValueList) decodeValues() {
-	// Decode nested message(s).
-	for i := range m.values {
-		// TODO: decide how to handle decoding errors.
-		_ = m.values[i].decode()
+	// Decode nested message(s). The errors are recorded on the nested messages.
+	for _, elem := range m.values {
+		if err := elem.decode(); err != nil {
+			elem._protoMessage.SetDecodeErr(err)
+		}
 	}
 	m._flags |= flags_KeyValueList_Values_Decoded
 }
 
+// ValuesE is the same as Values, but also returns the first error of
+// the decoding of the returned message(s) or of their nested messages that were
+// accessed so far.
+func (m *KeyValueList) ValuesE() (r KeyValueSlice, err error) {
+	r = m.Values()
+	for _, elem := range m.values {
+		if err := elem.DecodeErr(); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
 // SetValues sets the value of the values.
 func (m *KeyValueList) SetValues(v []*KeyValue) {
 	m.values = v
 
+	// The new value does not need decoding, it is either constructed locally
+	// or obtained from a decoded message.
+	m._flags |= flags_KeyValueList_Values_Decoded
+
 	// Make sure the field's Parent points to this message.
-	for _, elem := range m.values {
+	for _, elem := range v {
 		elem._protoMessage.Parent = &m._protoMessage
 	}
 
@@ -4318,32 +11454,11 @@ func (m *KeyValueList) SetValues(v []*KeyValue) {
 	m._protoMessage.MarkModified()
 }
 
-func (m *KeyValueList) ValuesRemoveIf(f func(*KeyValue) bool) {
-	// Call getter to load the field.
-	m.Values()
-
-	newLen := 0
-	for i := 0; i < len(m.values); i++ {
-		if f(m.values[i]) {
-			continue
-		}
-		if newLen == i {
-			// Nothing to move, element is at the right place.
-			newLen++
-			continue
-		}
-		if m.values[newLen] != nil {
-			m.values[newLen].Free()
-		}
-		m.values[newLen] = m.values[i]
-		m.values[i] = nil
-		newLen++
-	}
-	if newLen != len(m.values) {
-		m.values = m.values[:newLen]
-		// Mark this message modified, if not already.
-		m._protoMessage.MarkModified()
-	}
+// UnknownFields returns the wire representation of the fields that are not
+// known to the KeyValueList schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *KeyValueList) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
 }
 
 func validateKeyValueList(b []byte) error {
@@ -4408,7 +11523,9 @@ func (m *KeyValueList) decode() error {
 			// Skip the one-byte varint.
 			buf.SkipByteUnsafe()
 			valuesCount++
-			buf.SkipRawBytes()
+			if err := buf.SkipRawBytes(); err != nil {
+				return err
+			}
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
@@ -4469,6 +11586,8 @@ func (m *KeyValueList) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -4478,36 +11597,505 @@ func (m *KeyValueList) decode() error {
 				return err
 			}
 
-			switch fieldNum {
-			default:
-				// Unknown field number.
-				if err := buf.SkipFieldByWireType(wireType); err != nil {
-					return err
-				}
-			}
+			switch fieldNum {
+			default:
+				// Unknown field number.
+				if err := buf.SkipFieldByWireType(wireType); err != nil {
+					return err
+				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *KeyValueList) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *KeyValueList) calcSize() int {
+	size := 0
+	// Size of "values".
+	for _, elem := range m.values {
+		size += 1 + molecule.SizeBytes(elem.Size())
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
+var prepared_KeyValueList_Values = molecule.PrepareEmbeddedField(1)
+
+func (m *KeyValueList) Marshal(ps *molecule.ProtoStream) error {
+	if m._protoMessage.IsModified() {
+		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
+		// Marshal "values".
+		for _, elem := range m.values {
+			if size, ok := elem._protoMessage.KnownSize(); ok {
+				ps.EmbeddedSizePrepared(prepared_KeyValueList_Values, size)
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+			} else {
+				token := ps.BeginEmbedded()
+				if err := elem.Marshal(ps); err != nil {
+					return err
+				}
+				ps.EndEmbeddedPrepared(token, prepared_KeyValueList_Values)
+			}
+		}
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
+	} else {
+		// We have the original bytes and the message is unchanged. Use the original bytes.
+		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *KeyValueList) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *KeyValueList) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *KeyValueList) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := keyValueListPool.Get()
+	defer keyValueListPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *KeyValueList) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if len(m.values) > 0 {
+		js.Name("values")
+		js.BeginArray()
+		for _, elem := range m.values {
+			if m._flags&flags_KeyValueList_Values_Decoded != 0 {
+				if err := elem.MarshalJSONStream(js); err != nil {
+					return err
+				}
+			} else if err := elem.marshalJSONUndecoded(js); err != nil {
+				return err
+			}
+		}
+		js.EndArray()
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *KeyValueList) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from JSON, there is nothing to decode from wire bytes.
+	m._flags = flags_KeyValueList_Values_Decoded
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "values":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			if err := r.BeginArray(); err != nil {
+				return err
+			}
+			for {
+				ok, err := r.NextElem()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.values = append(m.values, elem)
+				if err := elem.UnmarshalJSONStream(r); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown field %q in KeyValueList", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *KeyValueList) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *KeyValueList) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *KeyValueList) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := keyValueListPool.Get()
+	defer keyValueListPool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *KeyValueList) MarshalTextStream(tw *textstream.Writer) error {
+	for _, elem := range m.values {
+		tw.Name("values")
+		tw.BeginMessage()
+		if m._flags&flags_KeyValueList_Values_Decoded != 0 {
+			if err := elem.MarshalTextStream(tw); err != nil {
+				return err
+			}
+		} else if err := elem.marshalTextUndecoded(tw); err != nil {
+			return err
+		}
+		tw.EndMessage()
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *KeyValueList) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+
+	// The message is built from text, there is nothing to decode from wire bytes.
+	m._flags = flags_KeyValueList_Values_Decoded
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "values":
+			if err := r.Colon(true); err != nil {
+				return err
+			}
+			list := r.BeginList()
+			for first := true; ; first = false {
+				if ok, err := r.NextRepeated(list, first); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				elem := keyValuePool.Get()
+				elem._protoMessage.Parent = &m._protoMessage
+				m.values = append(m.values, elem)
+				if err := r.BeginMessage(); err != nil {
+					return err
+				}
+				if err := elem.UnmarshalTextStream(r); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown field %q in KeyValueList", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *KeyValueList) ProtoReflect() protoreflect.Message {
+	return keyValueListReflectInfo.MessageOf(m)
+}
+
+// reflectValues returns the view of the values list.
+func (m *KeyValueList) reflectValues() protoreflect.List {
+	if m._flags&flags_KeyValueList_Values_Decoded == 0 {
+		m.decodeValues()
+	}
+	return lazyreflect.NewMessageList(&m.values, &m._protoMessage, NewKeyValue)
+}
+
+var keyValueListReflectInfo = &lazyreflect.MessageInfo[KeyValueList]{
+	File:          file_logs_proto,
+	FullName:      "simple.KeyValueList",
+	NewMessage:    NewKeyValueList,
+	UnknownFields: (*KeyValueList).UnknownFields,
+	SetUnknownFields: func(m *KeyValueList, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[KeyValueList]{
+		{
+			// values
+			Has: func(m *KeyValueList) bool { return len(m.values) > 0 },
+			Get: func(m *KeyValueList) protoreflect.Value { return protoreflect.ValueOfList(m.reflectValues()) },
+			Set: func(m *KeyValueList, v protoreflect.Value) {
+				m.SetValues(lazyreflect.MessagesFromList(v.List(), NewKeyValue))
+			},
+			Clear:   func(m *KeyValueList) { m.SetValues(nil) },
+			Mutable: func(m *KeyValueList) protoreflect.Value { return protoreflect.ValueOfList(m.reflectValues()) },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *KeyValueList) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *KeyValueList) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// KeyValueListSlice is a repeated field of KeyValueList messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type KeyValueListSlice struct {
+	elems  *[]*KeyValueList
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s KeyValueListSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s KeyValueListSlice) At(i int) *KeyValueList {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s KeyValueListSlice) Range(f func(i int, elem *KeyValueList) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
 		}
 	}
-	return nil
 }
 
-var prepared_KeyValueList_Values = molecule.PrepareEmbeddedField(1)
+// Append appends the elements to the end of the slice.
+func (s KeyValueListSlice) Append(elems ...*KeyValueList) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
 
-func (m *KeyValueList) Marshal(ps *molecule.ProtoStream) error {
-	if m._protoMessage.IsModified() {
-		// The struct is modified, marshal from the struct fields.
-		// Marshal "values".
-		for _, elem := range m.values {
-			token := ps.BeginEmbedded()
-			if err := elem.Marshal(ps); err != nil {
-				return err
-			}
-			ps.EndEmbeddedPrepared(token, prepared_KeyValueList_Values)
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s KeyValueListSlice) AppendNew() *KeyValueList {
+	elem := keyValueListPool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s KeyValueListSlice) InsertAt(i int, elem *KeyValueList) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s KeyValueListSlice) RemoveAt(i int) {
+	elems := *s.elems
+	keyValueListPool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s KeyValueListSlice) RemoveIf(f func(elem *KeyValueList) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			keyValueListPool.Release(elem)
+			continue
 		}
-	} else {
-		// We have the original bytes and the message is unchanged. Use the original bytes.
-		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
+		elems[newLen] = elem
+		newLen++
 	}
-	return nil
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s KeyValueListSlice) Sort(less func(a, b *KeyValueList) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *KeyValueList) reset() {
+	elem := m
+
+	// Release nested values recursively to their pool.
+	keyValuePool.ReleaseSlice(elem.values)
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem._flags = 0
+	elem.values = elem.values[:0]
 }
 
 // Pool of KeyValueList structs.
@@ -4572,13 +12160,7 @@ func (p *keyValueListPoolType) GetSlice(r []*KeyValueList) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *keyValueListPoolType) ReleaseSlice(slice []*KeyValueList) {
 	for _, elem := range slice {
-		// Release nested values recursively to their pool.
-		keyValuePool.ReleaseSlice(elem.values)
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem._flags = 0
-		elem.values = elem.values[:0]
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -4590,13 +12172,7 @@ func (p *keyValueListPoolType) ReleaseSlice(slice []*KeyValueList) {
 
 // Release an element back to the pool.
 func (p *keyValueListPoolType) Release(elem *KeyValueList) {
-	// Release nested values recursively to their pool.
-	keyValuePool.ReleaseSlice(elem.values)
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem._flags = 0
-	elem.values = elem.values[:0]
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -4605,15 +12181,68 @@ func (p *keyValueListPoolType) Release(elem *KeyValueList) {
 	p.pool = append(p.pool, elem)
 }
 
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *KeyValueList) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *KeyValueList) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *KeyValueList) XXX_CloneInto(c *KeyValueList) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *KeyValueList) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *KeyValueList) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidateKeyValueList is for use by the code generated for other packages only.
+func XXX_ValidateKeyValueList(b []byte) error {
+	return validateKeyValueList(b)
+}
+
+// XXX_NewKeyValueListSlice is for use by the code generated for other packages only.
+func XXX_NewKeyValueListSlice(elems *[]*KeyValueList, parent *protomessage.ProtoMessage) KeyValueListSlice {
+	return KeyValueListSlice{elems: elems, parent: parent}
+}
+
+// XXX_KeyValueListPool is for use by the code generated for other packages only.
+var XXX_KeyValueListPool = &keyValueListPool
+
 // ====================== PlainMessage message implementation ======================
 
 type PlainMessage struct {
-	_protoMessage protomessage.ProtoMessage
+	_protoMessage  protomessage.ProtoMessage
+	_unknownFields protomessage.UnknownFields
 
 	key   string
 	value string
 }
 
+// NewPlainMessage returns an empty PlainMessage message from the pool. Free() returns
+// the message to the pool when it is no longer needed.
+func NewPlainMessage() *PlainMessage {
+	return plainMessagePool.Get()
+}
+
+// NewPlainMessageSlice returns a slice of n empty PlainMessage messages from the pool.
+// The slice can be assigned to a repeated field by the field's setter.
+func NewPlainMessageSlice(n int) []*PlainMessage {
+	r := make([]*PlainMessage, n)
+	plainMessagePool.GetSlice(r)
+	return r
+}
+
 // UnmarshalPlainMessage unmarshals from the Protobuf wire bytes into a struct
 // representing the message.
 // If WithValidate option is provided the wire bytes will be validated to make sure
@@ -4634,16 +12263,114 @@ func UnmarshalPlainMessage(bytes []byte, opts lazyproto.UnmarshalOpts) (*PlainMe
 
 	m := plainMessagePool.Get()
 	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
 	if err := m.decode(); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// Unmarshal replaces the content of the message by the message unmarshalled from
+// the Protobuf wire bytes. The options have the same meaning as for
+// UnmarshalPlainMessage(). The nested messages that were previously referenced by
+// the message are returned to their pools. If the message is nested in another
+// message the parent message is marked modified.
+func (m *PlainMessage) Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error {
+	if opts.WithValidate {
+		if err := validatePlainMessage(bytes); err != nil {
+			return err
+		}
+	}
+
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	m._protoMessage.Bytes = protomessage.BytesViewFromBytes(bytes)
+	m._protoMessage.DiscardUnknown = opts.DiscardUnknown
+	if parent != nil {
+		parent.MarkModified()
+	}
+	return m.decode()
+}
+
 func (m *PlainMessage) Free() {
 	plainMessagePool.Release(m)
 }
 
+// IsModified returns true if the message must be encoded field by field by Marshal,
+// i.e. the message was modified since it was unmarshalled or was not unmarshalled at all.
+func (m *PlainMessage) IsModified() bool {
+	return m._protoMessage.IsModified()
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages that were accessed so far, or nil if there was no error. The
+// getters return the zero values for the fields of the messages that fail to
+// decode, the errors of nested messages are also returned by DecodeErr of all
+// their parents up to the root message.
+func (m *PlainMessage) DecodeErr() error {
+	return m._protoMessage.DecodeErr()
+}
+
+var _ lazyproto.Message = (*PlainMessage)(nil)
+
+// Clone returns a copy of the message. The copy shares the wire bytes with the
+// source message. Nested messages that are not decoded yet are not decoded by
+// Clone, so cloning a freshly unmarshalled message is cheap. String and bytes
+// values are also shared and must not be modified in place.
+// The copy can be modified and freed independently of the source message.
+func (m *PlainMessage) Clone() *PlainMessage {
+	c := plainMessagePool.Get()
+	m.cloneInto(c)
+	c._protoMessage.ShareBytesOwner(&m._protoMessage)
+	return c
+}
+
+// CloneMessage is the same as Clone(), but returns the copy as lazyproto.Message.
+func (m *PlainMessage) CloneMessage() lazyproto.Message {
+	return m.Clone()
+}
+
+// cloneInto copies the message into c. c must be a reset element taken from the pool.
+func (m *PlainMessage) cloneInto(c *PlainMessage) {
+	c._protoMessage.Bytes = m._protoMessage.Bytes
+	c._protoMessage.DiscardUnknown = m._protoMessage.IsDiscardUnknown()
+	c._unknownFields.CopyFrom(&m._unknownFields)
+	if err := m._protoMessage.DecodeErr(); err != nil {
+		c._protoMessage.SetDecodeErr(err)
+	}
+	c.key = m.key
+	c.value = m.value
+}
+
+// Equal returns true if the message is equal to the other message. Fields that are
+// set to default values are equal to absent fields, unless the fields have explicit
+// presence (proto2 optional fields). If both messages are unmodified
+// and have the same wire bytes the comparison does not decode the messages.
+// Otherwise the messages are compared field by field, decoding lazily as needed.
+func (m *PlainMessage) Equal(other *PlainMessage) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m == other {
+		return true
+	}
+	if !m._protoMessage.IsModified() && !other._protoMessage.IsModified() &&
+		bytes.Equal(
+			protomessage.BytesFromBytesView(m._protoMessage.Bytes),
+			protomessage.BytesFromBytesView(other._protoMessage.Bytes),
+		) {
+		return true
+	}
+	if m.Key() != other.Key() {
+		return false
+	}
+	if m.Value() != other.Value() {
+		return false
+	}
+	return m._unknownFields.Equal(&other._unknownFields)
+}
+
 // Key returns the value of the key.
 func (m *PlainMessage) Key() (r string) {
 	return m.key
@@ -4670,6 +12397,13 @@ func (m *PlainMessage) SetValue(v string) {
 	m._protoMessage.MarkModified()
 }
 
+// UnknownFields returns the wire representation of the fields that are not
+// known to the PlainMessage schema. Unknown fields are preserved unless the
+// message is unmarshalled with DiscardUnknown option.
+func (m *PlainMessage) UnknownFields() []byte {
+	return m._unknownFields.Bytes()
+}
+
 func validatePlainMessage(b []byte) error {
 	buf := codec.NewBuffer(b)
 
@@ -4745,6 +12479,8 @@ func (m *PlainMessage) decode() error {
 		default:
 			// Our speculation was wrong, the varint is more than one byte long.
 			// Do the full slow decoding.
+			// Remember where the field starts in case it is an unknown field.
+			start := buf.Bytes()
 			v, err := buf.DecodeVarint()
 			if err != nil {
 				return err
@@ -4760,22 +12496,75 @@ func (m *PlainMessage) decode() error {
 				if err := buf.SkipFieldByWireType(wireType); err != nil {
 					return err
 				}
+				if !m._protoMessage.IsDiscardUnknown() {
+					// Preserve the unknown field so that it can be marshalled later.
+					m._unknownFields.Add(start[:len(start)-buf.Len()])
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Size returns the number of bytes that Marshal writes for the message. It is O(1)
+// if the message is not modified since it was unmarshalled. Otherwise the size is
+// calculated and cached until the message or any of its nested messages is modified.
+func (m *PlainMessage) Size() int {
+	if size, ok := m._protoMessage.KnownSize(); ok {
+		return size
+	}
+	return m.calcSize()
+}
+
+// calcSize calculates the size of the modified message and caches it. It is
+// separate from Size, so that Size can be inlined.
+func (m *PlainMessage) calcSize() int {
+	size := 0
+	// Size of "key".
+	if m.key != "" {
+		size += 1 + molecule.SizeBytes(len(m.key))
+	}
+	// Size of "value".
+	if m.value != "" {
+		size += 1 + molecule.SizeBytes(len(m.value))
+	}
+	// Unknown fields.
+	for i := 0; i < m._unknownFields.Len(); i++ {
+		size += len(m._unknownFields.At(i))
+	}
+
+	m._protoMessage.SetCachedSize(size)
+	return size
+}
+
 var prepared_PlainMessage_Key = molecule.PrepareStringField(1)
 var prepared_PlainMessage_Value = molecule.PrepareStringField(2)
 
 func (m *PlainMessage) Marshal(ps *molecule.ProtoStream) error {
 	if m._protoMessage.IsModified() {
 		// The struct is modified, marshal from the struct fields.
+		if ps.UpfrontSizes() {
+			// The written bytes can't be moved, so the length prefixes of all nested
+			// messages are written upfront. Size() calculates and caches their sizes.
+			m.Size()
+		}
+		if m._protoMessage.Parent == nil {
+			if size, ok := m._protoMessage.KnownSize(); ok {
+				// The size is cached by Size() or by the previous Marshal.
+				ps.Grow(size)
+			}
+		}
+		start := ps.Len()
+
 		// Marshal "key".
 		ps.StringPrepared(prepared_PlainMessage_Key, m.key)
 		// Marshal "value".
 		ps.StringPrepared(prepared_PlainMessage_Value, m.value)
+		// Marshal unknown fields.
+		for i := 0; i < m._unknownFields.Len(); i++ {
+			ps.Raw(m._unknownFields.At(i))
+		}
+		ps.EndModified(&m._protoMessage, start)
 	} else {
 		// We have the original bytes and the message is unchanged. Use the original bytes.
 		ps.Raw(protomessage.BytesFromBytesView(m._protoMessage.Bytes))
@@ -4783,6 +12572,390 @@ func (m *PlainMessage) Marshal(ps *molecule.ProtoStream) error {
 	return nil
 }
 
+// MarshalJSON returns the JSON representation of the message according to the
+// proto3 JSON mapping. The output is compact and otherwise is the same as the
+// output of protojson.Marshal().
+func (m *PlainMessage) MarshalJSON() ([]byte, error) {
+	js := jsonstream.NewWriter(nil)
+	if err := m.MarshalJSONStream(js); err != nil {
+		return nil, err
+	}
+	return js.BufferBytes()
+}
+
+// UnmarshalJSON replaces the content of the message by the message read from its
+// JSON representation. The nested messages that were previously referenced by the
+// message are returned to their pools.
+func (m *PlainMessage) UnmarshalJSON(b []byte) error {
+	r := jsonstream.NewReader(b)
+	if err := m.UnmarshalJSONStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalJSONUndecoded is the same as MarshalJSONStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *PlainMessage) marshalJSONUndecoded(js *jsonstream.Writer) error {
+	tmp := plainMessagePool.Get()
+	defer plainMessagePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalJSONStream(js)
+}
+
+// MarshalJSONStream writes the JSON representation of the message to js.
+func (m *PlainMessage) MarshalJSONStream(js *jsonstream.Writer) error {
+	js.BeginObject()
+	if m.key != "" {
+		js.Name("key")
+		js.String(m.key)
+	}
+	if m.value != "" {
+		js.Name("value")
+		js.String(m.value)
+	}
+	js.EndObject()
+	return nil
+}
+
+// UnmarshalJSONStream replaces the content of the message by the message read
+// from r. The fields may be named using lowerCamelCase names or the original
+// proto names. Unknown fields result in an error.
+func (m *PlainMessage) UnmarshalJSONStream(r *jsonstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+	if err := r.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "key":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.key = v
+		case "value":
+			if r.ReadNull() {
+				// Null is the same as absent field.
+				continue
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.value = v
+		default:
+			return fmt.Errorf("unknown field %q in PlainMessage", name)
+		}
+	}
+	return nil
+}
+
+// MarshalText returns the text format representation of the message. The output
+// is the same as the output of prototext.MarshalOptions{Multiline: true}.Marshal().
+func (m *PlainMessage) MarshalText() ([]byte, error) {
+	tw := textstream.NewWriter(nil, "  ")
+	if err := m.MarshalTextStream(tw); err != nil {
+		return nil, err
+	}
+	tw.End()
+	return tw.BufferBytes()
+}
+
+// UnmarshalText replaces the content of the message by the message read from its
+// text format representation. The nested messages that were previously referenced
+// by the message are returned to their pools.
+func (m *PlainMessage) UnmarshalText(b []byte) error {
+	r := textstream.NewReader(b)
+	if err := m.UnmarshalTextStream(r); err != nil {
+		return err
+	}
+	return r.End()
+}
+
+// marshalTextUndecoded is the same as MarshalTextStream, but is used when the message
+// is not decoded yet. The message is decoded into a temporary struct, so that the
+// message itself remains undecoded.
+func (m *PlainMessage) marshalTextUndecoded(tw *textstream.Writer) error {
+	tmp := plainMessagePool.Get()
+	defer plainMessagePool.Release(tmp)
+	tmp._protoMessage.Bytes = m._protoMessage.Bytes
+	tmp._protoMessage.DiscardUnknown = true
+	if err := tmp.decode(); err != nil {
+		return err
+	}
+	return tmp.MarshalTextStream(tw)
+}
+
+// MarshalTextStream writes the fields of the message in text format to tw.
+func (m *PlainMessage) MarshalTextStream(tw *textstream.Writer) error {
+	if m.key != "" {
+		tw.Name("key")
+		tw.String(m.key)
+	}
+	if m.value != "" {
+		tw.Name("value")
+		tw.String(m.value)
+	}
+	return nil
+}
+
+// UnmarshalTextStream replaces the content of the message by the fields read
+// from r until the end of the message. Unknown fields result in an error.
+func (m *PlainMessage) UnmarshalTextStream(r *textstream.Reader) error {
+	parent := m._protoMessage.Parent
+	m.reset()
+	m._protoMessage.Parent = parent
+	if parent != nil {
+		parent.MarkModified()
+	}
+	for {
+		name, ok, err := r.NextField()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch name {
+		case "key":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.key = v
+		case "value":
+			if err := r.Colon(false); err != nil {
+				return err
+			}
+			v, err := r.ReadString()
+			if err != nil {
+				return err
+			}
+			m.value = v
+		default:
+			return fmt.Errorf("unknown field %q in PlainMessage", name)
+		}
+	}
+	return nil
+}
+
+// ProtoReflect returns the reflective view of the message, which implements
+// protoreflect.Message. The view uses the getters and setters of the message, so
+// the nested messages are decoded on access and the modifications mark the
+// message modified.
+func (m *PlainMessage) ProtoReflect() protoreflect.Message {
+	return plainMessageReflectInfo.MessageOf(m)
+}
+
+var plainMessageReflectInfo = &lazyreflect.MessageInfo[PlainMessage]{
+	File:          file_logs_proto,
+	FullName:      "simple.PlainMessage",
+	NewMessage:    NewPlainMessage,
+	UnknownFields: (*PlainMessage).UnknownFields,
+	SetUnknownFields: func(m *PlainMessage, b []byte) {
+		m._unknownFields.Reset()
+		m._unknownFields.Add(b)
+		m._protoMessage.MarkModified()
+	},
+	Fields: []lazyreflect.FieldInfo[PlainMessage]{
+		{
+			// key
+			Has:   func(m *PlainMessage) bool { return m.key != "" },
+			Get:   func(m *PlainMessage) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.Key()) },
+			Set:   func(m *PlainMessage, v protoreflect.Value) { m.SetKey(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *PlainMessage) { m.SetKey("") },
+		},
+		{
+			// value
+			Has:   func(m *PlainMessage) bool { return m.value != "" },
+			Get:   func(m *PlainMessage) protoreflect.Value { return lazyreflect.StringConv.ToValue(m.Value()) },
+			Set:   func(m *PlainMessage, v protoreflect.Value) { m.SetValue(lazyreflect.StringConv.FromValue(v)) },
+			Clear: func(m *PlainMessage) { m.SetValue("") },
+		},
+	},
+}
+
+// FromProto replaces the content of the message by the content of src, which is
+// the message of the same type generated by another protobuf implementation, e.g.
+// by protoc-gen-go or protoc-gen-gogo. The nested messages that were previously
+// referenced by the message are returned to their pools.
+func (m *PlainMessage) FromProto(src protoconv.Message) error {
+	b, err := protoconv.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(b, lazyproto.UnmarshalOpts{})
+}
+
+// ToProto replaces the content of dst, which is the message of the same type
+// generated by another protobuf implementation, by the content of the message.
+// If the message was not modified since it was unmarshalled its original bytes
+// are unmarshalled into dst, otherwise the message is marshalled first.
+func (m *PlainMessage) ToProto(dst protoconv.Message) error {
+	if !m._protoMessage.IsModified() {
+		return protoconv.Unmarshal(protomessage.BytesFromBytesView(m._protoMessage.Bytes), dst)
+	}
+
+	ps := molecule.NewProtoStream()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	return protoconv.Unmarshal(b, dst)
+}
+
+// PlainMessageSlice is a repeated field of PlainMessage messages. The methods that
+// modify the slice mark the message that contains the repeated field as modified.
+type PlainMessageSlice struct {
+	elems  *[]*PlainMessage
+	parent *protomessage.ProtoMessage
+}
+
+// Len returns the number of the elements.
+func (s PlainMessageSlice) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s PlainMessageSlice) At(i int) *PlainMessage {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s PlainMessageSlice) Range(f func(i int, elem *PlainMessage) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// Append appends the elements to the end of the slice.
+func (s PlainMessageSlice) Append(elems ...*PlainMessage) {
+	for _, elem := range elems {
+		elem._protoMessage.Parent = s.parent
+	}
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// AppendNew appends a new element taken from the pool and returns it.
+func (s PlainMessageSlice) AppendNew() *PlainMessage {
+	elem := plainMessagePool.Get()
+	elem._protoMessage.Parent = s.parent
+	*s.elems = append(*s.elems, elem)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+	return elem
+}
+
+// InsertAt inserts the element at index i, shifting the subsequent elements.
+func (s PlainMessageSlice) InsertAt(i int, elem *PlainMessage) {
+	elem._protoMessage.Parent = s.parent
+	elems := append(*s.elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	*s.elems = elems
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i and returns it to the pool.
+func (s PlainMessageSlice) RemoveAt(i int) {
+	elems := *s.elems
+	plainMessagePool.Release(elems[i])
+	copy(elems[i:], elems[i+1:])
+	elems[len(elems)-1] = nil
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveIf removes the elements for which f returns true and returns them to the pool.
+func (s PlainMessageSlice) RemoveIf(f func(elem *PlainMessage) bool) {
+	elems := *s.elems
+	newLen := 0
+	for _, elem := range elems {
+		if f(elem) {
+			plainMessagePool.Release(elem)
+			continue
+		}
+		elems[newLen] = elem
+		newLen++
+	}
+	if newLen == len(elems) {
+		// Nothing removed.
+		return
+	}
+
+	// Clear the tail, so that the removed elements are not referenced.
+	for i := newLen; i < len(elems); i++ {
+		elems[i] = nil
+	}
+	*s.elems = elems[:newLen]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Sort sorts the elements using the less function. The sort is stable.
+func (s PlainMessageSlice) Sort(less func(a, b *PlainMessage) bool) {
+	elems := *s.elems
+	sort.SliceStable(
+		elems, func(i, j int) bool {
+			return less(elems[i], elems[j])
+		},
+	)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// reset returns the nested messages to their pools and resets the message to
+// the empty state.
+func (m *PlainMessage) reset() {
+	elem := m
+
+	// Reset the released element.
+	elem._protoMessage.Reset()
+	elem._unknownFields.Reset()
+	elem.key = ""
+	elem.value = ""
+}
+
 // Pool of PlainMessage structs.
 type plainMessagePoolType struct {
 	pool []*PlainMessage
@@ -4845,11 +13018,7 @@ func (p *plainMessagePoolType) GetSlice(r []*PlainMessage) {
 // ReleaseSlice releases a slice of elements back to the pool.
 func (p *plainMessagePoolType) ReleaseSlice(slice []*PlainMessage) {
 	for _, elem := range slice {
-
-		// Reset the released element.
-		elem._protoMessage = protomessage.ProtoMessage{}
-		elem.key = ""
-		elem.value = ""
+		elem.reset()
 	}
 
 	p.mux.Lock()
@@ -4861,11 +13030,7 @@ func (p *plainMessagePoolType) ReleaseSlice(slice []*PlainMessage) {
 
 // Release an element back to the pool.
 func (p *plainMessagePoolType) Release(elem *PlainMessage) {
-
-	// Reset the released element.
-	elem._protoMessage = protomessage.ProtoMessage{}
-	elem.key = ""
-	elem.value = ""
+	elem.reset()
 
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -4873,3 +13038,200 @@ func (p *plainMessagePoolType) Release(elem *PlainMessage) {
 	// Add the slice to the end of the pool.
 	p.pool = append(p.pool, elem)
 }
+
+// XXX_ProtoMessage is for use by the code generated for other packages only.
+func (m *PlainMessage) XXX_ProtoMessage() *protomessage.ProtoMessage {
+	return &m._protoMessage
+}
+
+// XXX_Decode is for use by the code generated for other packages only.
+func (m *PlainMessage) XXX_Decode() error {
+	return m.decode()
+}
+
+// XXX_CloneInto is for use by the code generated for other packages only.
+func (m *PlainMessage) XXX_CloneInto(c *PlainMessage) {
+	m.cloneInto(c)
+}
+
+// XXX_MarshalJSONUndecoded is for use by the code generated for other packages only.
+func (m *PlainMessage) XXX_MarshalJSONUndecoded(js *jsonstream.Writer) error {
+	return m.marshalJSONUndecoded(js)
+}
+
+// XXX_MarshalTextUndecoded is for use by the code generated for other packages only.
+func (m *PlainMessage) XXX_MarshalTextUndecoded(tw *textstream.Writer) error {
+	return m.marshalTextUndecoded(tw)
+}
+
+// XXX_ValidatePlainMessage is for use by the code generated for other packages only.
+func XXX_ValidatePlainMessage(b []byte) error {
+	return validatePlainMessage(b)
+}
+
+// XXX_NewPlainMessageSlice is for use by the code generated for other packages only.
+func XXX_NewPlainMessageSlice(elems *[]*PlainMessage, parent *protomessage.ProtoMessage) PlainMessageSlice {
+	return PlainMessageSlice{elems: elems, parent: parent}
+}
+
+// XXX_PlainMessagePool is for use by the code generated for other packages only.
+var XXX_PlainMessagePool = &plainMessagePool
+
+// file_logs_proto_rawDesc is the serialized FileDescriptorProto of logs.proto.
+var file_logs_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x73, 0x69,
+	0x6d, 0x70, 0x6c, 0x65, 0x1a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67,
+	0x6f, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x67, 0x6f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x45, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x39, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x0c, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x0c, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42,
+	0x04, 0xc8, 0xde, 0x1f, 0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x30, 0x0a, 0x0a, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x09, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x4c, 0x6f, 0x67,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x55, 0x72, 0x6c,
+	0x22, 0x7c, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x0a,
+	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x04, 0xc8, 0xde, 0x1f, 0x00, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x98,
+	0x01, 0x0a, 0x09, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x38, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x69,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x42, 0x04, 0xc8, 0xde, 0x1f, 0x00, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x69,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0a,
+	0x6c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x55, 0x72, 0x6c, 0x22, 0xb6, 0x01, 0x0a, 0x14, 0x49, 0x6e,
+	0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x36, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4b, 0x65,
+	0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xc8, 0xde, 0x1f, 0x00, 0x52, 0x0a, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x64, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x64, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x8a, 0x03, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x24, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61,
+	0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x6e,
+	0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x35, 0x0a, 0x17, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e,
+	0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x06, 0x52, 0x14, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x3f, 0x0a,
+	0x0f, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x0e,
+	0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x54,
+	0x65, 0x78, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xc8, 0xde, 0x1f, 0x00, 0x52,
+	0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x07, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x70, 0x61, 0x6e, 0x49, 0x64, 0x22,
+	0x4a, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x41, 0x6e, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04,
+	0xc8, 0xde, 0x1f, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb2, 0x02, 0x0a, 0x08,
+	0x41, 0x6e, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a,
+	0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d,
+	0x0a, 0x09, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a,
+	0x0c, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x61,
+	0x72, 0x72, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x39, 0x0a, 0x0c, 0x6b, 0x76, 0x6c,
+	0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6b, 0x76, 0x6c, 0x69, 0x73, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x36, 0x0a, 0x0a, 0x41, 0x72, 0x72, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x41, 0x6e, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x3e, 0x0a, 0x0c, 0x4b, 0x65, 0x79, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xc8, 0xde, 0x1f, 0x00,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x69,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x2a, 0xc3, 0x05, 0x0a, 0x0e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59,
+	0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12,
+	0x1a, 0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42,
+	0x45, 0x52, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x32, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x53,
+	0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x54,
+	0x52, 0x41, 0x43, 0x45, 0x33, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45,
+	0x34, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x05, 0x12, 0x1a,
+	0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45,
+	0x52, 0x5f, 0x44, 0x45, 0x42, 0x55, 0x47, 0x32, 0x10, 0x06, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45,
+	0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x44, 0x45,
+	0x42, 0x55, 0x47, 0x33, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49,
+	0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x44, 0x45, 0x42, 0x55, 0x47, 0x34,
+	0x10, 0x08, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e,
+	0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x09, 0x12, 0x19, 0x0a, 0x15,
+	0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f,
+	0x49, 0x4e, 0x46, 0x4f, 0x32, 0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x33,
+	0x10, 0x0b, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e,
+	0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x34, 0x10, 0x0c, 0x12, 0x18, 0x0a,
+	0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52,
+	0x5f, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x0d, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x32,
+	0x10, 0x0e, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e,
+	0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x33, 0x10, 0x0f, 0x12, 0x19, 0x0a,
+	0x15, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52,
+	0x5f, 0x57, 0x41, 0x52, 0x4e, 0x34, 0x10, 0x10, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45,
+	0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x11, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x32, 0x10, 0x12, 0x12,
+	0x1a, 0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42,
+	0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x33, 0x10, 0x13, 0x12, 0x1a, 0x0a, 0x16, 0x53,
+	0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x34, 0x10, 0x14, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x46, 0x41, 0x54, 0x41, 0x4c,
+	0x10, 0x15, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e,
+	0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x46, 0x41, 0x54, 0x41, 0x4c, 0x32, 0x10, 0x16, 0x12, 0x1a,
+	0x0a, 0x16, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45,
+	0x52, 0x5f, 0x46, 0x41, 0x54, 0x41, 0x4c, 0x33, 0x10, 0x17, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45,
+	0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x46, 0x41,
+	0x54, 0x41, 0x4c, 0x34, 0x10, 0x18, 0x42, 0x0a, 0x5a, 0x08, 0x67, 0x65, 0x6e, 0x2f, 0x6c, 0x6f,
+	0x67, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_logs_proto = lazyreflect.RegisterFile("logs.proto", file_logs_proto_rawDesc)
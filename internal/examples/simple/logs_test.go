@@ -1,6 +1,8 @@
 package simple
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"sync"
 	"testing"
@@ -10,11 +12,12 @@ import (
 	gogomsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/gogo/gen/logs"
 	googlemsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/google/gen/logs"
 	lazymsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/lazy"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
 	googlelib "google.golang.org/protobuf/proto"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule"
 )
 
 const scaleCount = 10
@@ -189,59 +192,59 @@ func TestDecode(t *testing.T) {
 	require.NoError(t, err)
 
 	rl := lazy.ResourceLogs()
-	require.Len(t, rl, 1)
+	require.EqualValues(t, 1, rl.Len())
 
-	resource := rl[0].Resource()
+	resource := rl.At(0).Resource()
 	assert.EqualValues(t, 12, resource.DroppedAttributesCount())
 	require.NotNil(t, resource)
 
 	resAttrs := resource.Attributes()
-	require.Len(t, resAttrs, 3)
+	require.EqualValues(t, 3, resAttrs.Len())
 
-	kvr := resAttrs[0]
+	kvr := resAttrs.At(0)
 	require.EqualValues(t, "key1", kvr.Key())
 	require.EqualValues(t, lazymsg.AnyValueStringValue, kvr.Value().ValueType())
 	require.EqualValues(t, "value1", kvr.Value().StringValue())
 
-	kvr = resAttrs[1]
+	kvr = resAttrs.At(1)
 	require.EqualValues(t, "multivalue", kvr.Key())
 	require.EqualValues(t, lazymsg.AnyValueArrayValue, kvr.Value().ValueType())
 	arrayVals := kvr.Value().ArrayValue().Values()
-	require.Len(t, arrayVals, 1)
-	require.EqualValues(t, "1.2.3.4", arrayVals[0].StringValue())
+	require.EqualValues(t, 1, arrayVals.Len())
+	require.EqualValues(t, "1.2.3.4", arrayVals.At(0).StringValue())
 
-	kvr = resAttrs[2]
+	kvr = resAttrs.At(2)
 	require.EqualValues(t, "nested", kvr.Key())
 	require.EqualValues(t, lazymsg.AnyValueKvlistValue, kvr.Value().ValueType())
 	kvVals := kvr.Value().KvlistValue().Values()
-	require.Len(t, kvVals, 2)
-	require.EqualValues(t, "x", kvVals[0].Key())
-	require.EqualValues(t, "10", kvVals[0].Value().StringValue())
-	require.EqualValues(t, "y", kvVals[1].Key())
-	require.EqualValues(t, "20", kvVals[1].Value().StringValue())
+	require.EqualValues(t, 2, kvVals.Len())
+	require.EqualValues(t, "x", kvVals.At(0).Key())
+	require.EqualValues(t, "10", kvVals.At(0).Value().StringValue())
+	require.EqualValues(t, "y", kvVals.At(1).Key())
+	require.EqualValues(t, "20", kvVals.At(1).Value().StringValue())
 
-	sls := rl[0].ScopeLogs()
-	require.Len(t, sls, 1)
+	sls := rl.At(0).ScopeLogs()
+	require.EqualValues(t, 1, sls.Len())
 
-	sl := sls[0]
+	sl := sls.At(0)
 	logRecords := sl.LogRecords()
-	require.Len(t, logRecords, 1)
+	require.EqualValues(t, 1, logRecords.Len())
 
-	logRecord := logRecords[0]
+	logRecord := logRecords.At(0)
 	assert.EqualValues(t, 123, logRecord.TimeUnixNano())
 	assert.EqualValues(t, 234, logRecord.DroppedAttributesCount())
 	assert.EqualValues(t, []byte{1, 2, 3, 4, 5}, logRecord.SpanId())
 	assert.EqualValues(t, []byte{6, 7, 8, 9}, logRecord.TraceId())
 
 	attrs2 := logRecord.Attributes()
-	require.Len(t, attrs2, 1)
+	require.EqualValues(t, 1, attrs2.Len())
 
-	kv2 := attrs2[0]
+	kv2 := attrs2.At(0)
 	require.EqualValues(t, "key2", kv2.Key())
 	require.EqualValues(t, lazymsg.AnyValueStringValue, kv2.Value().ValueType())
 	require.EqualValues(t, "value2", kv2.Value().StringValue())
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	err = lazy.Marshal(ps)
 	assert.NoError(t, err)
 
@@ -259,7 +262,7 @@ func TestLazy_Pass(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, goldenWireBytes)
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 	require.NoError(t, err)
 
@@ -274,6 +277,324 @@ func TestLazy_Pass(t *testing.T) {
 	lazy.Free()
 }
 
+func marshalLazy(t *testing.T, lazy *lazymsg.LogsData) []byte {
+	ps := newMarshalStream()
+	require.NoError(t, lazy.Marshal(ps))
+	lazyBytes, err := ps.BufferBytes()
+	require.NoError(t, err)
+	return lazyBytes
+}
+
+func TestLazy_CloneUndecoded(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(t, err)
+
+	clone := lazy.Clone()
+
+	// Source can be freed independently of the clone.
+	expectedCount := countAttrsLazy(lazy)
+	lazy.Free()
+
+	assert.EqualValues(t, expectedCount, countAttrsLazy(clone))
+	assert.EqualValues(t, goldenWireBytes, marshalLazy(t, clone))
+	clone.Free()
+}
+
+func TestLazy_CloneModified(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(t, err)
+
+	// Decode and modify everything in the source before cloning.
+	countAttrsLazy(lazy)
+	touchAll(lazy)
+
+	clone := lazy.Clone()
+	assert.EqualValues(t, goldenWireBytes, marshalLazy(t, clone))
+
+	// Modifying the clone does not modify the source.
+	attr := clone.ResourceLogs().At(0).Resource().Attributes().At(0)
+	attr.SetKey("changed")
+	attr.Value().SetStringValue("changed")
+	assert.EqualValues(t, goldenWireBytes, marshalLazy(t, lazy))
+	assert.NotEqualValues(t, goldenWireBytes, marshalLazy(t, clone))
+
+	// Modifying the source does not modify the clone.
+	cloneBytes := marshalLazy(t, clone)
+	lazy.ResourceLogs().At(0).Resource().SetDroppedAttributesCount(1000)
+	assert.EqualValues(t, cloneBytes, marshalLazy(t, clone))
+
+	lazy.Free()
+	assert.EqualValues(t, cloneBytes, marshalLazy(t, clone))
+	clone.Free()
+}
+
+func TestLazy_MarshalJSON(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	var google googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(goldenWireBytes, &google))
+	googleJSON, err := protojson.Marshal(&google)
+	require.NoError(t, err)
+	// protojson randomly inserts whitespace, compact it to compare byte-for-byte.
+	var expected bytes.Buffer
+	require.NoError(t, json.Compact(&expected, googleJSON))
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(t, err)
+
+	lazyJSON, err := lazy.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, expected.String(), string(lazyJSON))
+
+	// The message is still unmodified and marshals to the original bytes.
+	assert.EqualValues(t, goldenWireBytes, marshalLazy(t, lazy))
+	lazy.Free()
+
+	// Unmarshalling the JSON produces the same message.
+	lazy = lazymsg.NewLogsData()
+	require.NoError(t, lazy.UnmarshalJSON(lazyJSON))
+	var google2 googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(marshalLazy(t, lazy), &google2))
+	assert.True(t, googlelib.Equal(&google, &google2))
+	lazy.Free()
+}
+
+func TestLazy_MarshalText(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	var google googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(goldenWireBytes, &google))
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(t, err)
+	lazyText, err := lazy.MarshalText()
+	require.NoError(t, err)
+	lazy.Free()
+
+	// The text can be parsed by prototext.
+	var google2 googlemsg.LogsData
+	require.NoError(t, prototext.Unmarshal(lazyText, &google2))
+	assert.True(t, googlelib.Equal(&google, &google2))
+
+	// And the text produced by prototext can be parsed by lazyproto.
+	googleText, err := prototext.Marshal(&google)
+	require.NoError(t, err)
+	lazy = lazymsg.NewLogsData()
+	require.NoError(t, lazy.UnmarshalText(googleText))
+	var google3 googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(marshalLazy(t, lazy), &google3))
+	assert.True(t, googlelib.Equal(&google, &google3))
+	lazy.Free()
+}
+
+func TestLazy_ProtoReflect(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	var google googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(goldenWireBytes, &google))
+
+	// The messages can be used with the libraries that work via protoreflect.
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(t, err)
+	lazyJSON, err := protojson.Marshal(lazy)
+	require.NoError(t, err)
+	googleJSON, err := protojson.Marshal(&google)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(googleJSON), string(lazyJSON))
+
+	wireBytes, err := googlelib.Marshal(lazy)
+	require.NoError(t, err)
+	var google2 googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(wireBytes, &google2))
+	assert.True(t, googlelib.Equal(&google, &google2))
+	assert.False(t, lazy.IsModified())
+	lazy.Free()
+}
+
+func TestLazy_ToProto(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	var google googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(goldenWireBytes, &google))
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(t, err)
+
+	var google2 googlemsg.LogsData
+	require.NoError(t, lazy.ToProto(&google2))
+	assert.True(t, googlelib.Equal(&google, &google2))
+
+	var gogo gogomsg.LogsData
+	require.NoError(t, lazy.ToProto(&gogo))
+	assert.True(t, gogolib.Equal(src, &gogo))
+
+	// Modify the message and convert it again.
+	lazy.ResourceLogs().At(0).Resource().SetDroppedAttributesCount(123)
+	google.ResourceLogs[0].Resource.DroppedAttributesCount = 123
+	require.NoError(t, lazy.ToProto(&google2))
+	assert.True(t, googlelib.Equal(&google, &google2))
+	lazy.Free()
+}
+
+func TestLazy_FromProto(t *testing.T) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	var google googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(goldenWireBytes, &google))
+
+	lazy := lazymsg.NewLogsData()
+	require.NoError(t, lazy.FromProto(&google))
+	var google2 googlemsg.LogsData
+	require.NoError(t, googlelib.Unmarshal(marshalLazy(t, lazy), &google2))
+	assert.True(t, googlelib.Equal(&google, &google2))
+
+	require.NoError(t, lazy.FromProto(src))
+	var gogo gogomsg.LogsData
+	require.NoError(t, gogolib.Unmarshal(marshalLazy(t, lazy), &gogo))
+	assert.True(t, gogolib.Equal(src, &gogo))
+	lazy.Free()
+}
+
+func attrKeys(attrs lazymsg.KeyValueSlice) []string {
+	var keys []string
+	attrs.Range(
+		func(i int, attr *lazymsg.KeyValue) bool {
+			keys = append(keys, attr.Key())
+			return true
+		},
+	)
+	return keys
+}
+
+func TestLazy_SliceMethods(t *testing.T) {
+	src := &gogomsg.Resource{
+		Attributes: []gogomsg.KeyValue{
+			createAttr("b", "1"),
+			createAttr("a", "2"),
+			createAttr("c", "3"),
+		},
+	}
+	wireBytes, err := gogolib.Marshal(src)
+	require.NoError(t, err)
+
+	lazy, err := lazymsg.UnmarshalResource(wireBytes, unmarshalOpts())
+	require.NoError(t, err)
+
+	attrs := lazy.Attributes()
+	assert.EqualValues(t, []string{"b", "a", "c"}, attrKeys(attrs))
+
+	// Range stops when f returns false.
+	calls := 0
+	attrs.Range(
+		func(i int, attr *lazymsg.KeyValue) bool {
+			calls++
+			return false
+		},
+	)
+	assert.EqualValues(t, 1, calls)
+
+	attrs.Sort(
+		func(a, b *lazymsg.KeyValue) bool {
+			return a.Key() < b.Key()
+		},
+	)
+	assert.EqualValues(t, []string{"a", "b", "c"}, attrKeys(attrs))
+
+	attrs.RemoveAt(1)
+	assert.EqualValues(t, []string{"a", "c"}, attrKeys(attrs))
+
+	newAttr := attrs.AppendNew()
+	newAttr.SetKey("d")
+	assert.EqualValues(t, []string{"a", "c", "d"}, attrKeys(attrs))
+
+	// Append an element taken from another message.
+	other, err := lazymsg.UnmarshalResource(wireBytes, unmarshalOpts())
+	require.NoError(t, err)
+	attrs.Append(other.Attributes().At(0))
+	other.Attributes().RemoveIf(
+		func(attr *lazymsg.KeyValue) bool {
+			return attr.Key() != "b"
+		},
+	)
+	assert.EqualValues(t, []string{"a", "c", "d", "b"}, attrKeys(attrs))
+
+	insertAttr := attrs.AppendNew()
+	insertAttr.SetKey("e")
+	attrs.RemoveIf(
+		func(attr *lazymsg.KeyValue) bool {
+			return attr.Key() == "e" || attr.Key() == "c"
+		},
+	)
+	assert.EqualValues(t, []string{"a", "d", "b"}, attrKeys(attrs))
+
+	other2, err := lazymsg.UnmarshalResource(wireBytes, unmarshalOpts())
+	require.NoError(t, err)
+	attrs.InsertAt(0, other2.Attributes().At(2))
+	assert.EqualValues(t, []string{"c", "a", "d", "b"}, attrKeys(attrs))
+	assert.EqualValues(t, 4, attrs.Len())
+
+	// The modifications must be marshalled.
+	expected := &gogomsg.Resource{
+		Attributes: []gogomsg.KeyValue{
+			createAttr("c", "3"),
+			createAttr("a", "2"),
+			{Key: "d"},
+			createAttr("b", "1"),
+		},
+	}
+
+	ps := newMarshalStream()
+	require.NoError(t, lazy.Marshal(ps))
+	lazyBytes, err := ps.BufferBytes()
+	require.NoError(t, err)
+
+	var actual gogomsg.Resource
+	require.NoError(t, gogolib.Unmarshal(lazyBytes, &actual))
+	assert.EqualValues(t, expected, &actual)
+}
+
+func BenchmarkLazy_Clone(b *testing.B) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(b, err)
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(b, err)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		clone := lazy.Clone()
+		clone.Free()
+	}
+}
+
 func forReport(b *testing.B) {
 	if os.Getenv("FORREPORT") == "" {
 		b.Skip()
@@ -484,8 +805,9 @@ func readAnyValueLazy(v *lazymsg.AnyValue) int {
 			count++
 		}
 	case lazymsg.AnyValueArrayValue:
-		for _, e := range v.ArrayValue().Values() {
-			count += readAnyValueLazy(e)
+		values := v.ArrayValue().Values()
+		for i := 0; i < values.Len(); i++ {
+			count += readAnyValueLazy(values.At(i))
 		}
 	case lazymsg.AnyValueKvlistValue:
 		count += readAttrsLazy(v.KvlistValue().Values())
@@ -510,9 +832,10 @@ func readAnyValueGogo(v gogomsg.AnyValue) int {
 	return count
 }
 
-func readAttrsLazy(v []*lazymsg.KeyValue) int {
+func readAttrsLazy(v lazymsg.KeyValueSlice) int {
 	count := 0
-	for _, e := range v {
+	for i := 0; i < v.Len(); i++ {
+		e := v.At(i)
 		if e.Key() != "" {
 			count++
 		}
@@ -535,22 +858,24 @@ func readAttrsGogo(v []gogomsg.KeyValue) int {
 func countAttrsLazy(lazy *lazymsg.LogsData) int {
 	attrCount := 0
 	rls := lazy.ResourceLogs()
-	for _, rl := range rls {
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
 		resource := rl.Resource()
 
 		attrs := resource.Attributes()
-		attrCount += len(attrs)
+		attrCount += attrs.Len()
 		readAttrsLazy(attrs)
 
 		sls := rl.ScopeLogs()
-		for _, sl := range sls {
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
 			readAttrsLazy(sl.Scope().Attributes())
 
 			logRecords := sl.LogRecords()
 
-			for _, logRecord := range logRecords {
-				attrs2 := logRecord.Attributes()
-				attrCount += len(attrs2)
+			for k := 0; k < logRecords.Len(); k++ {
+				attrs2 := logRecords.At(k).Attributes()
+				attrCount += attrs2.Len()
 				readAttrsLazy(attrs2)
 			}
 		}
@@ -595,16 +920,18 @@ func touchAnyValue(v *lazymsg.AnyValue) {
 	case lazymsg.AnyValueBytesValue:
 		v.SetBytesValue(v.BytesValue())
 	case lazymsg.AnyValueArrayValue:
-		for _, e := range v.ArrayValue().Values() {
-			touchAnyValue(e)
+		values := v.ArrayValue().Values()
+		for i := 0; i < values.Len(); i++ {
+			touchAnyValue(values.At(i))
 		}
 	case lazymsg.AnyValueKvlistValue:
 		touchAttrs(v.KvlistValue().Values())
 	}
 }
 
-func touchAttrs(v []*lazymsg.KeyValue) {
-	for _, e := range v {
+func touchAttrs(v lazymsg.KeyValueSlice) {
+	for i := 0; i < v.Len(); i++ {
+		e := v.At(i)
 		e.SetKey(e.Key())
 		touchAnyValue(e.Value())
 	}
@@ -612,20 +939,22 @@ func touchAttrs(v []*lazymsg.KeyValue) {
 
 func touchAll(lazy *lazymsg.LogsData) {
 	rls := lazy.ResourceLogs()
-	for _, rl := range rls {
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
 		resource := rl.Resource()
 
 		attrs := resource.Attributes()
 		touchAttrs(attrs)
 
 		sls := rl.ScopeLogs()
-		for _, sl := range sls {
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
 			touchAttrs(sl.Scope().Attributes())
 
 			logRecords := sl.LogRecords()
 
-			for _, logRecord := range logRecords {
-				touchAttrs(logRecord.Attributes())
+			for k := 0; k < logRecords.Len(); k++ {
+				touchAttrs(logRecords.At(k).Attributes())
 			}
 		}
 	}
@@ -645,7 +974,7 @@ func BenchmarkLazy_Marshal_Unchanged(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		ps.Reset()
 		err = lazy.Marshal(ps)
@@ -674,7 +1003,7 @@ func BenchmarkLazy_Marshal_ModifyAll(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		ps.Reset()
 		err = lazy.Marshal(ps)
@@ -688,6 +1017,41 @@ func BenchmarkLazy_Marshal_ModifyAll(b *testing.B) {
 	}
 }
 
+// BenchmarkLazy_Marshal_ModifyAllEachTime is the same as BenchmarkLazy_Marshal_ModifyAll,
+// but the messages are modified before each Marshal, so the sizes cached by the
+// previous Marshal can't be used.
+func BenchmarkLazy_Marshal_ModifyAllEachTime(b *testing.B) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(b, err)
+	require.NotNil(b, goldenWireBytes)
+
+	lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+	require.NoError(b, err)
+
+	countAttrsLazy(lazy)
+
+	b.ResetTimer()
+
+	ps := newMarshalStream()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		touchAll(lazy)
+		b.StartTimer()
+
+		ps.Reset()
+		err = lazy.Marshal(ps)
+		require.NoError(b, err)
+
+		lazyBytes, err := ps.BufferBytes()
+		assert.NoError(b, err)
+		if i%20 == 0 {
+			assert.EqualValues(b, goldenWireBytes, lazyBytes)
+		}
+	}
+}
+
 func BenchmarkGoogle_Pass_NoReadNoModify(b *testing.B) {
 	forReport(b)
 	BenchmarkGoogle_Pass_ModifyAll(b)
@@ -778,7 +1142,7 @@ func BenchmarkLazy_Pass_NoReadNoModify(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 		require.NoError(b, err)
@@ -807,7 +1171,7 @@ func BenchmarkLazy_Pass_ReadAllNoModify(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 		require.NoError(b, err)
@@ -839,7 +1203,7 @@ func BenchmarkLazy_Pass_ModifyAll(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 		require.NoError(b, err)
@@ -888,7 +1252,7 @@ func BenchmarkLazy_Pass_ModifyAllConc(b *testing.B) {
 		go func() {
 			defer wg.Done()
 
-			ps := molecule.NewProtoStream()
+			ps := newMarshalStream()
 			for i := 0; i < b.N; i++ {
 				lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 				require.NoError(b, err)
@@ -998,18 +1362,23 @@ func BenchmarkLazy_Inspect_ScopeAttr(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		inputMsg, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 		require.NoError(b, err)
 
 		foundCount := 0
-		for _, rl := range inputMsg.ResourceLogs() {
-			for _, sl := range rl.ScopeLogs() {
+		rls := inputMsg.ResourceLogs()
+		for j := 0; j < rls.Len(); j++ {
+			sls := rls.At(j).ScopeLogs()
+			for k := 0; k < sls.Len(); k++ {
+				sl := sls.At(k)
 				if sl.Scope() == nil {
 					continue
 				}
-				for _, attr := range sl.Scope().Attributes() {
+				attrs := sl.Scope().Attributes()
+				for l := 0; l < attrs.Len(); l++ {
+					attr := attrs.At(l)
 					if attr.Key() == "otel.profiling" &&
 						attr.Value().ValueType() == lazymsg.AnyValueStringValue &&
 						attr.Value().StringValue() == "true" {
@@ -1117,19 +1486,25 @@ func BenchmarkLazy_Inspect_LogAttr(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		inputMsg, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 		require.NoError(b, err)
 
 		foundCount := 0
-		for _, rl := range inputMsg.ResourceLogs() {
-			for _, sl := range rl.ScopeLogs() {
+		rls := inputMsg.ResourceLogs()
+		for j := 0; j < rls.Len(); j++ {
+			sls := rls.At(j).ScopeLogs()
+			for k := 0; k < sls.Len(); k++ {
+				sl := sls.At(k)
 				if sl.Scope() == nil {
 					continue
 				}
-				for _, lr := range sl.LogRecords() {
-					for _, attr := range lr.Attributes() {
+				lrs := sl.LogRecords()
+				for l := 0; l < lrs.Len(); l++ {
+					attrs := lrs.At(l).Attributes()
+					for n := 0; n < attrs.Len(); n++ {
+						attr := attrs.At(n)
 						if attr.Key() == "http.method" &&
 							attr.Value().ValueType() == lazymsg.AnyValueStringValue &&
 							attr.Value().StringValue() == "GET" {
@@ -1237,6 +1612,34 @@ func BenchmarkGogo_Filter_ScopeAttr(b *testing.B) {
 	}
 }
 
+// filterLazyScopeAttr removes the scopes that have the "otel.profiling" attribute
+// and returns the number of removed scopes.
+func filterLazyScopeAttr(inputMsg *lazymsg.LogsData) int {
+	foundCount := 0
+	rls := inputMsg.ResourceLogs()
+	for j := 0; j < rls.Len(); j++ {
+		rls.At(j).ScopeLogs().RemoveIf(
+			func(sl *lazymsg.ScopeLogs) bool {
+				if sl.Scope() == nil {
+					return false
+				}
+				attrs := sl.Scope().Attributes()
+				for k := 0; k < attrs.Len(); k++ {
+					attr := attrs.At(k)
+					if attr.Key() == "otel.profiling" &&
+						attr.Value().ValueType() == lazymsg.AnyValueStringValue &&
+						attr.Value().StringValue() == "true" {
+						foundCount++
+						return true
+					}
+				}
+				return false
+			},
+		)
+	}
+	return foundCount
+}
+
 func BenchmarkLazy_Filter_ScopeAttr(b *testing.B) {
 	src := createLogsData(scaleCount, 1)
 
@@ -1246,31 +1649,12 @@ func BenchmarkLazy_Filter_ScopeAttr(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 	for i := 0; i < b.N; i++ {
 		inputMsg, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
 		require.NoError(b, err)
 
-		foundCount := 0
-		for _, rl := range inputMsg.ResourceLogs() {
-			rl.ScopeLogsRemoveIf(
-				func(sl *lazymsg.ScopeLogs) bool {
-					if sl.Scope() == nil {
-						return false
-					}
-					for _, attr := range sl.Scope().Attributes() {
-						if attr.Key() == "otel.profiling" &&
-							attr.Value().ValueType() == lazymsg.AnyValueStringValue &&
-							attr.Value().StringValue() == "true" {
-							foundCount++
-							return true
-						}
-					}
-					return false
-				},
-			)
-		}
-		assert.Equal(b, 50, foundCount)
+		assert.Equal(b, 50, filterLazyScopeAttr(inputMsg))
 
 		ps.Reset()
 		err = inputMsg.Marshal(ps)
@@ -1357,7 +1741,7 @@ func BenchmarkLazy_Batch(b *testing.B) {
 
 	b.ResetTimer()
 
-	ps := molecule.NewProtoStream()
+	ps := newMarshalStream()
 
 	for i := 0; i < b.N; i++ {
 		var inputMsg [10]*lazymsg.LogsData
@@ -1368,9 +1752,10 @@ func BenchmarkLazy_Batch(b *testing.B) {
 			inputMsg[j], err = lazymsg.UnmarshalLogsData(inputWireBytes, unmarshalOpts())
 			require.NoError(b, err)
 
-			resourceLogs = append(
-				resourceLogs, inputMsg[j].ResourceLogs()...,
-			)
+			rls := inputMsg[j].ResourceLogs()
+			for k := 0; k < rls.Len(); k++ {
+				resourceLogs = append(resourceLogs, rls.At(k))
+			}
 		}
 
 		outputMsg.SetResourceLogs(resourceLogs)
@@ -1388,6 +1773,8 @@ func BenchmarkLazy_Batch(b *testing.B) {
 		}
 
 		for j := 0; j < 10; j++ {
+			// The resourceLogs are moved to outputMsg, which releases them.
+			inputMsg[j].SetResourceLogs(nil)
 			inputMsg[j].Free()
 		}
 		outputMsg.Free()
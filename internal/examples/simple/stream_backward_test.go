@@ -0,0 +1,11 @@
+//go:build backwardstream
+
+package simple
+
+import "github.com/tigrannajaryan/exp-lazyproto/runtime/streams/backwardstream"
+
+type marshalStream = backwardstream.BackwardMemStream
+
+func newMarshalStream() *marshalStream {
+	return backwardstream.NewBackwardMemStream()
+}
@@ -0,0 +1,128 @@
+//go:build !sizedstream && !backwardstream
+
+package simple
+
+import (
+	"testing"
+
+	gogolib "github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazymsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+// marshalStream is the stream that the lazy messages are generated to marshal
+// into. The benchmarks can be run against the other marshal streams by regenerating
+// the lazy messages with --marshal_stream and running the tests with the matching
+// build tag, see "make benchmark-streams".
+type marshalStream = molecule.ProtoStream
+
+func newMarshalStream() *marshalStream {
+	return molecule.NewProtoStream()
+}
+
+// The benchmarks below use the features that only the molecule stream has.
+
+// BenchmarkLazy_Marshal_ModifyAllFanOut marshals the modified message for several
+// exporters, with and without caching the encoded bytes by the first Marshal.
+func BenchmarkLazy_Marshal_ModifyAllFanOut(b *testing.B) {
+	const exporters = 4
+	for _, cacheBytes := range []bool{false, true} {
+		name := "nocache"
+		if cacheBytes {
+			name = "cache"
+		}
+		b.Run(
+			name, func(b *testing.B) {
+				src := createLogsData(scaleCount, 1)
+				goldenWireBytes, err := gogolib.Marshal(src)
+				require.NoError(b, err)
+
+				lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+				require.NoError(b, err)
+				countAttrsLazy(lazy)
+
+				b.ResetTimer()
+
+				ps := molecule.NewProtoStream()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					touchAll(lazy)
+					b.StartTimer()
+
+					for j := 0; j < exporters; j++ {
+						ps.Reset()
+						ps.SetCacheBytes(cacheBytes && j == 0)
+						err = lazy.Marshal(ps)
+						require.NoError(b, err)
+
+						if ps.Len() != len(goldenWireBytes) {
+							b.Fatal("unexpected marshaled size")
+						}
+						if cacheBytes && j == 0 {
+							// The bytes are now owned by the messages.
+							ps.DetachBuffer()
+						}
+					}
+				}
+			},
+		)
+	}
+}
+
+func BenchmarkLazy_Pass_NoReadNoModify_Vectored(b *testing.B) {
+	// Same as BenchmarkLazy_Pass_NoReadNoModify, but the unmodified bytes are
+	// referenced by the output buffers instead of being copied.
+
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(b, err)
+	require.NotNil(b, goldenWireBytes)
+
+	b.ResetTimer()
+
+	ps := molecule.NewProtoStream()
+	var joined []byte
+	for i := 0; i < b.N; i++ {
+		lazy, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+		require.NoError(b, err)
+
+		buffers, err := lazyproto.MarshalVectored(lazy, ps)
+		require.NoError(b, err)
+		if i%20 == 0 {
+			joined = joined[:0]
+			for _, buf := range buffers {
+				joined = append(joined, buf...)
+			}
+			assert.EqualValues(b, goldenWireBytes, joined)
+		}
+	}
+}
+
+func BenchmarkLazy_Filter_ScopeAttr_Vectored(b *testing.B) {
+	src := createLogsData(scaleCount, 1)
+
+	goldenWireBytes, err := gogolib.Marshal(src)
+	require.NoError(b, err)
+	require.NotNil(b, goldenWireBytes)
+
+	b.ResetTimer()
+
+	ps := molecule.NewProtoStream()
+	for i := 0; i < b.N; i++ {
+		inputMsg, err := lazymsg.UnmarshalLogsData(goldenWireBytes, unmarshalOpts())
+		require.NoError(b, err)
+
+		assert.Equal(b, 50, filterLazyScopeAttr(inputMsg))
+
+		buffers, err := lazyproto.MarshalVectored(inputMsg, ps)
+		require.NoError(b, err)
+		assert.NotNil(b, buffers)
+
+		inputMsg.Free()
+	}
+}
@@ -0,0 +1,11 @@
+//go:build sizedstream
+
+package simple
+
+import "github.com/tigrannajaryan/exp-lazyproto/runtime/streams/sizedstream"
+
+type marshalStream = sizedstream.ProtoStream
+
+func newMarshalStream() *marshalStream {
+	return sizedstream.NewProtoStream()
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy/resource"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+func marshalCached(t *testing.T, ps *molecule.ProtoStream, m lazyproto.Message) []byte {
+	ps.Reset()
+	ps.SetCacheBytes(true)
+	require.NoError(t, m.Marshal(ps))
+	return ps.DetachBuffer()
+}
+
+func requireUnmodified(t *testing.T, req *lazy.ExportRequest) {
+	require.False(t, req.IsModified())
+	req.Resources().Range(
+		func(_ int, res *resource.Resource) bool {
+			require.False(t, res.IsModified())
+			require.False(t, res.Attributes().At(0).IsModified())
+			return true
+		},
+	)
+}
+
+func TestCacheBytes(t *testing.T) {
+	// All messages are created locally, so they are all modified.
+	req := newExportRequest("a", "b")
+	expected := marshalLazy(t, req)
+
+	ps := molecule.NewProtoStream()
+	b := marshalCached(t, ps, req)
+	assert.EqualValues(t, expected, b)
+	requireUnmodified(t, req)
+
+	// The stream no longer owns the detached bytes, so writing to it again doesn't
+	// overwrite the bytes which the messages point at.
+	ps.Reset()
+	require.NoError(t, newExportRequest("c", "d", "e").Marshal(ps))
+	assert.EqualValues(t, expected, marshalLazy(t, req))
+
+	// The messages can be modified again after caching.
+	req.Resources().At(1).Attributes().At(0).SetKey("modified")
+	assert.True(t, req.IsModified())
+	assert.False(t, req.Resources().At(0).IsModified())
+	b = marshalCached(t, ps, req)
+	requireUnmodified(t, req)
+
+	unmarshalled, err := lazy.UnmarshalExportRequest(b, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	assert.True(t, unmarshalled.Equal(req))
+	assert.Equal(t, "modified", unmarshalled.Resources().At(1).Attributes().At(0).Key())
+	unmarshalled.Free()
+	req.Free()
+}
+
+func TestCacheBytesPartiallyModified(t *testing.T) {
+	src := newExportRequest("a", "b")
+	req, err := lazy.UnmarshalExportRequest(marshalLazy(t, src), lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	src.Free()
+
+	// Only the request and the second resource are modified.
+	req.Resources().At(1).SetMinSeverity(0)
+	req.SetName("cached")
+	expected := marshalLazy(t, req)
+
+	ps := molecule.NewProtoStream()
+	assert.EqualValues(t, expected, marshalCached(t, ps, req))
+	requireUnmodified(t, req)
+	assert.EqualValues(t, expected, marshalLazy(t, req))
+	assert.Equal(t, "cached", req.Name())
+	req.Free()
+}
+
+func TestCacheBytesReset(t *testing.T) {
+	req := newExportRequest("a")
+
+	// Reset discards the messages remembered by the stream, e.g. after a failed
+	// Marshal, so the messages remain modified.
+	ps := molecule.NewProtoStream()
+	ps.SetCacheBytes(true)
+	require.NoError(t, req.Marshal(ps))
+	ps.Reset()
+	ps.DetachBuffer()
+	assert.True(t, req.IsModified())
+
+	// Caching is opt-in.
+	ps.SetCacheBytes(false)
+	require.NoError(t, req.Marshal(ps))
+	ps.DetachBuffer()
+	assert.True(t, req.IsModified())
+	req.Free()
+}
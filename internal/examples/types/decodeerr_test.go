@@ -0,0 +1,127 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+// knownFieldsCorruptNested is KnownFields with name "a" and the nested message
+// that contains a truncated varint.
+var knownFieldsCorruptNested = []byte{0x0a, 0x01, 'a', 0x12, 0x02, 0x08, 0x80}
+
+func TestDecodeErr(t *testing.T) {
+	_, err := lazy.UnmarshalKnownFields(
+		knownFieldsCorruptNested, lazyproto.UnmarshalOpts{WithValidate: true},
+	)
+	require.Error(t, err)
+
+	// Without validation the nested message is decoded lazily when accessed.
+	m, err := lazy.UnmarshalKnownFields(knownFieldsCorruptNested, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	assert.EqualValues(t, "a", m.Name())
+	assert.NoError(t, m.DecodeErr())
+
+	nested, err := m.NestedE()
+	assert.Error(t, err)
+	require.NotNil(t, nested)
+	assert.EqualValues(t, 0, nested.Value())
+	assert.Equal(t, err, nested.DecodeErr())
+
+	// The error is propagated to the root and is returned again by the getter.
+	assert.Equal(t, err, m.DecodeErr())
+	_, err2 := m.NestedE()
+	assert.Equal(t, err, err2)
+
+	// The clone has the same error.
+	c := m.Clone()
+	assert.Equal(t, err, c.DecodeErr())
+	assert.Equal(t, err, c.Nested().DecodeErr())
+	c.Free()
+
+	// The error is cleared when the message is unmarshalled again.
+	b := wireBytesFromText(t, "unknown.proto", "types.KnownFields", `nested: {value: 1}`)
+	require.NoError(t, m.Unmarshal(b, lazyproto.UnmarshalOpts{}))
+	nested, err = m.NestedE()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, nested.Value())
+	assert.NoError(t, m.DecodeErr())
+	m.Free()
+}
+
+func TestDecodeErrRepeated(t *testing.T) {
+	// Record with a valid attribute and an attribute with a truncated key string.
+	b := []byte{0x12, 0x03, 0x0a, 0x01, 'b', 0x12, 0x03, 0x0a, 0x05, 'a'}
+	m, err := lazy.UnmarshalRecord(b, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	attrs, err := m.AttributesE()
+	assert.Error(t, err)
+	require.EqualValues(t, 2, attrs.Len())
+	assert.EqualValues(t, "b", attrs.At(0).Key())
+	assert.NoError(t, attrs.At(0).DecodeErr())
+	assert.Equal(t, err, attrs.At(1).DecodeErr())
+	assert.Equal(t, err, m.DecodeErr())
+
+	// The fields that decode successfully don't return the error.
+	resource, err := m.ResourceE()
+	assert.NoError(t, err)
+	assert.Nil(t, resource)
+	m.Free()
+}
+
+func TestDecodeErrMap(t *testing.T) {
+	// Maps with an int32_to_message entry that contains a truncated varint key.
+	m, err := lazy.UnmarshalMaps([]byte{0x12, 0x02, 0x08, 0x80}, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	assert.NoError(t, m.DecodeErr())
+
+	assert.EqualValues(t, 0, m.Int32ToMessageLen())
+	assert.Error(t, m.DecodeErr())
+	m.Free()
+}
+
+func TestDecodeErrMapE(t *testing.T) {
+	// Maps with a valid string_to_string entry "a": "b" and an int32_to_message
+	// entry that contains a truncated varint key.
+	b := []byte{0x0a, 0x06, 0x0a, 0x01, 'a', 0x12, 0x01, 'b', 0x12, 0x02, 0x08, 0x80}
+	m, err := lazy.UnmarshalMaps(b, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	_, ok, err := m.Int32ToMessageGetE(1)
+	assert.False(t, ok)
+	assert.Error(t, err)
+	assert.Equal(t, err, m.Int32ToMessageRangeE(func(k int32, v *lazy.MapValue) bool { return true }))
+	assert.Equal(t, err, m.DecodeErr())
+
+	// The error of another map is not returned.
+	v, ok, err := m.StringToStringGetE("a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, "b", v)
+	assert.NoError(t, m.StringToStringRangeE(func(k, v string) bool { return true }))
+	m.Free()
+
+	// The int32_to_message entry 7 with the value that contains a counts entry with
+	// a truncated varint value. The value is decoded lazily.
+	b = []byte{0x12, 0x08, 0x08, 0x07, 0x12, 0x04, 0x12, 0x02, 0x10, 0x80}
+	m, err = lazy.UnmarshalMaps(b, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	value, ok, err := m.Int32ToMessageGetE(7)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.NoError(t, m.Int32ToMessageRangeE(func(k int32, v *lazy.MapValue) bool { return true }))
+
+	_, _, err = value.CountsGetE("x")
+	assert.Error(t, err)
+	_, _, err2 := m.Int32ToMessageGetE(7)
+	assert.Equal(t, err, err2)
+	assert.Equal(t, err, m.Int32ToMessageRangeE(func(k int32, v *lazy.MapValue) bool { return true }))
+	assert.NoError(t, m.StringToStringRangeE(func(k, v string) bool { return true }))
+	m.Free()
+}
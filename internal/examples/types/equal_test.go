@@ -0,0 +1,240 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+// wireBytesFromText returns the concatenation of the wire bytes of the messages
+// specified in text format. Concatenation allows to control the order of the fields.
+func wireBytesFromText(t *testing.T, protoFile string, msgName string, texts ...string) []byte {
+	var r []byte
+	for _, text := range texts {
+		b, err := proto.Marshal(googleMessage(t, protoFile, msgName, text))
+		require.NoError(t, err)
+		r = append(r, b...)
+	}
+	return r
+}
+
+// requireEqualConsistent checks that Equal() of lazy messages returns the same
+// result as proto.Equal() of Google messages unmarshalled from the same bytes.
+func requireEqualConsistent(
+	t *testing.T, protoFile string, msgName string, b1, b2 []byte,
+	unmarshal func(b []byte, opts lazyproto.UnmarshalOpts) (interface{ Free() }, error),
+	equal func(m1, m2 interface{}) bool,
+) {
+	g1 := googleMessage(t, protoFile, msgName, "")
+	require.NoError(t, proto.Unmarshal(b1, g1))
+	g2 := g1.ProtoReflect().New().Interface()
+	require.NoError(t, proto.Unmarshal(b2, g2))
+	expected := proto.Equal(g1, g2)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m1, err := unmarshal(b1, opts)
+			require.NoError(t, err)
+			m2, err := unmarshal(b2, opts)
+			require.NoError(t, err)
+			assert.EqualValues(t, expected, equal(m1, m2))
+			assert.EqualValues(t, expected, equal(m2, m1))
+			m1.Free()
+			m2.Free()
+		},
+	)
+}
+
+func TestScalarsEqual(t *testing.T) {
+	unmarshal := func(b []byte, opts lazyproto.UnmarshalOpts) (interface{ Free() }, error) {
+		return lazy.UnmarshalScalars(b, opts)
+	}
+	equal := func(m1, m2 interface{}) bool {
+		return m1.(*lazy.Scalars).Equal(m2.(*lazy.Scalars))
+	}
+	wireBytes := func(texts ...string) []byte {
+		return wireBytesFromText(t, "scalars.proto", "types.Scalars", texts...)
+	}
+
+	tests := []struct {
+		name   string
+		b1, b2 []byte
+	}{
+		{"same", wireBytes(scalarsText), wireBytes(scalarsText)},
+		{"empty", wireBytes(""), wireBytes("")},
+		{"different", wireBytes(`int32_value: 1`), wireBytes(`int32_value: 2`)},
+		{"different field", wireBytes(`int32_value: 1`), wireBytes(`int64_value: 1`)},
+		{
+			"different order", wireBytes(`int32_value: 1`, `string_value: "a"`),
+			wireBytes(`string_value: "a"`, `int32_value: 1`),
+		},
+		{
+			// Explicitly encoded default value is equal to absent value.
+			"explicit default", []byte{0x18, 0x00}, wireBytes(""),
+		},
+		{"nan", wireBytes(`double_value: nan`), wireBytes(`double_value: nan`)},
+		{"float nan", wireBytes(`float_value: nan`), wireBytes(`float_value: 1`)},
+		{"bytes", wireBytes(`bytes_value: "a"`), wireBytes(`bytes_value: "b"`)},
+	}
+
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				requireEqualConsistent(t, "scalars.proto", "types.Scalars", test.b1, test.b2, unmarshal, equal)
+			},
+		)
+	}
+}
+
+func TestRepeatedAndOneOfEqual(t *testing.T) {
+	unmarshalRepeated := func(b []byte, opts lazyproto.UnmarshalOpts) (interface{ Free() }, error) {
+		return lazy.UnmarshalRepeatedScalars(b, opts)
+	}
+	equalRepeated := func(m1, m2 interface{}) bool {
+		return m1.(*lazy.RepeatedScalars).Equal(m2.(*lazy.RepeatedScalars))
+	}
+	repeated := func(texts ...string) []byte {
+		return wireBytesFromText(t, "scalars.proto", "types.RepeatedScalars", texts...)
+	}
+	requireEqualConsistent(
+		t, "scalars.proto", "types.RepeatedScalars",
+		repeated(`int32_values: [1, 2]`), repeated(`int32_values: [1]`, `int32_values: [2]`),
+		unmarshalRepeated, equalRepeated,
+	)
+	requireEqualConsistent(
+		t, "scalars.proto", "types.RepeatedScalars",
+		repeated(`int32_values: [1, 2]`), repeated(`int32_values: [2, 1]`),
+		unmarshalRepeated, equalRepeated,
+	)
+
+	unmarshalOneOf := func(b []byte, opts lazyproto.UnmarshalOpts) (interface{ Free() }, error) {
+		return lazy.UnmarshalOneOfScalars(b, opts)
+	}
+	equalOneOf := func(m1, m2 interface{}) bool {
+		return m1.(*lazy.OneOfScalars).Equal(m2.(*lazy.OneOfScalars))
+	}
+	oneOf := func(texts ...string) []byte {
+		return wireBytesFromText(t, "scalars.proto", "types.OneOfScalars", texts...)
+	}
+	requireEqualConsistent(
+		t, "scalars.proto", "types.OneOfScalars",
+		oneOf(`int32_value: 0`), oneOf(``), unmarshalOneOf, equalOneOf,
+	)
+	requireEqualConsistent(
+		t, "scalars.proto", "types.OneOfScalars",
+		oneOf(`int32_value: 1`), oneOf(`int64_value: 1`), unmarshalOneOf, equalOneOf,
+	)
+	requireEqualConsistent(
+		t, "scalars.proto", "types.OneOfScalars",
+		oneOf(`int64_value: 1`, `bool_value: true`), oneOf(`bool_value: true`),
+		unmarshalOneOf, equalOneOf,
+	)
+}
+
+func TestMapsEqual(t *testing.T) {
+	unmarshal := func(b []byte, opts lazyproto.UnmarshalOpts) (interface{ Free() }, error) {
+		return lazy.UnmarshalMaps(b, opts)
+	}
+	equal := func(m1, m2 interface{}) bool {
+		return m1.(*lazy.Maps).Equal(m2.(*lazy.Maps))
+	}
+	wireBytes := func(texts ...string) []byte {
+		return wireBytesFromText(t, "maps.proto", "types.Maps", texts...)
+	}
+
+	tests := []struct {
+		name   string
+		b1, b2 []byte
+	}{
+		{"same", wireBytes(mapsText), wireBytes(mapsText)},
+		{
+			"different order",
+			wireBytes(`string_to_string: {key: "a" value: "x"}`, `string_to_string: {key: "b" value: "y"}`),
+			wireBytes(`string_to_string: {key: "b" value: "y"}`, `string_to_string: {key: "a" value: "x"}`),
+		},
+		{
+			"different value",
+			wireBytes(`int32_to_message: {key: 1 value: {value: "a"}}`),
+			wireBytes(`int32_to_message: {key: 1 value: {value: "b"}}`),
+		},
+		{
+			"different key",
+			wireBytes(`string_to_enum: {key: "a" value: MAP_ENUM_ONE}`),
+			wireBytes(`string_to_enum: {key: "b" value: MAP_ENUM_ONE}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				requireEqualConsistent(t, "maps.proto", "types.Maps", test.b1, test.b2, unmarshal, equal)
+			},
+		)
+	}
+}
+
+func TestEqualModified(t *testing.T) {
+	wireBytes := wireBytesFromText(t, "maps.proto", "types.Maps", mapsText)
+
+	m1, err := lazy.UnmarshalMaps(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	m2, err := lazy.UnmarshalMaps(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	assert.True(t, m1.Equal(m2))
+
+	// Modified message with the same content is equal.
+	m1.SetName(m1.Name())
+	assert.True(t, m1.Equal(m2))
+
+	// Modified nested message.
+	v, ok := m1.Int32ToMessageGet(-1)
+	require.True(t, ok)
+	v.CountsSet("c", 4)
+	assert.False(t, m1.Equal(m2))
+	assert.False(t, m2.Equal(m1))
+
+	v.CountsSet("c", 3)
+	assert.True(t, m1.Equal(m2))
+
+	// Clone is equal to the source.
+	c := m1.Clone()
+	assert.True(t, c.Equal(m1))
+
+	assert.True(t, m1.Equal(m1))
+	assert.False(t, m1.Equal(nil))
+	var nilMaps *lazy.Maps
+	assert.True(t, nilMaps.Equal(nil))
+
+	c.Free()
+	m1.Free()
+	m2.Free()
+}
+
+func TestUnknownFieldsEqual(t *testing.T) {
+	unmarshal := func(b []byte, opts lazyproto.UnmarshalOpts) (interface{ Free() }, error) {
+		return lazy.UnmarshalKnownFields(b, opts)
+	}
+	equal := func(m1, m2 interface{}) bool {
+		return m1.(*lazy.KnownFields).Equal(m2.(*lazy.KnownFields))
+	}
+	wireBytes := func(texts ...string) []byte {
+		return wireBytesFromText(t, "unknown.proto", "types.KnownFieldsV2", texts...)
+	}
+	// Compare as KnownFields, so that the fields added in V2 are unknown.
+	requireEqualConsistent(
+		t, "unknown.proto", "types.KnownFields",
+		wireBytes(`name: "a"`, `added_int: 1`), wireBytes(`name: "a"`, `added_int: 2`),
+		unmarshal, equal,
+	)
+	requireEqualConsistent(
+		t, "unknown.proto", "types.KnownFields",
+		wireBytes(`name: "a"`, `added_int: 1`), wireBytes(`added_int: 1`, `name: "a"`),
+		unmarshal, equal,
+	)
+}
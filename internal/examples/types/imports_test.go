@@ -0,0 +1,157 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy/common"
+)
+
+const recordText = `
+resource: {
+  attributes: {key: "service" value: "frontend"}
+  attributes: {key: "host" value: "h1"}
+  min_severity: SEVERITY_INFO
+}
+attributes: {key: "a" value: "1"}
+severity: SEVERITY_ERROR
+attribute_map: {key: "x" value: {key: "k" value: "v"}}
+attribute_body: {key: "body" value: "text"}
+attribute: {key: "single" value: "s"}
+`
+
+func TestImportsGet(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalRecord(wireBytes, opts)
+			require.NoError(t, err)
+
+			res := m.Resource()
+			require.EqualValues(t, 2, res.Attributes().Len())
+			assert.EqualValues(t, "service", res.Attributes().At(0).Key())
+			assert.EqualValues(t, "h1", res.Attributes().At(1).Value())
+			assert.EqualValues(t, common.Severity_SEVERITY_INFO, res.MinSeverity())
+
+			require.EqualValues(t, 1, m.Attributes().Len())
+			assert.EqualValues(t, "a", m.Attributes().At(0).Key())
+			assert.EqualValues(t, common.Severity_SEVERITY_ERROR, m.Severity())
+
+			v, ok := m.AttributeMapGet("x")
+			require.True(t, ok)
+			assert.EqualValues(t, "v", v.Value())
+
+			assert.EqualValues(t, lazy.RecordAttributeBody, m.BodyType())
+			assert.EqualValues(t, "text", m.AttributeBody().Value())
+			assert.EqualValues(t, "s", m.Attribute().Value())
+
+			// Unmodified message is marshalled as is.
+			assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestImportsModify(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalRecord(wireBytes, opts)
+			require.NoError(t, err)
+
+			// Modifications of the messages from other packages must mark the
+			// parent message modified.
+			m.Resource().Attributes().At(1).SetValue("h2")
+			m.Resource().SetMinSeverity(common.Severity_SEVERITY_ERROR)
+			m.Attributes().AppendNew().SetKey("b")
+			v, _ := m.AttributeMapGet("x")
+			v.SetValue("w")
+			m.SetAttribute(m.AttributeBody().Clone())
+			m.SetSeverity(common.Severity_SEVERITY_UNSPECIFIED)
+
+			expected := googleMessage(
+				t, "imports.proto", "types.Record", `
+resource: {
+  attributes: {key: "service" value: "frontend"}
+  attributes: {key: "host" value: "h2"}
+  min_severity: SEVERITY_ERROR
+}
+attributes: {key: "a" value: "1"}
+attributes: {key: "b"}
+attribute_map: {key: "x" value: {key: "k" value: "w"}}
+attribute_body: {key: "body" value: "text"}
+attribute: {key: "body" value: "text"}
+`,
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestImportsCloneEqual(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	c := m.Clone()
+	assert.True(t, m.Equal(c))
+
+	c.Resource().Attributes().At(0).SetKey("changed")
+	assert.False(t, m.Equal(c))
+	assert.EqualValues(t, "service", m.Resource().Attributes().At(0).Key())
+
+	m.Free()
+	c.Free()
+}
+
+// fieldOffsets returns the offset of the key of the first field with the number num
+// in the wire bytes b and the offset of the field's value.
+func fieldOffsets(t *testing.T, b []byte, num protowire.Number) (keyOfs int, valueOfs int) {
+	for ofs := 0; ofs < len(b); {
+		n, typ, keyLen := protowire.ConsumeTag(b[ofs:])
+		require.GreaterOrEqual(t, keyLen, 0)
+		if n == num {
+			return ofs, ofs + keyLen
+		}
+		valueLen := protowire.ConsumeFieldValue(n, typ, b[ofs+keyLen:])
+		require.GreaterOrEqual(t, valueLen, 0)
+		ofs += keyLen + valueLen
+	}
+	require.Failf(t, "field not found", "field number %d", num)
+	return 0, 0
+}
+
+func TestImportsValidate(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	// Find the Attribute message in the "attribute" field and replace the key of
+	// its "value" field by a key with an invalid wire type.
+	_, attrOfs := fieldOffsets(t, wireBytes, 7)
+	attr, prefixLen := protowire.ConsumeBytes(wireBytes[attrOfs:])
+	require.GreaterOrEqual(t, prefixLen, 0)
+	attrOfs += prefixLen - len(attr)
+	valueKeyOfs, _ := fieldOffsets(t, attr, 2)
+	require.EqualValues(t, 0x12, wireBytes[attrOfs+valueKeyOfs])
+	wireBytes[attrOfs+valueKeyOfs] = 0x17
+
+	_, err = lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{WithValidate: true})
+	assert.Error(t, err)
+}
@@ -0,0 +1,275 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/jsonstream"
+)
+
+// formatTestCase describes a message which JSON and text format representations
+// are compared to the ones produced by protojson and prototext.
+type formatTestCase struct {
+	name      string
+	protoFile string
+	msgName   string
+	text      string
+	unmarshal func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error)
+	new       func() lazyproto.Message
+}
+
+var formatTestCases = []formatTestCase{
+	{
+		name:      "scalars",
+		protoFile: "scalars.proto",
+		msgName:   "types.Scalars",
+		text:      scalarsText,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalScalars(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewScalars() },
+	},
+	{
+		name:      "scalars limits",
+		protoFile: "scalars.proto",
+		msgName:   "types.Scalars",
+		text: `
+double_value: -inf
+float_value: nan
+int64_value: -9223372036854775808
+uint64_value: 18446744073709551615
+string_value: "\"quoted\"\n\té <>&\x01"
+bytes_value: "\x00\xff\xfe"
+`,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalScalars(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewScalars() },
+	},
+	{
+		name:      "floats",
+		protoFile: "scalars.proto",
+		msgName:   "types.Scalars",
+		text:      `double_value: 1e-7 float_value: 1e21`,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalScalars(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewScalars() },
+	},
+	{
+		name:      "repeated scalars",
+		protoFile: "scalars.proto",
+		msgName:   "types.RepeatedScalars",
+		text:      repeatedScalarsText,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalRepeatedScalars(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewRepeatedScalars() },
+	},
+	{
+		name:      "oneof",
+		protoFile: "scalars.proto",
+		msgName:   "types.OneOfScalars",
+		text:      `sint64_value: 0`,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalOneOfScalars(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewOneOfScalars() },
+	},
+	{
+		name:      "maps",
+		protoFile: "maps.proto",
+		msgName:   "types.Maps",
+		text:      mapsText,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalMaps(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewMaps() },
+	},
+	{
+		name:      "optional",
+		protoFile: "optional.proto",
+		msgName:   "types.Optional",
+		text:      optionalZeroText,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalOptional(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewOptional() },
+	},
+	{
+		name:      "proto2",
+		protoFile: "proto2.proto",
+		msgName:   "types.Proto2Message",
+		text:      proto2Text,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalProto2Message(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewProto2Message() },
+	},
+	{
+		name:      "imports",
+		protoFile: "imports.proto",
+		msgName:   "types.Record",
+		text:      recordText,
+		unmarshal: func(b []byte, opts lazyproto.UnmarshalOpts) (lazyproto.Message, error) {
+			return lazy.UnmarshalRecord(b, opts)
+		},
+		new: func() lazyproto.Message { return lazy.NewRecord() },
+	},
+}
+
+// googleJSON returns the compacted JSON produced by protojson for the message.
+func googleJSON(t *testing.T, src proto.Message) []byte {
+	b, err := protojson.Marshal(src)
+	require.NoError(t, err)
+	// protojson randomly inserts whitespace to discourage byte comparisons.
+	var buf bytes.Buffer
+	require.NoError(t, json.Compact(&buf, b))
+	return buf.Bytes()
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+				expected := googleJSON(t, src)
+
+				forEachUnmarshalOpts(
+					t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+						m, err := test.unmarshal(wireBytes, opts)
+						require.NoError(t, err)
+
+						b, err := m.MarshalJSON()
+						require.NoError(t, err)
+						assert.Equal(t, string(expected), string(b))
+
+						// JSON marshalling must not modify the message.
+						assert.False(t, m.IsModified())
+						assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+						m.Free()
+					},
+				)
+			},
+		)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				expected := googleJSON(t, src)
+
+				m := test.new()
+				require.NoError(t, m.UnmarshalJSON(expected))
+				assert.True(t, m.IsModified())
+				requireEqualGoogle(t, src, marshalLazy(t, m))
+
+				// The JSON produced by the unmarshalled message is the same.
+				b, err := m.MarshalJSON()
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), string(b))
+				m.Free()
+			},
+		)
+	}
+}
+
+func TestMarshalJSONPartiallyDecoded(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+	expected := googleJSON(t, src)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	// Decode some of the nested messages and the map, leave the rest undecoded.
+	assert.EqualValues(t, "h1", m.Resource().Attributes().At(1).Value())
+	_, ok := m.AttributeMapGet("x")
+	assert.True(t, ok)
+
+	b, err := m.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+
+	// Modified fields are reflected in the JSON.
+	m.Resource().Attributes().At(1).SetValue("h2")
+	b, err = m.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `{"key":"host","value":"h2"}`)
+	m.Free()
+}
+
+func TestUnmarshalJSONProtoNames(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	b, err := protojson.MarshalOptions{UseProtoNames: true, UseEnumNumbers: true}.Marshal(src)
+	require.NoError(t, err)
+
+	m := lazy.NewRecord()
+	require.NoError(t, m.UnmarshalJSON(b))
+	requireEqualGoogle(t, src, marshalLazy(t, m))
+	m.Free()
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "unknown field", json: `{"foo":1}`},
+		{name: "wrong type", json: `{"int32Value":"abc"}`},
+		{name: "int32 out of range", json: `{"int32Value":2147483648}`},
+		{name: "fractional int", json: `{"int32Value":1.5}`},
+		{name: "bad base64", json: `{"bytesValue":"!"}`},
+		{name: "trailing data", json: `{}{}`},
+		{name: "unterminated", json: `{"int32Value":1`},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				m := lazy.NewScalars()
+				assert.Error(t, m.UnmarshalJSON([]byte(test.json)))
+				m.Free()
+			},
+		)
+	}
+
+	m := lazy.NewOneOfScalars()
+	assert.Error(t, m.UnmarshalJSON([]byte(`{"int32Value":1,"boolValue":true}`)))
+	m.Free()
+
+	p := lazy.NewProto2Message()
+	assert.Error(t, p.UnmarshalJSON([]byte(`{}`)))
+	p.Free()
+}
+
+func TestMarshalJSONStream(t *testing.T) {
+	src := googleMessage(t, "maps.proto", "types.Maps", mapsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalMaps(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	js := jsonstream.NewWriter(&buf)
+	require.NoError(t, m.MarshalJSONStream(js))
+	require.NoError(t, js.Flush())
+	assert.Equal(t, string(googleJSON(t, src)), buf.String())
+	m.Free()
+}
@@ -0,0 +1,238 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+const mapsText = `
+string_to_string: {key: "a" value: "x"}
+string_to_string: {key: "b" value: "y"}
+string_to_string: {key: "" value: ""}
+int32_to_message: {key: -1 value: {value: "minus one" counts: {key: "c" value: 3}}}
+int32_to_message: {key: 2 value: {}}
+string_to_enum: {key: "one" value: MAP_ENUM_ONE}
+string_to_enum: {key: "two" value: MAP_ENUM_TWO}
+sint64_to_double: {key: -64 value: 6.4}
+bool_to_bytes: {key: true value: "yes"}
+bool_to_bytes: {key: false value: "no"}
+uint64_to_fixed32: {key: 18000000000000000000 value: 32}
+name: "maps"
+`
+
+func unmarshalMaps(t *testing.T, wireBytes []byte, opts lazyproto.UnmarshalOpts) *lazy.Maps {
+	m, err := lazy.UnmarshalMaps(wireBytes, opts)
+	require.NoError(t, err)
+	return m
+}
+
+func TestMapsGet(t *testing.T) {
+	src := googleMessage(t, "maps.proto", "types.Maps", mapsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m := unmarshalMaps(t, wireBytes, opts)
+
+			assert.EqualValues(t, 3, m.StringToStringLen())
+			v, ok := m.StringToStringGet("a")
+			assert.True(t, ok)
+			assert.EqualValues(t, "x", v)
+			v, ok = m.StringToStringGet("")
+			assert.True(t, ok)
+			assert.EqualValues(t, "", v)
+			_, ok = m.StringToStringGet("z")
+			assert.False(t, ok)
+
+			assert.EqualValues(t, 2, m.Int32ToMessageLen())
+			mv, ok := m.Int32ToMessageGet(-1)
+			require.True(t, ok)
+			assert.EqualValues(t, "minus one", mv.Value())
+			count, ok := mv.CountsGet("c")
+			assert.True(t, ok)
+			assert.EqualValues(t, 3, count)
+			mv, ok = m.Int32ToMessageGet(2)
+			require.True(t, ok)
+			assert.EqualValues(t, "", mv.Value())
+			assert.EqualValues(t, 0, mv.CountsLen())
+
+			e, ok := m.StringToEnumGet("two")
+			assert.True(t, ok)
+			assert.EqualValues(t, lazy.MapEnum_MAP_ENUM_TWO, e)
+
+			d, ok := m.Sint64ToDoubleGet(-64)
+			assert.True(t, ok)
+			assert.EqualValues(t, 6.4, d)
+
+			b, ok := m.BoolToBytesGet(false)
+			assert.True(t, ok)
+			assert.EqualValues(t, []byte("no"), b)
+
+			f, ok := m.Uint64ToFixed32Get(18000000000000000000)
+			assert.True(t, ok)
+			assert.EqualValues(t, 32, f)
+
+			assert.EqualValues(t, "maps", m.Name())
+
+			keys := map[string]string{}
+			m.StringToStringRange(
+				func(k string, v string) bool {
+					keys[k] = v
+					return true
+				},
+			)
+			assert.EqualValues(t, map[string]string{"a": "x", "b": "y", "": ""}, keys)
+
+			// Range stops when false is returned.
+			calls := 0
+			m.StringToStringRange(
+				func(k string, v string) bool {
+					calls++
+					return false
+				},
+			)
+			assert.EqualValues(t, 1, calls)
+
+			// Unmodified message is marshalled as is.
+			assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestMapsUndecodedMarshal(t *testing.T) {
+	src := googleMessage(t, "maps.proto", "types.Maps", mapsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m := unmarshalMaps(t, wireBytes, opts)
+
+			// Modify the message without touching any of the maps. The maps
+			// must be marshalled from the original bytes.
+			m.SetName("changed")
+
+			expected := googleMessage(
+				t, "maps.proto", "types.Maps",
+				strings.Replace(mapsText, `name: "maps"`, `name: "changed"`, 1),
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestMapsModify(t *testing.T) {
+	src := googleMessage(t, "maps.proto", "types.Maps", mapsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	valueSrc := googleMessage(
+		t, "maps.proto", "types.MapValue", `value: "new" counts: {key: "d" value: -4}`,
+	)
+	valueBytes, err := proto.Marshal(valueSrc)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m := unmarshalMaps(t, wireBytes, opts)
+
+			m.StringToStringSet("a", "xx")
+			m.StringToStringSet("c", "z")
+			m.StringToStringDelete("b")
+			m.StringToStringDelete("not found")
+
+			newValue, err := lazy.UnmarshalMapValue(valueBytes, opts)
+			require.NoError(t, err)
+			m.Int32ToMessageSet(2, newValue)
+			m.Int32ToMessageDelete(-1)
+
+			// Modify a map of the nested value in place.
+			mv, ok := m.Int32ToMessageGet(2)
+			require.True(t, ok)
+			mv.CountsSet("e", 5)
+
+			m.StringToEnumSet("one", lazy.MapEnum_MAP_ENUM_TWO)
+			m.Sint64ToDoubleDelete(-64)
+			m.BoolToBytesSet(true, []byte("si"))
+			m.Uint64ToFixed32Set(1, 1)
+
+			expected := googleMessage(
+				t, "maps.proto", "types.Maps", `
+string_to_string: {key: "a" value: "xx"}
+string_to_string: {key: "c" value: "z"}
+string_to_string: {key: "" value: ""}
+int32_to_message: {key: 2 value: {value: "new" counts: {key: "d" value: -4} counts: {key: "e" value: 5}}}
+string_to_enum: {key: "one" value: MAP_ENUM_TWO}
+string_to_enum: {key: "two" value: MAP_ENUM_TWO}
+bool_to_bytes: {key: true value: "si"}
+bool_to_bytes: {key: false value: "no"}
+uint64_to_fixed32: {key: 18000000000000000000 value: 32}
+uint64_to_fixed32: {key: 1 value: 1}
+name: "maps"
+`,
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestMapsInvalid(t *testing.T) {
+	// Field 1 (string_to_string) entry that contains key with invalid wire type.
+	wireBytes := []byte{0x0A, 0x02, 0x08, 0x01}
+
+	_, err := lazy.UnmarshalMaps(wireBytes, lazyproto.UnmarshalOpts{WithValidate: true})
+	assert.Error(t, err)
+
+	// Without validation the error is not detected until the map is accessed
+	// and the invalid entry is skipped.
+	m, err := lazy.UnmarshalMaps(wireBytes, lazyproto.UnmarshalOpts{WithValidate: false})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, m.StringToStringLen())
+	m.Free()
+}
+
+func TestMapsClone(t *testing.T) {
+	src := googleMessage(t, "maps.proto", "types.Maps", mapsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m := unmarshalMaps(t, wireBytes, lazyproto.UnmarshalOpts{})
+
+	// Clone with undecoded maps.
+	clone := m.Clone()
+	assert.EqualValues(t, 3, clone.StringToStringLen())
+	clone.StringToStringSet("a", "changed")
+	clone.Free()
+
+	// Clone with decoded and modified maps.
+	m.StringToStringSet("c", "z")
+	clone = m.Clone()
+	mv, ok := clone.Int32ToMessageGet(-1)
+	require.True(t, ok)
+	mv.SetValue("changed")
+
+	// The source is not affected by the changes of the clone.
+	mv, ok = m.Int32ToMessageGet(-1)
+	require.True(t, ok)
+	assert.EqualValues(t, "minus one", mv.Value())
+	v, ok := m.StringToStringGet("a")
+	assert.True(t, ok)
+	assert.EqualValues(t, "x", v)
+
+	m.Free()
+	v, ok = clone.StringToStringGet("c")
+	assert.True(t, ok)
+	assert.EqualValues(t, "z", v)
+	clone.Free()
+}
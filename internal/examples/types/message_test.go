@@ -0,0 +1,91 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+func TestMessageInterface(t *testing.T) {
+	tests := []struct {
+		protoFile string
+		msgName   string
+		text      string
+		msg       lazyproto.Message
+	}{
+		{"scalars.proto", "types.Scalars", `int32_value: -1 string_value: "abc"`, lazy.NewScalars()},
+		{"maps.proto", "types.Maps", mapsText, lazy.NewMaps()},
+		{"proto2.proto", "types.Proto2Message", proto2Text, lazy.NewProto2Message()},
+		{"optional.proto", "types.Optional", optionalZeroText, lazy.NewOptional()},
+		{"imports.proto", "types.Record", recordText, lazy.NewRecord()},
+	}
+
+	for _, test := range tests {
+		t.Run(
+			test.msgName, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+
+				m := test.msg
+				assert.True(t, m.IsModified())
+
+				require.NoError(t, m.Unmarshal(wireBytes, lazyproto.UnmarshalOpts{WithValidate: true}))
+				assert.False(t, m.IsModified())
+				assert.Empty(t, m.UnknownFields())
+				assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+
+				c := m.CloneMessage()
+				assert.IsType(t, m, c)
+				assert.EqualValues(t, wireBytes, marshalLazy(t, c))
+
+				// Unmarshal into a message that already has content replaces it.
+				require.NoError(t, c.Unmarshal(nil, lazyproto.UnmarshalOpts{}))
+				assert.Empty(t, marshalLazy(t, c))
+
+				m.Free()
+				c.Free()
+			},
+		)
+	}
+}
+
+func TestUnmarshalNested(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	attrBytes, err := proto.Marshal(
+		googleMessage(t, "common.proto", "types.common.Attribute", `key: "new"`),
+	)
+	require.NoError(t, err)
+
+	// Unmarshalling into a nested message marks the parent modified.
+	attr := m.Attribute()
+	require.NoError(t, attr.Unmarshal(attrBytes, lazyproto.UnmarshalOpts{WithValidate: true}))
+	assert.True(t, m.IsModified())
+	assert.False(t, attr.IsModified())
+	assert.EqualValues(t, "new", attr.Key())
+	assert.EqualValues(t, "", attr.Value())
+
+	// Invalid bytes fail the validation and leave the message intact.
+	assert.Error(t, attr.Unmarshal([]byte{0xFF}, lazyproto.UnmarshalOpts{WithValidate: true}))
+	assert.EqualValues(t, "new", attr.Key())
+
+	expected := googleMessage(
+		t, "imports.proto", "types.Record", strings.Replace(
+			recordText, `attribute: {key: "single" value: "s"}`, `attribute: {key: "new"}`, 1,
+		),
+	)
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+	m.Free()
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy/common"
+	"github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy/resource"
+)
+
+func newAttribute(key, value string) *common.Attribute {
+	a := common.NewAttribute()
+	a.SetKey(key)
+	a.SetValue(value)
+	return a
+}
+
+func TestNewMessages(t *testing.T) {
+	res := resource.NewResource()
+	attrs := common.NewAttributeSlice(2)
+	attrs[0].SetKey("service")
+	attrs[0].SetValue("frontend")
+	attrs[1].SetKey("host")
+	res.SetAttributes(attrs)
+
+	m := lazy.NewRecord()
+	m.SetResource(res)
+	m.SetSeverity(common.Severity_SEVERITY_INFO)
+	m.Attributes().Append(newAttribute("a", "1"))
+	m.AttributeMapSet("x", newAttribute("k", "v"))
+	m.SetAttributeBody(newAttribute("body", "text"))
+	m.SetAttribute(common.NewAttribute())
+
+	expected := googleMessage(
+		t, "imports.proto", "types.Record", `
+resource: {
+  attributes: {key: "service" value: "frontend"}
+  attributes: {key: "host"}
+}
+attributes: {key: "a" value: "1"}
+severity: SEVERITY_INFO
+attribute_map: {key: "x" value: {key: "k" value: "v"}}
+attribute_body: {key: "body" value: "text"}
+attribute: {}
+`,
+	)
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+
+	// Getters return the values that were set and do not decode them again.
+	assert.Same(t, res, m.Resource())
+	assert.EqualValues(t, "frontend", m.Resource().Attributes().At(0).Value())
+
+	// Modifications of the nested messages are tracked via the parent pointers.
+	attrs[1].SetValue("h1")
+	m.Attribute().SetKey("single")
+
+	expected = googleMessage(
+		t, "imports.proto", "types.Record", `
+resource: {
+  attributes: {key: "service" value: "frontend"}
+  attributes: {key: "host" value: "h1"}
+}
+attributes: {key: "a" value: "1"}
+severity: SEVERITY_INFO
+attribute_map: {key: "x" value: {key: "k" value: "v"}}
+attribute_body: {key: "body" value: "text"}
+attribute: {key: "single"}
+`,
+	)
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+	m.Free()
+
+	// Messages taken from the pool after Free() are empty.
+	for i := 0; i < 10; i++ {
+		m = lazy.NewRecord()
+		assert.Nil(t, m.Resource())
+		assert.EqualValues(t, 0, m.Attributes().Len())
+		assert.EqualValues(t, 0, m.AttributeMapLen())
+		assert.Empty(t, marshalLazy(t, m))
+	}
+}
+
+func TestNewMessageInUnmarshalled(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	// Replace a nested message of the unmarshalled message by a new one.
+	attr := newAttribute("new", "")
+	m.SetAttribute(attr)
+
+	// Modification of the new message is reflected in the parent.
+	marshalLazy(t, m)
+	attr.SetValue("value")
+
+	expected := googleMessage(
+		t, "imports.proto", "types.Record", strings.Replace(
+			recordText, `attribute: {key: "single" value: "s"}`,
+			`attribute: {key: "new" value: "value"}`, 1,
+		),
+	)
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+	m.Free()
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+// optionalZeroText sets all optional fields to zero values.
+const optionalZeroText = `
+int32_value: 0
+string_value: ""
+double_value: 0
+bool_value: false
+bytes_value: ""
+enum_value: OPTIONAL_ENUM_ZERO
+nested: {value: 0}
+choice_uint32: 0
+fixed64_value: 0
+`
+
+func TestOptionalZeroValuesPresent(t *testing.T) {
+	src := googleMessage(t, "optional.proto", "types.Optional", optionalZeroText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalOptional(wireBytes, opts)
+			require.NoError(t, err)
+
+			assert.True(t, m.HasInt32Value())
+			assert.True(t, m.HasStringValue())
+			assert.True(t, m.HasDoubleValue())
+			assert.True(t, m.HasBoolValue())
+			assert.True(t, m.HasBytesValue())
+			assert.True(t, m.HasEnumValue())
+			assert.True(t, m.HasFixed64Value())
+			require.NotNil(t, m.Nested())
+			assert.True(t, m.Nested().HasValue())
+			assert.EqualValues(t, lazy.OptionalChoiceUint32, m.ChoiceType())
+
+			// Marshal from the struct fields. Zero values of present fields
+			// must be preserved.
+			m.SetImplicitValue(0)
+			m.Nested().SetValue(0)
+			requireEqualGoogle(t, src, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestOptionalAbsent(t *testing.T) {
+	src := googleMessage(t, "optional.proto", "types.Optional", `implicit_value: 1`)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalOptional(wireBytes, lazyproto.UnmarshalOpts{WithValidate: true})
+	require.NoError(t, err)
+
+	assert.False(t, m.HasInt32Value())
+	assert.False(t, m.HasStringValue())
+	assert.False(t, m.HasFixed64Value())
+	assert.Nil(t, m.Nested())
+
+	// Setting to zero value makes the field present.
+	m.SetInt32Value(0)
+	m.SetStringValue("")
+	assert.True(t, m.HasInt32Value())
+	assert.True(t, m.HasStringValue())
+
+	// Setting the implicit presence field to zero value omits it.
+	m.SetImplicitValue(0)
+
+	expected := googleMessage(
+		t, "optional.proto", "types.Optional", `int32_value: 0 string_value: ""`,
+	)
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+	m.Free()
+}
+
+func TestOptionalClear(t *testing.T) {
+	src := googleMessage(
+		t, "optional.proto", "types.Optional",
+		`int32_value: 5 string_value: "abc" bool_value: true fixed64_value: 64`,
+	)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalOptional(wireBytes, opts)
+			require.NoError(t, err)
+
+			m.ClearInt32Value()
+			m.ClearBoolValue()
+			assert.False(t, m.HasInt32Value())
+			assert.EqualValues(t, 0, m.Int32Value())
+			assert.False(t, m.HasBoolValue())
+			assert.True(t, m.HasStringValue())
+
+			expected := googleMessage(
+				t, "optional.proto", "types.Optional", `string_value: "abc" fixed64_value: 64`,
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestOptionalEqual(t *testing.T) {
+	zero := googleMessage(t, "optional.proto", "types.Optional", `int32_value: 0`)
+	zeroBytes, err := proto.Marshal(zero)
+	require.NoError(t, err)
+
+	absent := googleMessage(t, "optional.proto", "types.Optional", ``)
+	absentBytes, err := proto.Marshal(absent)
+	require.NoError(t, err)
+
+	m1, err := lazy.UnmarshalOptional(zeroBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	m2, err := lazy.UnmarshalOptional(absentBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, proto.Equal(zero, absent), m1.Equal(m2))
+
+	m1.ClearInt32Value()
+	assert.True(t, m1.Equal(m2))
+
+	m1.Free()
+	m2.Free()
+}
@@ -0,0 +1,248 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+const proto2Text = `
+int32_value: 0
+string_value: ""
+sint64_default: 5
+bool_default: false
+enum_value: PROTO2_ENUM_TWO
+required_value: 11
+nested: {name: "nested" fixed64_value: 64}
+Result: {url: "http://example.com" ranks: [1, 2, 3]}
+Item: {id: 1}
+Item: {id: 2 inner: {name: "inner"}}
+numbers: [-1, 0, 1]
+`
+
+func unmarshalProto2(t *testing.T, wireBytes []byte, opts lazyproto.UnmarshalOpts) *lazy.Proto2Message {
+	m, err := lazy.UnmarshalProto2Message(wireBytes, opts)
+	require.NoError(t, err)
+	return m
+}
+
+func TestProto2Get(t *testing.T) {
+	src := googleMessage(t, "proto2.proto", "types.Proto2Message", proto2Text)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m := unmarshalProto2(t, wireBytes, opts)
+
+			// Fields that are present with zero value.
+			assert.True(t, m.HasInt32Value())
+			assert.EqualValues(t, 0, m.Int32Value())
+			assert.True(t, m.HasStringValue())
+			assert.EqualValues(t, "", m.StringValue())
+			assert.True(t, m.HasBoolDefault())
+			assert.False(t, m.BoolDefault())
+
+			// Present fields with non-zero value.
+			assert.EqualValues(t, 5, m.Sint64Default())
+			assert.EqualValues(t, lazy.Proto2Enum_PROTO2_ENUM_TWO, m.EnumValue())
+			assert.EqualValues(t, 11, m.RequiredValue())
+			assert.EqualValues(t, "nested", m.Nested().Name())
+			assert.EqualValues(t, 64, m.Nested().Fixed64Value())
+
+			// Groups.
+			assert.EqualValues(t, "http://example.com", m.Result().Url())
+			assert.EqualValues(t, []uint32{1, 2, 3}, m.Result().Ranks().AppendTo(nil))
+			require.EqualValues(t, 2, m.Item().Len())
+			assert.EqualValues(t, 1, m.Item().At(0).Id())
+			assert.Nil(t, m.Item().At(0).Inner())
+			assert.EqualValues(t, 2, m.Item().At(1).Id())
+			assert.EqualValues(t, "inner", m.Item().At(1).Inner().Name())
+
+			assert.EqualValues(t, []int32{-1, 0, 1}, m.Numbers().AppendTo(nil))
+
+			// Unmodified message is marshalled as is.
+			assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestProto2Defaults(t *testing.T) {
+	src := googleMessage(t, "proto2.proto", "types.Proto2Message", `required_value: 0`)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m := unmarshalProto2(t, wireBytes, lazyproto.UnmarshalOpts{WithValidate: true})
+
+	// Absent fields return the default values.
+	assert.False(t, m.HasInt32Value())
+	assert.EqualValues(t, 0, m.Int32Value())
+	assert.False(t, m.HasSint64Default())
+	assert.EqualValues(t, -64, m.Sint64Default())
+	assert.EqualValues(t, `hello "world"`, m.StringDefault())
+	assert.EqualValues(t, []byte{1, 2}, m.BytesDefault())
+	assert.True(t, math.IsInf(m.DoubleDefault(), 1))
+	assert.EqualValues(t, 1.5, m.FloatDefault())
+	assert.True(t, m.BoolDefault())
+	assert.EqualValues(t, lazy.Proto2Enum_PROTO2_ENUM_ONE, m.EnumValue())
+	assert.EqualValues(t, lazy.Proto2Enum_PROTO2_ENUM_TWO, m.EnumDefault())
+	assert.True(t, m.HasRequiredValue())
+	assert.EqualValues(t, 0, m.RequiredValue())
+	assert.Nil(t, m.Result())
+
+	// Explicitly set zero values are returned instead of the defaults and are
+	// marshaled.
+	m.SetSint64Default(0)
+	m.SetBoolDefault(false)
+	m.SetDoubleDefault(0)
+	m.SetEnumDefault(lazy.Proto2Enum_PROTO2_ENUM_ONE)
+	assert.EqualValues(t, 0, m.Sint64Default())
+	assert.False(t, m.BoolDefault())
+
+	expected := googleMessage(
+		t, "proto2.proto", "types.Proto2Message", `
+sint64_default: 0
+double_default: 0
+bool_default: false
+enum_default: PROTO2_ENUM_ONE
+required_value: 0
+`,
+	)
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+	m.Free()
+}
+
+func TestProto2Modify(t *testing.T) {
+	src := googleMessage(t, "proto2.proto", "types.Proto2Message", proto2Text)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m := unmarshalProto2(t, wireBytes, opts)
+
+			m.SetStringValue("abc")
+			m.Result().SetUrl("")
+			m.Item().At(0).SetId(0)
+			m.Item().AppendNew().SetId(3)
+
+			expected := googleMessage(
+				t, "proto2.proto", "types.Proto2Message", `
+int32_value: 0
+string_value: "abc"
+sint64_default: 5
+bool_default: false
+enum_value: PROTO2_ENUM_TWO
+required_value: 11
+nested: {name: "nested" fixed64_value: 64}
+Result: {url: "" ranks: [1, 2, 3]}
+Item: {id: 0}
+Item: {id: 2 inner: {name: "inner"}}
+Item: {id: 3}
+numbers: [-1, 0, 1]
+`,
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestProto2RequiredMissing(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{
+			name: "top-level",
+			text: `int32_value: 1`,
+		},
+		{
+			name: "nested message",
+			text: `required_value: 1 nested: {fixed64_value: 1}`,
+		},
+		{
+			name: "group",
+			text: `required_value: 1 Item: {inner: {name: "inner"}}`,
+		},
+		{
+			name: "message in group",
+			text: `required_value: 1 Item: {id: 1 inner: {}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, "proto2.proto", "types.Proto2Message", test.text)
+				wireBytes, err := proto.MarshalOptions{AllowPartial: true}.Marshal(src)
+				require.NoError(t, err)
+
+				_, err = lazy.UnmarshalProto2Message(
+					wireBytes, lazyproto.UnmarshalOpts{WithValidate: true},
+				)
+				assert.Error(t, err)
+
+				// Required fields are not checked without validation.
+				m, err := lazy.UnmarshalProto2Message(
+					wireBytes, lazyproto.UnmarshalOpts{WithValidate: false},
+				)
+				require.NoError(t, err)
+				m.Free()
+			},
+		)
+	}
+}
+
+func TestProto2Equal(t *testing.T) {
+	zero := googleMessage(t, "proto2.proto", "types.Proto2Message", `required_value: 1 int32_value: 0`)
+	zeroBytes, err := proto.Marshal(zero)
+	require.NoError(t, err)
+
+	absent := googleMessage(t, "proto2.proto", "types.Proto2Message", `required_value: 1`)
+	absentBytes, err := proto.Marshal(absent)
+	require.NoError(t, err)
+
+	m1 := unmarshalProto2(t, zeroBytes, lazyproto.UnmarshalOpts{})
+	m2 := unmarshalProto2(t, absentBytes, lazyproto.UnmarshalOpts{})
+
+	// Present zero value is not equal to absent field.
+	assert.EqualValues(t, proto.Equal(zero, absent), m1.Equal(m2))
+	assert.False(t, m1.Equal(m2))
+
+	m2.SetInt32Value(0)
+	assert.True(t, m1.Equal(m2))
+
+	m1.Free()
+	m2.Free()
+}
+
+func TestProto2UnknownGroups(t *testing.T) {
+	src := googleMessage(t, "proto2.proto", "types.Proto2Message", proto2Text)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalProto2Partial(wireBytes, opts)
+			require.NoError(t, err)
+			assert.NotEmpty(t, m.UnknownFields())
+
+			// Groups are preserved as unknown fields.
+			m.SetInt32Value(7)
+			expected := googleMessage(
+				t, "proto2.proto", "types.Proto2Message",
+				"int32_value: 7\n"+proto2Text[len("\nint32_value: 0\n"):],
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
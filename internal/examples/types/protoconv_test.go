@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protoconv"
+)
+
+// convertibleMessage is a generated message that can be converted to and from the
+// messages of other implementations.
+type convertibleMessage interface {
+	lazyproto.Message
+	FromProto(src protoconv.Message) error
+	ToProto(dst protoconv.Message) error
+}
+
+func TestToProto(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+
+				forEachUnmarshalOpts(
+					t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+						m, err := test.unmarshal(wireBytes, opts)
+						require.NoError(t, err)
+
+						// Unmodified message hands over the original bytes.
+						dst := src.ProtoReflect().New().Interface()
+						require.NoError(t, m.(convertibleMessage).ToProto(dst))
+						assert.True(t, proto.Equal(src, dst))
+						assert.False(t, m.IsModified())
+
+						// Modified message is marshalled field by field.
+						m.ProtoReflect().SetUnknown(nil)
+						assert.True(t, m.IsModified())
+						require.NoError(t, m.(convertibleMessage).ToProto(dst))
+						assert.True(t, proto.Equal(src, dst))
+						m.Free()
+					},
+				)
+			},
+		)
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+
+				m := test.new().(convertibleMessage)
+				require.NoError(t, m.FromProto(src))
+				requireEqualGoogle(t, src, marshalLazy(t, m))
+				m.Free()
+			},
+		)
+	}
+}
+
+func TestToProtoNested(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	// The nested message is converted from its own bytes.
+	resourceDescr := src.ProtoReflect().Descriptor().Fields().ByName("resource")
+	expected := src.ProtoReflect().Get(resourceDescr).Message().Interface()
+	dst := expected.ProtoReflect().New().Interface()
+	require.NoError(t, m.Resource().ToProto(dst))
+	assert.True(t, proto.Equal(expected, dst))
+
+	// Replacing the content of the nested message marks the parent modified.
+	require.NoError(t, m.Resource().FromProto(dst))
+	assert.True(t, m.IsModified())
+	requireEqualGoogle(t, src, marshalLazy(t, m))
+	m.Free()
+}
@@ -0,0 +1,203 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+// dynamicMessage unmarshals the wire bytes into a dynamic message that uses the
+// descriptor of the lazy message. proto.Equal and proto.Merge require the messages
+// to have the same descriptor.
+func dynamicMessage(t *testing.T, m lazyproto.Message, wireBytes []byte) proto.Message {
+	d := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+	require.NoError(t, proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(wireBytes, d))
+	return d
+}
+
+func TestProtoReflectGet(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+
+				forEachUnmarshalOpts(
+					t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+						m, err := test.unmarshal(wireBytes, opts)
+						require.NoError(t, err)
+
+						assert.EqualValues(t, test.msgName, m.ProtoReflect().Descriptor().FullName())
+						assert.True(t, proto.Equal(dynamicMessage(t, m, wireBytes), m))
+
+						// Reflection based encoders produce the same output.
+						b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(m)
+						require.NoError(t, err)
+						requireEqualGoogle(t, src, b)
+
+						expectedJSON, err := protojson.Marshal(src)
+						require.NoError(t, err)
+						actualJSON, err := protojson.Marshal(m)
+						require.NoError(t, err)
+						assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+
+						// Reading must not modify the message.
+						assert.False(t, m.IsModified())
+						assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+						m.Free()
+					},
+				)
+			},
+		)
+	}
+}
+
+func TestProtoReflectSet(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+
+				// Merge sets the fields of the message to the values of the dynamic
+				// message, which are copied.
+				m := test.new()
+				d := dynamicMessage(t, m, wireBytes)
+				proto.Merge(m, d)
+				assert.True(t, m.IsModified())
+				assert.True(t, proto.Equal(d, m))
+				requireEqualGoogle(t, src, marshalLazy(t, m))
+
+				// Unmarshal populates the message using Mutable and the list and
+				// map views.
+				u := test.new()
+				require.NoError(t, proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(wireBytes, u))
+				assert.True(t, proto.Equal(d, u))
+				requireEqualGoogle(t, src, marshalLazy(t, u))
+				u.Free()
+
+				// Clone creates the message using the message type.
+				c := proto.Clone(m)
+				assert.IsType(t, m, c)
+				assert.True(t, proto.Equal(d, c))
+				requireEqualGoogle(t, src, marshalLazy(t, c.(lazyproto.Message)))
+				c.(lazyproto.Message).Free()
+
+				// Clear all populated fields.
+				r := m.ProtoReflect()
+				r.Range(
+					func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+						r.Clear(fd)
+						return true
+					},
+				)
+				assert.Empty(t, marshalLazy(t, m))
+				m.Free()
+			},
+		)
+	}
+}
+
+func TestProtoReflectModifies(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	r := m.ProtoReflect()
+	fields := r.Descriptor().Fields()
+
+	// Mutating a nested message marks both messages modified.
+	resource := r.Mutable(fields.ByName("resource")).Message()
+	assert.False(t, m.IsModified())
+	resource.Set(
+		resource.Descriptor().Fields().ByName("min_severity"),
+		protoreflect.ValueOfEnum(2),
+	)
+	assert.True(t, m.IsModified())
+	assert.True(t, m.Resource().IsModified())
+
+	// Appending to the list.
+	attr := r.Mutable(fields.ByName("attributes")).List().AppendMutable().Message()
+	attr.Set(attr.Descriptor().Fields().ByName("key"), protoreflect.ValueOfString("added"))
+	assert.Equal(t, "added", m.Attributes().At(m.Attributes().Len()-1).Key())
+
+	// Setting the oneof choice.
+	body := r.Descriptor().Oneofs().ByName("body")
+	assert.Equal(t, "attribute_body", string(r.WhichOneof(body).Name()))
+	r.Set(fields.ByName("string_body"), protoreflect.ValueOfString("text"))
+	assert.Equal(t, "string_body", string(r.WhichOneof(body).Name()))
+	assert.Equal(t, "text", m.StringBody())
+	r.Clear(fields.ByName("string_body"))
+	assert.Nil(t, r.WhichOneof(body))
+
+	// The modified message is encoded with the changes.
+	expected := proto.Clone(src)
+	er := expected.ProtoReflect()
+	eresource := er.Mutable(er.Descriptor().Fields().ByName("resource")).Message()
+	eresource.Set(
+		eresource.Descriptor().Fields().ByName("min_severity"), protoreflect.ValueOfEnum(2),
+	)
+	eattr := er.Mutable(er.Descriptor().Fields().ByName("attributes")).List().AppendMutable().Message()
+	eattr.Set(eattr.Descriptor().Fields().ByName("key"), protoreflect.ValueOfString("added"))
+	er.Clear(er.Descriptor().Fields().ByName("attribute_body"))
+	requireEqualGoogle(t, expected, marshalLazy(t, m))
+	m.Free()
+}
+
+func TestProtoReflectUnknownFields(t *testing.T) {
+	src := googleMessage(t, "unknown.proto", "types.KnownFieldsV2", `name: "n" added_int: 5`)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalKnownFields(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	r := m.ProtoReflect()
+	unknown := r.GetUnknown()
+	assert.EqualValues(t, m.UnknownFields(), unknown)
+	assert.NotEmpty(t, unknown)
+
+	r.SetUnknown(nil)
+	assert.True(t, m.IsModified())
+	assert.Empty(t, m.UnknownFields())
+
+	r.SetUnknown(unknown)
+	requireEqualGoogle(t, src, marshalLazy(t, m))
+	m.Free()
+}
+
+func TestProtoReflectInvalid(t *testing.T) {
+	var m *lazy.Optional
+	r := m.ProtoReflect()
+	assert.False(t, r.IsValid())
+
+	fields := r.Descriptor().Fields()
+	assert.False(t, r.Has(fields.ByName("nested")))
+	assert.False(t, r.Get(fields.ByName("nested")).Message().IsValid())
+	assert.EqualValues(t, 0, r.Get(fields.ByName("int32_value")).Int())
+	assert.Panics(t, func() { r.Set(fields.ByName("int32_value"), protoreflect.ValueOfInt32(1)) })
+
+	// New creates a valid message.
+	n := r.New()
+	assert.True(t, n.IsValid())
+	n.Set(fields.ByName("int32_value"), protoreflect.ValueOfInt32(1))
+	assert.True(t, n.Has(fields.ByName("int32_value")))
+	assert.EqualValues(t, 1, n.Interface().(*lazy.Optional).Int32Value())
+
+	// The fields of other messages are rejected.
+	other := lazy.NewScalars().ProtoReflect()
+	assert.Panics(t, func() { n.Get(other.Descriptor().Fields().Get(0)) })
+}
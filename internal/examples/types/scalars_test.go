@@ -0,0 +1,374 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+const scalarsText = `
+double_value: 1.5
+float_value: -2.25
+int32_value: -32
+int64_value: -64
+uint32_value: 4000000000
+uint64_value: 18000000000000000000
+sint32_value: -320
+sint64_value: -640
+fixed32_value: 3200
+fixed64_value: 6400
+sfixed32_value: -3200
+sfixed64_value: -6400
+bool_value: true
+string_value: "abc"
+bytes_value: "def"
+`
+
+func TestScalars(t *testing.T) {
+	src := googleMessage(t, "scalars.proto", "types.Scalars", scalarsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalScalars(wireBytes, opts)
+			require.NoError(t, err)
+
+			assert.EqualValues(t, 1.5, m.DoubleValue())
+			assert.EqualValues(t, float32(-2.25), m.FloatValue())
+			assert.EqualValues(t, int32(-32), m.Int32Value())
+			assert.EqualValues(t, int64(-64), m.Int64Value())
+			assert.EqualValues(t, uint32(4000000000), m.Uint32Value())
+			assert.EqualValues(t, uint64(18000000000000000000), m.Uint64Value())
+			assert.EqualValues(t, int32(-320), m.Sint32Value())
+			assert.EqualValues(t, int64(-640), m.Sint64Value())
+			assert.EqualValues(t, uint32(3200), m.Fixed32Value())
+			assert.EqualValues(t, uint64(6400), m.Fixed64Value())
+			assert.EqualValues(t, int32(-3200), m.Sfixed32Value())
+			assert.EqualValues(t, int64(-6400), m.Sfixed64Value())
+			assert.True(t, m.BoolValue())
+			assert.EqualValues(t, "abc", m.StringValue())
+			assert.EqualValues(t, []byte("def"), m.BytesValue())
+
+			// Unmodified message is marshalled as is.
+			assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+
+			// Set all fields to force marshaling from the struct fields.
+			m.SetDoubleValue(m.DoubleValue())
+			m.SetFloatValue(m.FloatValue())
+			m.SetInt32Value(m.Int32Value())
+			m.SetInt64Value(m.Int64Value())
+			m.SetUint32Value(m.Uint32Value())
+			m.SetUint64Value(m.Uint64Value())
+			m.SetSint32Value(m.Sint32Value())
+			m.SetSint64Value(m.Sint64Value())
+			m.SetFixed32Value(m.Fixed32Value())
+			m.SetFixed64Value(m.Fixed64Value())
+			m.SetSfixed32Value(m.Sfixed32Value())
+			m.SetSfixed64Value(m.Sfixed64Value())
+			m.SetBoolValue(m.BoolValue())
+			m.SetStringValue(m.StringValue())
+			m.SetBytesValue(m.BytesValue())
+
+			requireEqualGoogle(t, src, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestScalarsLimits(t *testing.T) {
+	src := googleMessage(
+		t, "scalars.proto", "types.Scalars", `
+float_value: 3.4028235e+38
+int32_value: -2147483648
+int64_value: -9223372036854775808
+sint32_value: 2147483647
+sint64_value: -9223372036854775808
+sfixed32_value: -2147483648
+`,
+	)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalScalars(wireBytes, lazyproto.UnmarshalOpts{WithValidate: true})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, float32(math.MaxFloat32), m.FloatValue())
+	assert.EqualValues(t, int32(math.MinInt32), m.Int32Value())
+	assert.EqualValues(t, int64(math.MinInt64), m.Int64Value())
+	assert.EqualValues(t, int32(math.MaxInt32), m.Sint32Value())
+	assert.EqualValues(t, int64(math.MinInt64), m.Sint64Value())
+	assert.EqualValues(t, int32(math.MinInt32), m.Sfixed32Value())
+
+	m.SetInt32Value(m.Int32Value())
+	requireEqualGoogle(t, src, marshalLazy(t, m))
+}
+
+const repeatedScalarsText = `
+double_values: [1.5, 0, -2.5]
+float_values: [-2.25, 0, 3.5]
+int32_values: [-32, 0, 32]
+int64_values: [-64, 0, 64]
+uint32_values: [4000000000, 0, 1]
+uint64_values: [18000000000000000000, 0, 1]
+sint32_values: [-320, 0, 320]
+sint64_values: [-640, 0, 640]
+fixed32_values: [3200, 0, 1]
+fixed64_values: [6400, 0, 1]
+sfixed32_values: [-3200, 0, 3200]
+sfixed64_values: [-6400, 0, 6400]
+bool_values: [true, false, true]
+enum_values: [SCALAR_ENUM_TWO, SCALAR_ENUM_ZERO, SCALAR_ENUM_ONE]
+`
+
+func TestRepeatedScalars(t *testing.T) {
+	src := googleMessage(t, "scalars.proto", "types.RepeatedScalars", repeatedScalarsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalRepeatedScalars(wireBytes, opts)
+			require.NoError(t, err)
+
+			assert.EqualValues(t, []float64{1.5, 0, -2.5}, m.DoubleValues().AppendTo(nil))
+			assert.EqualValues(t, []float32{-2.25, 0, 3.5}, m.FloatValues().AppendTo(nil))
+			assert.EqualValues(t, []int32{-32, 0, 32}, m.Int32Values().AppendTo(nil))
+			assert.EqualValues(t, []int64{-64, 0, 64}, m.Int64Values().AppendTo(nil))
+			assert.EqualValues(t, []uint32{4000000000, 0, 1}, m.Uint32Values().AppendTo(nil))
+			assert.EqualValues(t, []uint64{18000000000000000000, 0, 1}, m.Uint64Values().AppendTo(nil))
+			assert.EqualValues(t, []int32{-320, 0, 320}, m.Sint32Values().AppendTo(nil))
+			assert.EqualValues(t, []int64{-640, 0, 640}, m.Sint64Values().AppendTo(nil))
+			assert.EqualValues(t, []uint32{3200, 0, 1}, m.Fixed32Values().AppendTo(nil))
+			assert.EqualValues(t, []uint64{6400, 0, 1}, m.Fixed64Values().AppendTo(nil))
+			assert.EqualValues(t, []int32{-3200, 0, 3200}, m.Sfixed32Values().AppendTo(nil))
+			assert.EqualValues(t, []int64{-6400, 0, 6400}, m.Sfixed64Values().AppendTo(nil))
+			assert.EqualValues(t, []bool{true, false, true}, m.BoolValues().AppendTo(nil))
+			assert.EqualValues(
+				t, []lazy.ScalarEnum{lazy.ScalarEnum_SCALAR_ENUM_TWO, lazy.ScalarEnum_SCALAR_ENUM_ZERO, lazy.ScalarEnum_SCALAR_ENUM_ONE},
+				m.EnumValues().AppendTo(nil),
+			)
+
+			assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+
+			m.SetFloatValues(m.FloatValues().AppendTo(nil))
+			requireEqualGoogle(t, src, marshalLazy(t, m))
+			assert.EqualValues(t, len(marshalLazy(t, m)), m.Size())
+			m.Free()
+		},
+	)
+}
+
+func TestRepeatedScalarsModify(t *testing.T) {
+	src := googleMessage(t, "scalars.proto", "types.RepeatedScalars", repeatedScalarsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRepeatedScalars(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	defer m.Free()
+
+	// The modifications via the returned slices mark the message modified.
+	m.Int32Values().Set(0, 5)
+	assert.True(t, m.IsModified())
+	m.BoolValues().Append(false)
+	m.DoubleValues().RemoveAt(1)
+
+	modified, err := lazy.UnmarshalRepeatedScalars(marshalLazy(t, m), lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	defer modified.Free()
+	assert.EqualValues(t, []int32{5, 0, 32}, modified.Int32Values().AppendTo(nil))
+	assert.EqualValues(t, []bool{true, false, true, false}, modified.BoolValues().AppendTo(nil))
+	assert.EqualValues(t, []float64{1.5, -2.5}, modified.DoubleValues().AppendTo(nil))
+	assert.True(t, modified.Equal(m))
+}
+
+func TestUnpackedScalars(t *testing.T) {
+	src := googleMessage(
+		t, "scalars.proto", "types.UnpackedScalars", `
+float_values: [-2.25, 0, 3.5]
+int32_values: [-32, 0, 32]
+sint64_values: [-640, 0, 640]
+fixed64_values: [6400, 0, 1]
+sfixed32_values: [-3200, 0, 3200]
+enum_values: [SCALAR_ENUM_TWO, SCALAR_ENUM_ZERO, SCALAR_ENUM_ONE]
+`,
+	)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalUnpackedScalars(wireBytes, opts)
+			require.NoError(t, err)
+
+			assert.EqualValues(t, []float32{-2.25, 0, 3.5}, m.FloatValues().AppendTo(nil))
+			assert.EqualValues(t, []int32{-32, 0, 32}, m.Int32Values().AppendTo(nil))
+			assert.EqualValues(t, []int64{-640, 0, 640}, m.Sint64Values().AppendTo(nil))
+			assert.EqualValues(t, []uint64{6400, 0, 1}, m.Fixed64Values().AppendTo(nil))
+			assert.EqualValues(t, []int32{-3200, 0, 3200}, m.Sfixed32Values().AppendTo(nil))
+			assert.EqualValues(
+				t, []lazy.ScalarEnum{lazy.ScalarEnum_SCALAR_ENUM_TWO, lazy.ScalarEnum_SCALAR_ENUM_ZERO, lazy.ScalarEnum_SCALAR_ENUM_ONE},
+				m.EnumValues().AppendTo(nil),
+			)
+
+			assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+
+			// Modified message is marshalled in packed form, which parsers must
+			// accept for unpacked fields too.
+			m.SetInt32Values(m.Int32Values().AppendTo(nil))
+			requireEqualGoogle(t, src, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestPackedIntoUnpacked(t *testing.T) {
+	// Parsers must accept packed form for unpacked fields. Use the packed
+	// wire bytes of RepeatedScalars, which has matching field numbers.
+	src := googleMessage(t, "scalars.proto", "types.RepeatedScalars", repeatedScalarsText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalUnpackedScalars(wireBytes, lazyproto.UnmarshalOpts{WithValidate: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, []float32{-2.25, 0, 3.5}, m.FloatValues().AppendTo(nil))
+	assert.EqualValues(t, []int64{-640, 0, 640}, m.Sint64Values().AppendTo(nil))
+	assert.EqualValues(t, []int32{-3200, 0, 3200}, m.Sfixed32Values().AppendTo(nil))
+	assert.EqualValues(
+		t, []lazy.ScalarEnum{lazy.ScalarEnum_SCALAR_ENUM_TWO, lazy.ScalarEnum_SCALAR_ENUM_ZERO, lazy.ScalarEnum_SCALAR_ENUM_ONE},
+		m.EnumValues().AppendTo(nil),
+	)
+}
+
+func TestOneOfScalars(t *testing.T) {
+	tests := []struct {
+		text  string
+		check func(t *testing.T, m *lazy.OneOfScalars)
+		set   func(m *lazy.OneOfScalars)
+	}{
+		{
+			text: "double_value: -1.5",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, lazy.OneOfScalarsDoubleValue, m.ValueType())
+				assert.EqualValues(t, -1.5, m.DoubleValue())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetDoubleValue(m.DoubleValue()) },
+		},
+		{
+			text: "float_value: -2.25",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, lazy.OneOfScalarsFloatValue, m.ValueType())
+				assert.EqualValues(t, float32(-2.25), m.FloatValue())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetFloatValue(m.FloatValue()) },
+		},
+		{
+			text: "int32_value: -32",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, lazy.OneOfScalarsInt32Value, m.ValueType())
+				assert.EqualValues(t, int32(-32), m.Int32Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetInt32Value(m.Int32Value()) },
+		},
+		{
+			text: "int64_value: -64",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, int64(-64), m.Int64Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetInt64Value(m.Int64Value()) },
+		},
+		{
+			text: "uint32_value: 4000000000",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, uint32(4000000000), m.Uint32Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetUint32Value(m.Uint32Value()) },
+		},
+		{
+			text: "uint64_value: 18000000000000000000",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, uint64(18000000000000000000), m.Uint64Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetUint64Value(m.Uint64Value()) },
+		},
+		{
+			text: "sint32_value: -320",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, int32(-320), m.Sint32Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetSint32Value(m.Sint32Value()) },
+		},
+		{
+			text: "sint64_value: -640",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, lazy.OneOfScalarsSint64Value, m.ValueType())
+				assert.EqualValues(t, int64(-640), m.Sint64Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetSint64Value(m.Sint64Value()) },
+		},
+		{
+			text: "fixed32_value: 3200",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, uint32(3200), m.Fixed32Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetFixed32Value(m.Fixed32Value()) },
+		},
+		{
+			text: "fixed64_value: 6400",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, uint64(6400), m.Fixed64Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetFixed64Value(m.Fixed64Value()) },
+		},
+		{
+			text: "sfixed32_value: -3200",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, lazy.OneOfScalarsSfixed32Value, m.ValueType())
+				assert.EqualValues(t, int32(-3200), m.Sfixed32Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetSfixed32Value(m.Sfixed32Value()) },
+		},
+		{
+			text: "sfixed64_value: -6400",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.EqualValues(t, int64(-6400), m.Sfixed64Value())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetSfixed64Value(m.Sfixed64Value()) },
+		},
+		{
+			text: "bool_value: true",
+			check: func(t *testing.T, m *lazy.OneOfScalars) {
+				assert.True(t, m.BoolValue())
+			},
+			set: func(m *lazy.OneOfScalars) { m.SetBoolValue(m.BoolValue()) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(
+			test.text, func(t *testing.T) {
+				src := googleMessage(t, "scalars.proto", "types.OneOfScalars", test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+
+				m, err := lazy.UnmarshalOneOfScalars(
+					wireBytes, lazyproto.UnmarshalOpts{WithValidate: true},
+				)
+				require.NoError(t, err)
+				test.check(t, m)
+
+				test.set(m)
+				test.check(t, m)
+				requireEqualGoogle(t, src, marshalLazy(t, m))
+				m.Free()
+			},
+		)
+	}
+}
@@ -0,0 +1,237 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy/common"
+	"github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy/resource"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/grpccodec"
+)
+
+type resourceServer struct {
+	lazy.UnimplementedResourceServiceServer
+}
+
+func (s *resourceServer) Export(_ context.Context, req *lazy.ExportRequest) (*lazy.ExportResponse, error) {
+	if req.Name() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is empty")
+	}
+	resp := lazy.NewExportResponse()
+	resp.SetAcceptedResources(int64(req.Resources().Len()))
+	req.Free()
+	return resp, nil
+}
+
+func (s *resourceServer) List(
+	req *lazy.ExportRequest, stream grpc.ServerStreamingServer[resource.Resource],
+) error {
+	var err error
+	req.Resources().Range(
+		func(_ int, res *resource.Resource) bool {
+			err = stream.Send(res)
+			return err == nil
+		},
+	)
+	req.Free()
+	return err
+}
+
+func (s *resourceServer) Collect(
+	stream grpc.ClientStreamingServer[resource.Resource, lazy.ExportResponse],
+) error {
+	resp := lazy.NewExportResponse()
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+		resp.SetAcceptedResources(resp.AcceptedResources() + 1)
+		res.Free()
+	}
+}
+
+func (s *resourceServer) Echo(
+	stream grpc.BidiStreamingServer[lazy.ExportRequest, lazy.ExportRequest],
+) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// The request is not modified, so its received bytes are sent back.
+		err = stream.Send(req)
+		req.Free()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func startResourceServer(
+	t *testing.T, srv lazy.ResourceServiceServer, opts ...grpc.ServerOption,
+) lazy.ResourceServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(opts...)
+	lazy.RegisterResourceServiceServer(s, srv)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return lazy.NewResourceServiceClient(conn)
+}
+
+func newResource(key string) *resource.Resource {
+	res := resource.NewResource()
+	res.SetMinSeverity(common.Severity_SEVERITY_INFO)
+	attr := res.Attributes().AppendNew()
+	attr.SetKey(key)
+	attr.SetValue("v")
+	return res
+}
+
+func newExportRequest(keys ...string) *lazy.ExportRequest {
+	req := lazy.NewExportRequest()
+	req.SetName("export")
+	for _, key := range keys {
+		req.Resources().Append(newResource(key))
+	}
+	return req
+}
+
+func TestServiceUnary(t *testing.T) {
+	client := startResourceServer(t, &resourceServer{}, grpc.ForceServerCodecV2(grpccodec.CodecV2{}))
+	ctx := context.Background()
+
+	req := newExportRequest("a", "b", "c")
+	resp, err := client.Export(ctx, req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, resp.AcceptedResources())
+	resp.Free()
+
+	// The errors returned by the server are passed to the client.
+	req.SetName("")
+	_, err = client.Export(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	req.Free()
+}
+
+func TestServiceServerStreaming(t *testing.T) {
+	client := startResourceServer(t, &resourceServer{}, grpc.ForceServerCodecV2(grpccodec.CodecV2{}))
+
+	req := newExportRequest("a", "b", "c")
+	stream, err := client.List(context.Background(), req)
+	require.NoError(t, err)
+	req.Free()
+
+	var keys []string
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.False(t, res.IsModified())
+		assert.Equal(t, common.Severity_SEVERITY_INFO, res.MinSeverity())
+		keys = append(keys, res.Attributes().At(0).Key())
+		res.Free()
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+}
+
+func TestServiceClientStreaming(t *testing.T) {
+	client := startResourceServer(t, &resourceServer{}, grpc.ForceServerCodecV2(grpccodec.CodecV2{}))
+
+	stream, err := client.Collect(context.Background())
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b"} {
+		res := newResource(key)
+		require.NoError(t, stream.Send(res))
+		res.Free()
+	}
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.AcceptedResources())
+	resp.Free()
+}
+
+func TestServiceBidiStreaming(t *testing.T) {
+	client := startResourceServer(t, &resourceServer{}, grpc.ForceServerCodecV2(grpccodec.CodecV2{}))
+
+	stream, err := client.Echo(context.Background())
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b"} {
+		req := newExportRequest(key)
+		require.NoError(t, stream.Send(req))
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.True(t, req.Equal(resp))
+		req.Free()
+		resp.Free()
+	}
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestServiceUnimplemented(t *testing.T) {
+	client := startResourceServer(
+		t, lazy.UnimplementedResourceServiceServer{}, grpc.ForceServerCodecV2(grpccodec.CodecV2{}),
+	)
+	ctx := context.Background()
+
+	req := newExportRequest("a")
+	_, err := client.Export(ctx, req)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	stream, err := client.Echo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(req))
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	req.Free()
+}
+
+func TestServiceDefaultServerCodec(t *testing.T) {
+	// The server that uses the default proto codec unmarshals the lazyproto
+	// messages via reflection.
+	client := startResourceServer(t, &resourceServer{})
+
+	req := newExportRequest("a", "b")
+	resp, err := client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.AcceptedResources())
+	resp.Free()
+	req.Free()
+}
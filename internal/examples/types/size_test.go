@@ -0,0 +1,105 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+func TestSize(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+
+				// The size of the unmodified message is the size of its bytes.
+				m, err := test.unmarshal(wireBytes, lazyproto.UnmarshalOpts{})
+				require.NoError(t, err)
+				assert.EqualValues(t, len(wireBytes), m.Size())
+				m.Free()
+
+				// The message built from JSON is modified, including all nested messages.
+				json := googleJSON(t, src)
+				m = test.new()
+				require.NoError(t, m.UnmarshalJSON(json))
+				require.True(t, m.IsModified())
+				size := m.Size()
+				b := marshalLazy(t, m)
+				assert.EqualValues(t, len(b), size)
+				requireEqualGoogle(t, src, b)
+
+				// The cached size is returned by the next call.
+				assert.EqualValues(t, size, m.Size())
+				m.Free()
+			},
+		)
+	}
+}
+
+func TestSizeNestedModified(t *testing.T) {
+	req := newExportRequest("a", "b")
+	b, err := lazy.UnmarshalExportRequest(marshalLazy(t, req), lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	req.Free()
+	req = b
+
+	size := req.Size()
+	assert.False(t, req.IsModified())
+
+	// Modify a deeply nested message, so that its size no longer fits in one byte
+	// and the length prefixes of all parents grow.
+	attr := req.Resources().At(1).Attributes().At(0)
+	attr.SetKey(strings.Repeat("k", 200))
+	assert.True(t, req.IsModified())
+	assert.EqualValues(t, size+199+3, req.Size())
+	assert.EqualValues(t, len(marshalLazy(t, req)), req.Size())
+
+	// The cached size is invalidated by the modification of the nested message.
+	attr.SetKey("key")
+	assert.EqualValues(t, size+2, req.Size())
+	assert.EqualValues(t, len(marshalLazy(t, req)), req.Size())
+
+	// Removing a nested message invalidates the cached size too.
+	req.Resources().RemoveAt(0)
+	b2 := marshalLazy(t, req)
+	assert.EqualValues(t, len(b2), req.Size())
+
+	unmarshalled, err := lazy.UnmarshalExportRequest(b2, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	assert.True(t, unmarshalled.Equal(req))
+	unmarshalled.Free()
+	req.Free()
+}
+
+func TestMarshalWithoutSize(t *testing.T) {
+	req := newExportRequest("a", "b")
+	b, err := lazy.UnmarshalExportRequest(marshalLazy(t, req), lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	req.Free()
+	req = b
+
+	// Marshal the modified message without calling Size() first, so that the
+	// length prefixes of the modified messages are written after them, including
+	// the ones that don't fit in the reserved space.
+	req.Resources().At(1).Attributes().At(0).SetKey(strings.Repeat("k", 200))
+	first := marshalLazy(t, req)
+
+	// The sizes cached by the first Marshal are used by the next one.
+	assert.EqualValues(t, len(first), req.Size())
+	assert.EqualValues(t, first, marshalLazy(t, req))
+
+	unmarshalled, err := lazy.UnmarshalExportRequest(first, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	assert.True(t, unmarshalled.Equal(req))
+	unmarshalled.Free()
+	req.Free()
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/textstream"
+)
+
+// prototextSpaces matches the random extra space that prototext inserts after
+// the field names to discourage byte comparisons.
+var prototextSpaces = regexp.MustCompile(`(?m)^(\s*\w+):  `)
+
+// googleText returns the multiline text format produced by prototext for the
+// message.
+func googleText(t *testing.T, src proto.Message) []byte {
+	b, err := prototext.MarshalOptions{Multiline: true}.Marshal(src)
+	require.NoError(t, err)
+	return prototextSpaces.ReplaceAll(b, []byte("$1: "))
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				wireBytes, err := proto.Marshal(src)
+				require.NoError(t, err)
+				expected := googleText(t, src)
+
+				forEachUnmarshalOpts(
+					t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+						m, err := test.unmarshal(wireBytes, opts)
+						require.NoError(t, err)
+
+						b, err := m.MarshalText()
+						require.NoError(t, err)
+						assert.Equal(t, string(expected), string(b))
+
+						// Text marshalling must not modify the message.
+						assert.False(t, m.IsModified())
+						assert.EqualValues(t, wireBytes, marshalLazy(t, m))
+						m.Free()
+					},
+				)
+			},
+		)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	for _, test := range formatTestCases {
+		test := test
+		t.Run(
+			test.name, func(t *testing.T) {
+				src := googleMessage(t, test.protoFile, test.msgName, test.text)
+				expected := googleText(t, src)
+
+				m := test.new()
+				require.NoError(t, m.UnmarshalText(expected))
+				assert.True(t, m.IsModified())
+				requireEqualGoogle(t, src, marshalLazy(t, m))
+
+				// The text produced by the unmarshalled message is the same.
+				b, err := m.MarshalText()
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), string(b))
+
+				// The original hand-written text is also accepted.
+				require.NoError(t, m.UnmarshalText([]byte(test.text)))
+				requireEqualGoogle(t, src, marshalLazy(t, m))
+				m.Free()
+			},
+		)
+	}
+}
+
+func TestMarshalTextSingleLine(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	m, err := lazy.UnmarshalRecord(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	tw := textstream.NewWriter(&buf, "")
+	require.NoError(t, m.MarshalTextStream(tw))
+	tw.End()
+	require.NoError(t, tw.Flush())
+	m.Free()
+
+	// The single line output can be parsed by prototext.
+	actual := src.ProtoReflect().New().Interface()
+	require.NoError(t, prototext.Unmarshal(buf.Bytes(), actual))
+	assert.True(t, proto.Equal(src, actual))
+	assert.NotContains(t, buf.String(), "\n")
+}
+
+func TestUnmarshalTextSyntax(t *testing.T) {
+	src := googleMessage(t, "imports.proto", "types.Record", recordText)
+
+	// Alternative delimiters, separators, lists, comments and enum numbers.
+	text := `
+# Comment.
+resource <
+  attributes: [{key: 'service' value: "front" "end"}, <key: "host"; value: "h1">]
+  min_severity: 1
+>
+attributes {key: "a", value: "1"},
+severity: SEVERITY_ERROR;
+attribute_map [{key: "x" value {key: "k" value: "v"}}]
+attribute_body: {key: "body" value: "\x74\145xt"}
+attribute {key: "single" value: "s"}
+`
+	m := lazy.NewRecord()
+	require.NoError(t, m.UnmarshalText([]byte(text)))
+	requireEqualGoogle(t, src, marshalLazy(t, m))
+	m.Free()
+}
+
+func TestUnmarshalTextInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "unknown field", text: `foo: 1`},
+		{name: "missing colon", text: `int32_value 1`},
+		{name: "wrong type", text: `int32_value: "abc"`},
+		{name: "int32 out of range", text: `int32_value: 2147483648`},
+		{name: "negative uint", text: `uint32_value: -1`},
+		{name: "float for int", text: `int32_value: 1.5`},
+		{name: "invalid utf8", text: `string_value: "\xff"`},
+		{name: "unterminated string", text: `string_value: "abc`},
+		{name: "extension", text: `[ext]: 1`},
+		{name: "unexpected close", text: `}`},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				m := lazy.NewScalars()
+				assert.Error(t, m.UnmarshalText([]byte(test.text)))
+				m.Free()
+			},
+		)
+	}
+
+	m := lazy.NewOneOfScalars()
+	assert.Error(t, m.UnmarshalText([]byte(`int32_value: 1 bool_value: true`)))
+	m.Free()
+
+	p := lazy.NewProto2Message()
+	assert.Error(t, p.UnmarshalText([]byte(`nested {}`)))
+	p.Free()
+
+	r := lazy.NewRecord()
+	assert.Error(t, r.UnmarshalText([]byte(`resource {`)))
+	r.Free()
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+// googleMessage creates a message using Google Protobuf library. The message type
+// is looked up by name in the specified proto file and the message is populated
+// from the text format representation.
+func googleMessage(t *testing.T, protoFile string, msgName string, text string) proto.Message {
+	p := protoparse.Parser{ImportPaths: []string{"."}}
+	fileDescrs, err := p.ParseFiles(protoFile)
+	require.NoError(t, err)
+
+	files, err := protodesc.NewFiles(desc.ToFileDescriptorSet(fileDescrs...))
+	require.NoError(t, err)
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(msgName))
+	require.NoError(t, err)
+
+	msg := dynamicpb.NewMessage(d.(protoreflect.MessageDescriptor))
+	// Allow missing proto2 required fields, so that invalid messages can be tested.
+	opts := prototext.UnmarshalOptions{AllowPartial: true}
+	require.NoError(t, opts.Unmarshal([]byte(text), msg))
+	return msg
+}
+
+// marshalLazy marshals the lazy message to wire bytes.
+func marshalLazy(t *testing.T, m lazyproto.Message) []byte {
+	ps := molecule.NewProtoStream()
+	require.NoError(t, m.Marshal(ps))
+	b, err := ps.BufferBytes()
+	require.NoError(t, err)
+	return b
+}
+
+// requireEqualGoogle unmarshals the wire bytes using Google Protobuf library and
+// checks that the result is equal to the expected message.
+func requireEqualGoogle(t *testing.T, expected proto.Message, wireBytes []byte) {
+	actual := expected.ProtoReflect().New().Interface()
+	require.NoError(t, proto.Unmarshal(wireBytes, actual))
+	require.True(
+		t, proto.Equal(expected, actual), "expected %v, got %v", expected, actual,
+	)
+}
+
+// forEachUnmarshalOpts runs the test function with and without validation.
+func forEachUnmarshalOpts(t *testing.T, f func(t *testing.T, opts lazyproto.UnmarshalOpts)) {
+	t.Run(
+		"validate", func(t *testing.T) {
+			f(t, lazyproto.UnmarshalOpts{WithValidate: true})
+		},
+	)
+	t.Run(
+		"novalidate", func(t *testing.T) {
+			f(t, lazyproto.UnmarshalOpts{WithValidate: false})
+		},
+	)
+}
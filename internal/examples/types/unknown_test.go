@@ -0,0 +1,106 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+)
+
+const knownFieldsV2Text = `
+name: "abc"
+nested: {value: 1 added_double: 2.5}
+added_int: 3
+added_string: "def"
+added_repeated: [4, 5]
+`
+
+func TestUnknownFieldsPreserved(t *testing.T) {
+	src := googleMessage(t, "unknown.proto", "types.KnownFieldsV2", knownFieldsV2Text)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			m, err := lazy.UnmarshalKnownFields(wireBytes, opts)
+			require.NoError(t, err)
+
+			assert.EqualValues(t, "abc", m.Name())
+			assert.EqualValues(t, 1, m.Nested().Value())
+			assert.NotEmpty(t, m.UnknownFields())
+			assert.NotEmpty(t, m.Nested().UnknownFields())
+
+			// Modify both the message and the nested message. Unknown fields
+			// must survive the re-encoding from the struct fields.
+			m.SetName("xyz")
+			m.Nested().SetValue(10)
+
+			expected := googleMessage(
+				t, "unknown.proto", "types.KnownFieldsV2", `
+name: "xyz"
+nested: {value: 10 added_double: 2.5}
+added_int: 3
+added_string: "def"
+added_repeated: [4, 5]
+`,
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestUnknownFieldsDiscarded(t *testing.T) {
+	src := googleMessage(t, "unknown.proto", "types.KnownFieldsV2", knownFieldsV2Text)
+	wireBytes, err := proto.Marshal(src)
+	require.NoError(t, err)
+
+	forEachUnmarshalOpts(
+		t, func(t *testing.T, opts lazyproto.UnmarshalOpts) {
+			opts.DiscardUnknown = true
+			m, err := lazy.UnmarshalKnownFields(wireBytes, opts)
+			require.NoError(t, err)
+
+			assert.Nil(t, m.UnknownFields())
+			assert.Nil(t, m.Nested().UnknownFields())
+
+			m.SetName("xyz")
+			m.Nested().SetValue(10)
+
+			expected := googleMessage(
+				t, "unknown.proto", "types.KnownFieldsV2", `
+name: "xyz"
+nested: {value: 10}
+`,
+			)
+			requireEqualGoogle(t, expected, marshalLazy(t, m))
+			m.Free()
+		},
+	)
+}
+
+func TestUnknownFieldsBytes(t *testing.T) {
+	marshal := func(text string) []byte {
+		b, err := proto.Marshal(googleMessage(t, "unknown.proto", "types.KnownFieldsV2", text))
+		require.NoError(t, err)
+		return b
+	}
+
+	// Unknown fields that are interleaved with known fields.
+	var wireBytes []byte
+	wireBytes = append(wireBytes, marshal(`added_int: 3`)...)
+	wireBytes = append(wireBytes, marshal(`name: "abc"`)...)
+	wireBytes = append(wireBytes, marshal(`added_string: "def"`)...)
+
+	m, err := lazy.UnmarshalKnownFields(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+
+	// The unknown fields are returned in their original order.
+	expected := append(marshal(`added_int: 3`), marshal(`added_string: "def"`)...)
+	assert.EqualValues(t, expected, m.UnknownFields())
+	m.Free()
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazy "github.com/tigrannajaryan/exp-lazyproto/internal/examples/types/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+func TestMarshalVectored(t *testing.T) {
+	// The resources are large enough to be referenced instead of being copied.
+	long := strings.Repeat("k", molecule.VectoredMinRawSize)
+	src := newExportRequest(long+"a", "b", long+"c")
+	wireBytes := marshalLazy(t, src)
+	src.Free()
+
+	req, err := lazy.UnmarshalExportRequest(wireBytes, lazyproto.UnmarshalOpts{})
+	require.NoError(t, err)
+	ps := molecule.NewProtoStream()
+
+	// The unmodified message is referenced as a whole.
+	buffers, err := lazyproto.MarshalVectored(req, ps)
+	require.NoError(t, err)
+	require.Len(t, buffers, 1)
+	assert.Same(t, &wireBytes[0], &buffers[0][0])
+
+	// Only the modified messages and the small resource are copied.
+	req.SetName("vectored")
+	expected := marshalLazy(t, req)
+	buffers, err = lazyproto.MarshalVectored(req, ps)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, bytes.Join(buffers, nil))
+	assert.EqualValues(t, len(expected), ps.Len())
+	referenced := 0
+	for _, b := range buffers {
+		for i := range wireBytes {
+			if &b[0] == &wireBytes[i] {
+				referenced++
+			}
+		}
+	}
+	assert.EqualValues(t, 2, referenced)
+
+	// The stream can be used in normal mode again.
+	ps.SetVectored(false)
+	ps.Reset()
+	require.NoError(t, req.Marshal(ps))
+	b, err := ps.BufferBytes()
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, b)
+	req.Free()
+}
@@ -0,0 +1,24 @@
+package lazyproto
+
+import (
+	"net"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+// MarshalVectored resets the stream, switches it to vectored mode and encodes the
+// message into it. The bytes of the unmodified messages are not copied, the returned
+// buffers reference them directly, so the bytes that the message was unmarshalled
+// from must not be modified while the buffers are used. Only the modified messages
+// and the length prefixes are encoded into the stream's buffer, which is valid until
+// the next Reset of the stream. See molecule.ProtoStream.SetVectored.
+//
+// The buffers can be written using writev by net.Buffers.WriteTo.
+func MarshalVectored(m Message, ps *molecule.ProtoStream) (net.Buffers, error) {
+	ps.Reset()
+	ps.SetVectored(true)
+	if err := m.Marshal(ps); err != nil {
+		return nil, err
+	}
+	return ps.Buffers(), nil
+}
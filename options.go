@@ -1,5 +1,70 @@
 package lazyproto
 
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
 type UnmarshalOpts struct {
 	WithValidate bool
+
+	// DiscardUnknown indicates that the fields that are unknown to the schema
+	// must not be preserved. By default unknown fields are preserved and are
+	// emitted by Marshal() even if the message is modified.
+	DiscardUnknown bool
+}
+
+// Message is implemented by all messages generated by lazyproto. It allows to write
+// utilities that work with messages of any type.
+type Message interface {
+	// Unmarshal replaces the content of the message by the message decoded from
+	// the Protobuf wire bytes. The nested messages that were previously referenced
+	// by the message are returned to their pools.
+	Unmarshal(bytes []byte, opts UnmarshalOpts) error
+
+	// Marshal encodes the message into the stream.
+	Marshal(ps *molecule.ProtoStream) error
+
+	// Size returns the number of bytes that Marshal writes for the message.
+	Size() int
+
+	// IsModified returns true if the message must be encoded field by field by
+	// Marshal, i.e. the message was modified since it was unmarshalled or was
+	// not unmarshalled at all.
+	IsModified() bool
+
+	// DecodeErr returns the first error of the lazy decoding of the message or of
+	// its nested messages that were accessed so far.
+	DecodeErr() error
+
+	// UnknownFields returns the wire representation of the fields that are not
+	// known to the schema of the message.
+	UnknownFields() []byte
+
+	// MarshalJSON returns the JSON representation of the message according to
+	// the proto3 JSON mapping.
+	MarshalJSON() ([]byte, error)
+
+	// UnmarshalJSON replaces the content of the message by the message read from
+	// its JSON representation.
+	UnmarshalJSON(b []byte) error
+
+	// MarshalText returns the text format representation of the message.
+	MarshalText() ([]byte, error)
+
+	// UnmarshalText replaces the content of the message by the message read from
+	// its text format representation.
+	UnmarshalText(b []byte) error
+
+	// ProtoReflect returns the reflective view of the message. It makes the message
+	// a proto.Message that can be used with google.golang.org/protobuf libraries.
+	ProtoReflect() protoreflect.Message
+
+	// CloneMessage returns a copy of the message. It is the same as the Clone()
+	// method of the generated message, but returns the copy as a Message.
+	CloneMessage() Message
+
+	// Free returns the message and its nested messages to their pools.
+	Free()
 }
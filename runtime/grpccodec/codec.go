@@ -0,0 +1,177 @@
+// Package grpccodec implements the gRPC codecs that unmarshal the messages
+// generated by lazyproto lazily and marshal them using pooled ProtoStreams. The
+// messages of other implementations are marshalled and unmarshalled the same way
+// as by the default gRPC proto codec, so the codecs can replace it.
+//
+// The codecs can be used for all RPCs of the process:
+//
+//	grpccodec.Register()
+//
+// or for particular servers and calls:
+//
+//	grpc.NewServer(grpc.ForceServerCodecV2(grpccodec.CodecV2{}))
+//	conn.Invoke(ctx, method, req, resp, grpc.ForceCodecV2(grpccodec.CodecV2{}))
+package grpccodec
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/mem"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protoconv"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
+)
+
+// Name is the name of the codecs. It is the same as the name of the default gRPC
+// proto codec, so the codecs are used for the "application/grpc+proto" content
+// subtype.
+const Name = "proto"
+
+// Register registers CodecV2 for all gRPC clients and servers of the process,
+// replacing the default proto codec. It must be called during initialization,
+// e.g. in an init function, as required by encoding.RegisterCodecV2.
+func Register() {
+	encoding.RegisterCodecV2(CodecV2{})
+}
+
+var protoStreamPool = sync.Pool{
+	New: func() any {
+		return molecule.NewProtoStream()
+	},
+}
+
+// marshalLazy marshals the message into a pooled ProtoStream and calls fn with
+// the encoded bytes, which are valid only until fn returns.
+func marshalLazy(m lazyproto.Message, fn func(b []byte)) error {
+	ps := protoStreamPool.Get().(*molecule.ProtoStream)
+	defer protoStreamPool.Put(ps)
+
+	ps.Reset()
+	if err := m.Marshal(ps); err != nil {
+		return err
+	}
+	b, err := ps.BufferBytes()
+	if err != nil {
+		return err
+	}
+	fn(b)
+	return nil
+}
+
+// Codec is the encoding.Codec for lazyproto messages.
+type Codec struct {
+	// UnmarshalOpts are the options used to unmarshal lazyproto messages.
+	UnmarshalOpts lazyproto.UnmarshalOpts
+}
+
+var _ encoding.Codec = Codec{}
+
+// Marshal returns the wire representation of v.
+func (c Codec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(lazyproto.Message)
+	if !ok {
+		return protoconv.Marshal(v)
+	}
+
+	var out []byte
+	err := marshalLazy(
+		m, func(b []byte) {
+			out = append([]byte(nil), b...)
+		},
+	)
+	return out, err
+}
+
+// Unmarshal replaces the content of v by the message decoded from data. A lazyproto
+// message references data until it is unmarshalled again or freed, which is safe
+// since gRPC does not reuse data after Unmarshal returns.
+func (c Codec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(lazyproto.Message)
+	if !ok {
+		return protoconv.Unmarshal(data, v)
+	}
+	return m.Unmarshal(data, c.UnmarshalOpts)
+}
+
+// Name returns the name of the codec.
+func (c Codec) Name() string {
+	return Name
+}
+
+// CodecV2 is the encoding.CodecV2 for lazyproto messages.
+type CodecV2 struct {
+	// UnmarshalOpts are the options used to unmarshal lazyproto messages.
+	UnmarshalOpts lazyproto.UnmarshalOpts
+}
+
+var _ encoding.CodecV2 = CodecV2{}
+
+// Marshal returns the wire representation of v. Large messages are copied into the
+// buffers of the default gRPC buffer pool.
+func (c CodecV2) Marshal(v any) (mem.BufferSlice, error) {
+	m, ok := v.(lazyproto.Message)
+	if !ok {
+		b, err := protoconv.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return mem.BufferSlice{mem.SliceBuffer(b)}, nil
+	}
+
+	var out mem.BufferSlice
+	err := marshalLazy(
+		m, func(b []byte) {
+			out = mem.BufferSlice{mem.Copy(b, mem.DefaultBufferPool())}
+		},
+	)
+	return out, err
+}
+
+// bytesOwnerHolder is implemented by the generated messages.
+type bytesOwnerHolder interface {
+	XXX_ProtoMessage() *protomessage.ProtoMessage
+}
+
+// Unmarshal replaces the content of v by the message decoded from data.
+//
+// A lazyproto message references the decoded bytes until it is unmarshalled again
+// or freed. If data consists of a single buffer the message references it directly
+// and the buffer is returned to its pool when the message is freed or unmarshalled
+// again. The clones of the message and of its nested messages keep the buffer alive
+// until they are freed too. The nested messages that are moved out of the message
+// reference the buffer without keeping it alive, so they must not be used after the
+// message is freed. If data consists of multiple buffers it is copied into a new
+// slice.
+func (c CodecV2) Unmarshal(data mem.BufferSlice, v any) error {
+	m, ok := v.(lazyproto.Message)
+	if !ok {
+		buf := data.MaterializeToBuffer(mem.DefaultBufferPool())
+		defer buf.Free()
+		return protoconv.Unmarshal(buf.ReadOnlyData(), v)
+	}
+
+	holder, ok := v.(bytesOwnerHolder)
+	if len(data) != 1 || !ok {
+		return m.Unmarshal(data.Materialize(), c.UnmarshalOpts)
+	}
+
+	// Keep the buffer alive after gRPC frees data.
+	buf := data[0]
+	buf.Ref()
+	if err := m.Unmarshal(buf.ReadOnlyData(), c.UnmarshalOpts); err != nil {
+		// The message is not used after the error. Don't keep the buffer alive
+		// for it, since it may never be freed.
+		buf.Free()
+		return err
+	}
+	holder.XXX_ProtoMessage().SetBytesOwner(buf)
+	return nil
+}
+
+// Name returns the name of the codec.
+func (c CodecV2) Name() string {
+	return Name
+}
@@ -0,0 +1,226 @@
+package grpccodec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	lazymsg "github.com/tigrannajaryan/exp-lazyproto/internal/examples/simple/lazy"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/grpccodec"
+)
+
+const attrCount = 100
+
+// newResource returns a message that is large enough for its wire bytes to be
+// stored in pooled buffers.
+func newResource() *lazymsg.Resource {
+	src := lazymsg.NewResource()
+	src.SetDroppedAttributesCount(123)
+	attrs := src.Attributes()
+	for i := 0; i < attrCount; i++ {
+		attrs.AppendNew().SetKey("host.name")
+	}
+	return src
+}
+
+func checkResource(t *testing.T, m *lazymsg.Resource) {
+	assert.EqualValues(t, 123, m.DroppedAttributesCount())
+	require.Equal(t, attrCount, m.Attributes().Len())
+	for i := 0; i < attrCount; i++ {
+		assert.Equal(t, "host.name", m.Attributes().At(i).Key())
+	}
+}
+
+// clearingPool is a mem.BufferPool that clears the buffers returned to it, so
+// that the bytes that are used after being freed are detected.
+type clearingPool struct{}
+
+func (clearingPool) Get(length int) *[]byte {
+	b := make([]byte, length)
+	return &b
+}
+
+func (clearingPool) Put(b *[]byte) {
+	for i := range *b {
+		(*b)[i] = 0
+	}
+}
+
+// countingPool is a mem.BufferPool that counts the buffers returned to it.
+type countingPool struct {
+	puts int
+}
+
+func (p *countingPool) Get(length int) *[]byte {
+	b := make([]byte, length)
+	return &b
+}
+
+func (p *countingPool) Put(*[]byte) {
+	p.puts++
+}
+
+func TestCodec(t *testing.T) {
+	var codec grpccodec.Codec
+	assert.Equal(t, "proto", codec.Name())
+
+	src := newResource()
+	b, err := codec.Marshal(src)
+	require.NoError(t, err)
+
+	dst := lazymsg.NewResource()
+	require.NoError(t, codec.Unmarshal(b, dst))
+	assert.False(t, dst.IsModified())
+	checkResource(t, dst)
+	dst.Free()
+	src.Free()
+}
+
+func TestCodecV2(t *testing.T) {
+	codec := grpccodec.CodecV2{UnmarshalOpts: lazyproto.UnmarshalOpts{WithValidate: true}}
+	assert.Equal(t, "proto", codec.Name())
+
+	src := newResource()
+	data, err := codec.Marshal(src)
+	require.NoError(t, err)
+	assert.False(t, mem.IsBelowBufferPoolingThreshold(data.Len()))
+	b := data.Materialize()
+	data.Free()
+	src.Free()
+
+	tests := []struct {
+		name string
+		data mem.BufferSlice
+	}{
+		{
+			name: "single buffer",
+			data: mem.BufferSlice{mem.Copy(b, clearingPool{})},
+		},
+		{
+			name: "multiple buffers",
+			data: mem.BufferSlice{
+				mem.Copy(b[:len(b)/2], clearingPool{}),
+				mem.Copy(b[len(b)/2:], clearingPool{}),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				dst := lazymsg.NewResource()
+				require.NoError(t, codec.Unmarshal(test.data, dst))
+				// gRPC frees the data after Unmarshal returns, the message must
+				// remain readable.
+				test.data.Free()
+				checkResource(t, dst)
+				dst.Free()
+			},
+		)
+	}
+}
+
+func TestCodecNotLazy(t *testing.T) {
+	src := &timestamppb.Timestamp{Seconds: 10, Nanos: 20}
+
+	var codec grpccodec.Codec
+	b, err := codec.Marshal(src)
+	require.NoError(t, err)
+	dst := &timestamppb.Timestamp{}
+	require.NoError(t, codec.Unmarshal(b, dst))
+	assert.True(t, proto.Equal(src, dst))
+
+	var codecV2 grpccodec.CodecV2
+	data, err := codecV2.Marshal(src)
+	require.NoError(t, err)
+	dst = &timestamppb.Timestamp{}
+	require.NoError(t, codecV2.Unmarshal(data, dst))
+	assert.True(t, proto.Equal(src, dst))
+
+	_, err = codec.Marshal(struct{}{})
+	assert.Error(t, err)
+}
+
+func TestCodecV2ReleasesBuffer(t *testing.T) {
+	codec := grpccodec.CodecV2{UnmarshalOpts: lazyproto.UnmarshalOpts{WithValidate: true}}
+
+	src := newResource()
+	data, err := codec.Marshal(src)
+	require.NoError(t, err)
+	b := data.Materialize()
+	data.Free()
+	src.Free()
+
+	// The buffer is returned to the pool when the message is freed.
+	var pool countingPool
+	data = mem.BufferSlice{mem.Copy(b, &pool)}
+	dst := lazymsg.NewResource()
+	require.NoError(t, codec.Unmarshal(data, dst))
+	data.Free()
+	assert.EqualValues(t, 0, pool.puts)
+	checkResource(t, dst)
+	dst.Free()
+	assert.EqualValues(t, 1, pool.puts)
+
+	// The buffer is returned to the pool when the message is unmarshalled again.
+	data = mem.BufferSlice{mem.Copy(b, &pool)}
+	dst = lazymsg.NewResource()
+	require.NoError(t, codec.Unmarshal(data, dst))
+	data.Free()
+	require.NoError(t, dst.Unmarshal(b, lazyproto.UnmarshalOpts{}))
+	assert.EqualValues(t, 2, pool.puts)
+	dst.Free()
+	assert.EqualValues(t, 2, pool.puts)
+
+	// The buffer is returned to the pool if the message fails to unmarshal.
+	data = mem.BufferSlice{mem.Copy(b[:len(b)-1], &pool)}
+	dst = lazymsg.NewResource()
+	require.Error(t, codec.Unmarshal(data, dst))
+	data.Free()
+	assert.EqualValues(t, 3, pool.puts)
+	dst.Free()
+	assert.EqualValues(t, 3, pool.puts)
+}
+
+func TestCodecV2Clone(t *testing.T) {
+	codec := grpccodec.CodecV2{}
+
+	src := newResource()
+	data, err := codec.Marshal(src)
+	require.NoError(t, err)
+	b := data.Materialize()
+	data.Free()
+	src.Free()
+
+	// The clones use the buffer after the message is freed.
+	data = mem.BufferSlice{mem.Copy(b, clearingPool{})}
+	dst := lazymsg.NewResource()
+	require.NoError(t, codec.Unmarshal(data, dst))
+	data.Free()
+	clone := dst.Clone()
+	attrClone := dst.Attributes().At(0).Clone()
+	dst.Free()
+	checkResource(t, clone)
+	assert.Equal(t, "host.name", attrClone.Key())
+	clone.Free()
+	attrClone.Free()
+
+	// The buffer is returned to the pool when the message and all its clones are
+	// freed.
+	var pool countingPool
+	data = mem.BufferSlice{mem.Copy(b, &pool)}
+	dst = lazymsg.NewResource()
+	require.NoError(t, codec.Unmarshal(data, dst))
+	data.Free()
+	clone = dst.Clone()
+	attrClone = dst.Attributes().At(0).Clone()
+	dst.Free()
+	clone.Free()
+	assert.EqualValues(t, 0, pool.puts)
+	attrClone.Free()
+	assert.EqualValues(t, 1, pool.puts)
+}
@@ -0,0 +1,209 @@
+package jsonstream
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterValues(t *testing.T) {
+	js := NewWriter(nil)
+	js.BeginObject()
+	js.Name("a")
+	js.Int32(-1)
+	js.Name("b")
+	js.Uint64(math.MaxUint64)
+	js.Name("c")
+	js.BeginArray()
+	js.Bool(true)
+	js.Null()
+	js.Bytes([]byte{0xff, 0xfe})
+	js.EndArray()
+	js.Name("d")
+	js.BeginObject()
+	js.Int64Name(-5)
+	js.Enum(1, map[uint32]string{1: "ONE"})
+	js.BoolName(false)
+	js.Enum(2, map[uint32]string{1: "ONE"})
+	js.EndObject()
+	js.EndObject()
+
+	b, err := js.BufferBytes()
+	require.NoError(t, err)
+	assert.Equal(
+		t, `{"a":-1,"b":"18446744073709551615","c":[true,null,"//4="],"d":{"-5":"ONE","false":2}}`,
+		string(b),
+	)
+}
+
+func TestWriterFloats(t *testing.T) {
+	tests := []struct {
+		value    float64
+		bitSize  int
+		expected string
+	}{
+		{value: 0, bitSize: 64, expected: `0`},
+		{value: math.Copysign(0, -1), bitSize: 64, expected: `-0`},
+		{value: 1.5, bitSize: 64, expected: `1.5`},
+		{value: 1e-7, bitSize: 64, expected: `1e-7`},
+		{value: 1e21, bitSize: 64, expected: `1e+21`},
+		{value: 1e20, bitSize: 64, expected: `100000000000000000000`},
+		{value: 0.1, bitSize: 32, expected: `0.1`},
+		{value: math.NaN(), bitSize: 64, expected: `"NaN"`},
+		{value: math.Inf(1), bitSize: 32, expected: `"Infinity"`},
+		{value: math.Inf(-1), bitSize: 64, expected: `"-Infinity"`},
+	}
+	for _, test := range tests {
+		js := NewWriter(nil)
+		if test.bitSize == 32 {
+			js.Float(float32(test.value))
+		} else {
+			js.Double(test.value)
+		}
+		b, err := js.BufferBytes()
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, string(b))
+	}
+}
+
+func TestWriterString(t *testing.T) {
+	js := NewWriter(nil)
+	js.String("a\"\\\n\t\x01\x1fé<>& ")
+	b, err := js.BufferBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `"a\"\\\n\t\u0001\u001fé<>&`+" "+`"`, string(b))
+
+	js.Reset(nil)
+	js.String("\xff")
+	_, err = js.BufferBytes()
+	assert.Error(t, err)
+}
+
+func TestWriterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	js := NewWriter(&buf)
+	js.BeginArray()
+	for i := 0; i < 1000; i++ {
+		js.BeginObject()
+		js.Name("value")
+		js.Int32(int32(i))
+		js.EndObject()
+	}
+	js.EndArray()
+	// Some of the output must be already written before Flush.
+	assert.Greater(t, buf.Len(), 0)
+	require.NoError(t, js.Flush())
+	assert.True(t, strings.HasSuffix(buf.String(), `{"value":999}]`))
+}
+
+func TestReader(t *testing.T) {
+	r := NewReader(
+		[]byte(` { "a" : [1, "2", 3e0, -4.0], "b":"xé😀\n", "c": null,
+"d":{"e":true}, "f": "//4", "g": "NaN", "h": "ONE", "i": 2 } `),
+	)
+	require.NoError(t, r.BeginObject())
+
+	name, ok, err := r.NextField()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "a", name)
+	require.NoError(t, r.BeginArray())
+	var ints []int64
+	for {
+		ok, err := r.NextElem()
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		v, err := r.ReadInt64()
+		require.NoError(t, err)
+		ints = append(ints, v)
+	}
+	assert.Equal(t, []int64{1, 2, 3, -4}, ints)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+	s, err := r.ReadString()
+	require.NoError(t, err)
+	assert.Equal(t, "xé😀\n", s)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "c", name)
+	assert.True(t, r.ReadNull())
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "d", name)
+	require.NoError(t, r.Skip())
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "f", name)
+	b, err := r.ReadBytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xfe}, b)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "g", name)
+	f, err := r.ReadDouble()
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(f))
+
+	values := map[string]uint32{"ONE": 1}
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "h", name)
+	e, err := r.ReadEnum(values)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, e)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "i", name)
+	e, err = r.ReadEnum(values)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, e)
+
+	_, ok, err = r.NextField()
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, r.End())
+}
+
+func TestReaderIntErrors(t *testing.T) {
+	for _, s := range []string{`2147483648`, `1.5`, `"abc"`, `true`, `1e10`} {
+		_, err := NewReader([]byte(s)).ReadInt32()
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{`-1`, `18446744073709551616`} {
+		_, err := NewReader([]byte(s)).ReadUint64()
+		assert.Error(t, err, s)
+	}
+	_, err := NewReader([]byte(`1e39`)).ReadFloat()
+	assert.Error(t, err)
+}
+
+func TestParseKeys(t *testing.T) {
+	b, err := ParseBoolKey("true")
+	require.NoError(t, err)
+	assert.True(t, b)
+	_, err = ParseBoolKey("1")
+	assert.Error(t, err)
+
+	i, err := ParseInt32Key("-32")
+	require.NoError(t, err)
+	assert.EqualValues(t, -32, i)
+	_, err = ParseInt32Key("2147483648")
+	assert.Error(t, err)
+
+	u, err := ParseUint64Key("18446744073709551615")
+	require.NoError(t, err)
+	assert.EqualValues(t, uint64(math.MaxUint64), u)
+}
@@ -0,0 +1,545 @@
+package jsonstream
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Reader reads JSON values from a byte slice. The values are read in the order
+// they appear in the input. Objects are read by calling BeginObject() and then
+// NextField() until it returns false, arrays are read by calling BeginArray() and
+// then NextElem() until it returns false.
+//
+// The numeric values are accepted both as JSON numbers and as JSON strings, as
+// required by the proto3 JSON mapping.
+type Reader struct {
+	buf []byte
+	pos int
+
+	// needComma is true if a value was completed and the next field or element
+	// must be preceded by a comma.
+	needComma bool
+}
+
+// NewReader creates a Reader that reads from b.
+func NewReader(b []byte) *Reader {
+	return &Reader{buf: b}
+}
+
+// Reset makes the Reader read from b.
+func (r *Reader) Reset(b []byte) {
+	r.buf = b
+	r.pos = 0
+	r.needComma = false
+}
+
+func (r *Reader) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid JSON at offset %d: %s", r.pos, fmt.Sprintf(format, a...))
+}
+
+func (r *Reader) skipWhitespace() {
+	for r.pos < len(r.buf) {
+		switch r.buf[r.pos] {
+		case ' ', '\t', '\n', '\r':
+			r.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next non-whitespace byte or 0 if the end of input is reached.
+func (r *Reader) peek() byte {
+	r.skipWhitespace()
+	if r.pos < len(r.buf) {
+		return r.buf[r.pos]
+	}
+	return 0
+}
+
+func (r *Reader) expect(c byte) error {
+	if r.peek() != c {
+		return r.unexpected()
+	}
+	r.pos++
+	return nil
+}
+
+func (r *Reader) unexpected() error {
+	if r.pos >= len(r.buf) {
+		return r.errorf("unexpected end of input")
+	}
+	return r.errorf("unexpected character %q", r.buf[r.pos])
+}
+
+// End checks that there is nothing but whitespace after the last read value.
+func (r *Reader) End() error {
+	if r.peek() != 0 {
+		return r.unexpected()
+	}
+	return nil
+}
+
+// BeginObject reads the start of an object.
+func (r *Reader) BeginObject() error {
+	if err := r.expect('{'); err != nil {
+		return err
+	}
+	r.needComma = false
+	return nil
+}
+
+// NextField reads the name of the next member of the object. Returns false if
+// the end of the object is reached. The value of the member must be read or
+// skipped before calling NextField() again.
+func (r *Reader) NextField() (name string, ok bool, err error) {
+	if r.peek() == '}' {
+		r.pos++
+		r.needComma = true
+		return "", false, nil
+	}
+	if r.needComma {
+		if err := r.expect(','); err != nil {
+			return "", false, err
+		}
+	}
+	if r.peek() != '"' {
+		return "", false, r.unexpected()
+	}
+	name, err = r.readString()
+	if err != nil {
+		return "", false, err
+	}
+	if err := r.expect(':'); err != nil {
+		return "", false, err
+	}
+	r.needComma = false
+	return name, true, nil
+}
+
+// BeginArray reads the start of an array.
+func (r *Reader) BeginArray() error {
+	if err := r.expect('['); err != nil {
+		return err
+	}
+	r.needComma = false
+	return nil
+}
+
+// NextElem prepares to read the next element of the array. Returns false if the
+// end of the array is reached.
+func (r *Reader) NextElem() (ok bool, err error) {
+	if r.peek() == ']' {
+		r.pos++
+		r.needComma = true
+		return false, nil
+	}
+	if r.needComma {
+		if err := r.expect(','); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ReadNull reads null and returns true if the next value is null. Otherwise,
+// nothing is read and false is returned.
+func (r *Reader) ReadNull() bool {
+	r.skipWhitespace()
+	if r.readLiteral("null") {
+		r.needComma = true
+		return true
+	}
+	return false
+}
+
+func (r *Reader) readLiteral(literal string) bool {
+	if !bytes.HasPrefix(r.buf[r.pos:], []byte(literal)) {
+		return false
+	}
+	r.pos += len(literal)
+	return true
+}
+
+// ReadBool reads a bool value.
+func (r *Reader) ReadBool() (bool, error) {
+	r.skipWhitespace()
+	r.needComma = true
+	switch {
+	case r.readLiteral("true"):
+		return true, nil
+	case r.readLiteral("false"):
+		return false, nil
+	}
+	return false, r.unexpected()
+}
+
+// ReadString reads a string value.
+func (r *Reader) ReadString() (string, error) {
+	if r.peek() != '"' {
+		return "", r.unexpected()
+	}
+	r.needComma = true
+	return r.readString()
+}
+
+// ReadBytes reads a bytes value, which is a base64 string. Both standard and
+// URL-safe encodings are accepted, with or without padding.
+func (r *Reader) ReadBytes() ([]byte, error) {
+	s, err := r.ReadString()
+	if err != nil {
+		return nil, err
+	}
+
+	enc := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.URLEncoding
+	}
+	if len(s)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, r.errorf("invalid base64 value: %v", err)
+	}
+	return b, nil
+}
+
+// ReadInt32 reads a 32-bit signed integer value.
+func (r *Reader) ReadInt32() (int32, error) {
+	v, err := r.readInt(32)
+	return int32(v), err
+}
+
+// ReadInt64 reads a 64-bit signed integer value.
+func (r *Reader) ReadInt64() (int64, error) {
+	return r.readInt(64)
+}
+
+// ReadUint32 reads a 32-bit unsigned integer value.
+func (r *Reader) ReadUint32() (uint32, error) {
+	v, err := r.readUint(32)
+	return uint32(v), err
+}
+
+// ReadUint64 reads a 64-bit unsigned integer value.
+func (r *Reader) ReadUint64() (uint64, error) {
+	return r.readUint(64)
+}
+
+// ReadFloat reads a float value.
+func (r *Reader) ReadFloat() (float32, error) {
+	v, err := r.readFloat(32)
+	return float32(v), err
+}
+
+// ReadDouble reads a double value.
+func (r *Reader) ReadDouble() (float64, error) {
+	return r.readFloat(64)
+}
+
+// ReadEnum reads an enum value, which is either the name of the value or a number.
+func (r *Reader) ReadEnum(values map[string]uint32) (uint32, error) {
+	if r.peek() != '"' {
+		v, err := r.ReadInt32()
+		return uint32(v), err
+	}
+	name, err := r.ReadString()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := values[name]
+	if !ok {
+		return 0, r.errorf("invalid enum value %q", name)
+	}
+	return v, nil
+}
+
+func (r *Reader) readInt(bitSize int) (int64, error) {
+	s, _, err := r.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(s, 10, bitSize)
+	if err == nil {
+		return v, nil
+	}
+
+	// The value may be in the exponent or fraction form, e.g. "1e2" or "1.0".
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) ||
+		f < -math.Ldexp(1, bitSize-1) || f >= math.Ldexp(1, bitSize-1) {
+		return 0, r.errorf("invalid %d-bit integer value %s", bitSize, s)
+	}
+	return int64(f), nil
+}
+
+func (r *Reader) readUint(bitSize int) (uint64, error) {
+	s, _, err := r.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(s, 10, bitSize)
+	if err == nil {
+		return v, nil
+	}
+
+	// The value may be in the exponent or fraction form, e.g. "1e2" or "1.0".
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < 0 || f >= math.Ldexp(1, bitSize) {
+		return 0, r.errorf("invalid %d-bit unsigned integer value %s", bitSize, s)
+	}
+	return uint64(f), nil
+}
+
+func (r *Reader) readFloat(bitSize int) (float64, error) {
+	s, quoted, err := r.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	if quoted {
+		switch s {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+	}
+	v, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, r.errorf("invalid %d-bit float value %s", bitSize, s)
+	}
+	return v, nil
+}
+
+// readNumber reads a number that is either a JSON number or a JSON string and
+// returns its text.
+func (r *Reader) readNumber() (s string, quoted bool, err error) {
+	if r.peek() == '"' {
+		r.needComma = true
+		s, err = r.readString()
+		return s, true, err
+	}
+
+	start := r.pos
+	for r.pos < len(r.buf) {
+		c := r.buf[r.pos]
+		if (c < '0' || c > '9') && c != '-' && c != '+' && c != '.' && c != 'e' && c != 'E' {
+			break
+		}
+		r.pos++
+	}
+	if r.pos == start {
+		return "", false, r.unexpected()
+	}
+	r.needComma = true
+	return string(r.buf[start:r.pos]), false, nil
+}
+
+// readString reads the quoted string at the current position and unescapes it.
+func (r *Reader) readString() (string, error) {
+	// Skip the opening quote.
+	r.pos++
+
+	// Fast path: no escape sequences.
+	start := r.pos
+	for r.pos < len(r.buf) {
+		c := r.buf[r.pos]
+		if c == '"' {
+			s := string(r.buf[start:r.pos])
+			r.pos++
+			if !utf8.ValidString(s) {
+				return "", r.errorf("invalid UTF-8 in string")
+			}
+			return s, nil
+		}
+		if c == '\\' || c < ' ' {
+			break
+		}
+		r.pos++
+	}
+
+	// Slow path: unescape.
+	b := make([]byte, r.pos-start, r.pos-start+16)
+	copy(b, r.buf[start:r.pos])
+	for r.pos < len(r.buf) {
+		c := r.buf[r.pos]
+		switch {
+		case c == '"':
+			r.pos++
+			if !utf8.Valid(b) {
+				return "", r.errorf("invalid UTF-8 in string")
+			}
+			return string(b), nil
+		case c < ' ':
+			return "", r.errorf("invalid control character %q in string", c)
+		case c != '\\':
+			b = append(b, c)
+			r.pos++
+			continue
+		}
+
+		// Escape sequence.
+		if r.pos+1 >= len(r.buf) {
+			break
+		}
+		r.pos++
+		switch r.buf[r.pos] {
+		case '"', '\\', '/':
+			b = append(b, r.buf[r.pos])
+		case 'b':
+			b = append(b, '\b')
+		case 'f':
+			b = append(b, '\f')
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case 'u':
+			rn, ok := r.readHex4()
+			if !ok {
+				return "", r.errorf("invalid escape sequence in string")
+			}
+			if utf16.IsSurrogate(rn) {
+				// Must be followed by the second half of the surrogate pair.
+				r2 := utf8.RuneError
+				if r.pos+2 < len(r.buf) && r.buf[r.pos+1] == '\\' && r.buf[r.pos+2] == 'u' {
+					r.pos += 2
+					r2, ok = r.readHex4()
+					if !ok {
+						return "", r.errorf("invalid escape sequence in string")
+					}
+				}
+				rn = utf16.DecodeRune(rn, r2)
+			}
+			b = utf8.AppendRune(b, rn)
+		default:
+			return "", r.errorf("invalid escape sequence in string")
+		}
+		r.pos++
+	}
+	return "", r.errorf("unterminated string")
+}
+
+// readHex4 reads the 4 hex digits following the current position and leaves
+// the position at the last digit.
+func (r *Reader) readHex4() (rune, bool) {
+	if r.pos+4 >= len(r.buf) {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(r.buf[r.pos+1:r.pos+5]), 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	r.pos += 4
+	return rune(v), true
+}
+
+// Skip skips the next value, including nested objects and arrays.
+func (r *Reader) Skip() error {
+	switch c := r.peek(); c {
+	case '{':
+		if err := r.BeginObject(); err != nil {
+			return err
+		}
+		for {
+			_, ok, err := r.NextField()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	case '[':
+		if err := r.BeginArray(); err != nil {
+			return err
+		}
+		for {
+			ok, err := r.NextElem()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	case '"':
+		_, err := r.ReadString()
+		return err
+	case 't', 'f':
+		_, err := r.ReadBool()
+		return err
+	case 'n':
+		if !r.ReadNull() {
+			return r.unexpected()
+		}
+		return nil
+	default:
+		_, _, err := r.readNumber()
+		return err
+	}
+}
+
+// ParseBoolKey parses the map key of bool type.
+func ParseBoolKey(s string) (bool, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid bool map key %q", s)
+}
+
+// ParseInt32Key parses the map key of 32-bit signed integer type.
+func ParseInt32Key(s string) (int32, error) {
+	v, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int32 map key %q", s)
+	}
+	return int32(v), nil
+}
+
+// ParseInt64Key parses the map key of 64-bit signed integer type.
+func ParseInt64Key(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int64 map key %q", s)
+	}
+	return v, nil
+}
+
+// ParseUint32Key parses the map key of 32-bit unsigned integer type.
+func ParseUint32Key(s string) (uint32, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid uint32 map key %q", s)
+	}
+	return uint32(v), nil
+}
+
+// ParseUint64Key parses the map key of 64-bit unsigned integer type.
+func ParseUint64Key(s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid uint64 map key %q", s)
+	}
+	return v, nil
+}
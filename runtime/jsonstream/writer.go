@@ -0,0 +1,302 @@
+// Package jsonstream implements the reading and writing of the JSON representation
+// of the messages according to the proto3 JSON mapping. The output is compatible
+// with the output of google.golang.org/protobuf/encoding/protojson. The package is
+// used by the code generated by lazyproto.
+package jsonstream
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"strconv"
+	"unicode/utf8"
+)
+
+var errInvalidUTF8 = errors.New("string field contains invalid UTF-8")
+
+// flushThreshold is the size of the buffered output after which the Writer
+// writes the buffer to the underlying io.Writer.
+const flushThreshold = 4096
+
+// Writer writes JSON values. The commas between the values are inserted
+// automatically. The output is compact, i.e. contains no whitespace.
+//
+// The write methods don't return errors. The first error that happens is
+// remembered and is returned by Flush() or BufferBytes().
+type Writer struct {
+	w   io.Writer
+	buf []byte
+
+	// needComma is true if a value was completed and the next value or name
+	// must be preceded by a comma.
+	needComma bool
+
+	err error
+}
+
+// NewWriter creates a Writer that writes to w. If w is nil the output is
+// accumulated in the buffer and can be obtained using BufferBytes().
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Reset discards the buffered output and the error and makes the Writer write to w.
+// The buffer is reused.
+func (js *Writer) Reset(w io.Writer) {
+	js.w = w
+	js.buf = js.buf[:0]
+	js.needComma = false
+	js.err = nil
+}
+
+// BufferBytes returns the accumulated output. The returned slice is valid until
+// the next write or Reset.
+func (js *Writer) BufferBytes() ([]byte, error) {
+	return js.buf, js.err
+}
+
+// Flush writes the buffered output to the underlying io.Writer.
+func (js *Writer) Flush() error {
+	if js.err != nil || js.w == nil || len(js.buf) == 0 {
+		return js.err
+	}
+	_, js.err = js.w.Write(js.buf)
+	js.buf = js.buf[:0]
+	return js.err
+}
+
+func (js *Writer) maybeFlush() {
+	if js.w != nil && len(js.buf) >= flushThreshold {
+		_ = js.Flush()
+	}
+}
+
+// beginValue inserts the comma before the value if necessary.
+func (js *Writer) beginValue() {
+	if js.needComma {
+		js.buf = append(js.buf, ',')
+	}
+}
+
+// BeginObject writes the start of an object.
+func (js *Writer) BeginObject() {
+	js.beginValue()
+	js.buf = append(js.buf, '{')
+	js.needComma = false
+}
+
+// EndObject writes the end of an object.
+func (js *Writer) EndObject() {
+	js.buf = append(js.buf, '}')
+	js.needComma = true
+	js.maybeFlush()
+}
+
+// BeginArray writes the start of an array.
+func (js *Writer) BeginArray() {
+	js.beginValue()
+	js.buf = append(js.buf, '[')
+	js.needComma = false
+}
+
+// EndArray writes the end of an array.
+func (js *Writer) EndArray() {
+	js.buf = append(js.buf, ']')
+	js.needComma = true
+	js.maybeFlush()
+}
+
+// Name writes the name of an object member. Must be followed by the value.
+func (js *Writer) Name(name string) {
+	js.beginValue()
+	js.appendString(name)
+	js.buf = append(js.buf, ':')
+	js.needComma = false
+}
+
+// Int64Name writes the integer map key as the name of an object member.
+func (js *Writer) Int64Name(k int64) {
+	js.Name(strconv.FormatInt(k, 10))
+}
+
+// Uint64Name writes the unsigned integer map key as the name of an object member.
+func (js *Writer) Uint64Name(k uint64) {
+	js.Name(strconv.FormatUint(k, 10))
+}
+
+// BoolName writes the bool map key as the name of an object member.
+func (js *Writer) BoolName(k bool) {
+	js.Name(strconv.FormatBool(k))
+}
+
+// Null writes null.
+func (js *Writer) Null() {
+	js.beginValue()
+	js.buf = append(js.buf, "null"...)
+	js.needComma = true
+}
+
+// Bool writes a bool value.
+func (js *Writer) Bool(v bool) {
+	js.beginValue()
+	js.buf = strconv.AppendBool(js.buf, v)
+	js.needComma = true
+}
+
+// Int32 writes a 32-bit signed integer as a number.
+func (js *Writer) Int32(v int32) {
+	js.beginValue()
+	js.buf = strconv.AppendInt(js.buf, int64(v), 10)
+	js.needComma = true
+}
+
+// Uint32 writes a 32-bit unsigned integer as a number.
+func (js *Writer) Uint32(v uint32) {
+	js.beginValue()
+	js.buf = strconv.AppendUint(js.buf, uint64(v), 10)
+	js.needComma = true
+}
+
+// Int64 writes a 64-bit signed integer as a string, as required by the proto3
+// JSON mapping.
+func (js *Writer) Int64(v int64) {
+	js.beginValue()
+	js.buf = append(js.buf, '"')
+	js.buf = strconv.AppendInt(js.buf, v, 10)
+	js.buf = append(js.buf, '"')
+	js.needComma = true
+}
+
+// Uint64 writes a 64-bit unsigned integer as a string, as required by the proto3
+// JSON mapping.
+func (js *Writer) Uint64(v uint64) {
+	js.beginValue()
+	js.buf = append(js.buf, '"')
+	js.buf = strconv.AppendUint(js.buf, v, 10)
+	js.buf = append(js.buf, '"')
+	js.needComma = true
+}
+
+// Float writes a float value.
+func (js *Writer) Float(v float32) {
+	js.beginValue()
+	js.appendFloat(float64(v), 32)
+	js.needComma = true
+}
+
+// Double writes a double value.
+func (js *Writer) Double(v float64) {
+	js.beginValue()
+	js.appendFloat(v, 64)
+	js.needComma = true
+}
+
+// String writes a string value. The string must be valid UTF-8.
+func (js *Writer) String(v string) {
+	js.beginValue()
+	js.appendString(v)
+	js.needComma = true
+}
+
+// Bytes writes a bytes value as a base64 string with padding.
+func (js *Writer) Bytes(v []byte) {
+	js.beginValue()
+	js.buf = append(js.buf, '"')
+	n := len(js.buf)
+	js.buf = append(js.buf, make([]byte, base64.StdEncoding.EncodedLen(len(v)))...)
+	base64.StdEncoding.Encode(js.buf[n:], v)
+	js.buf = append(js.buf, '"')
+	js.needComma = true
+}
+
+// Enum writes the enum value as the name of the value. If the value is not
+// found in names, it is written as a number.
+func (js *Writer) Enum(v uint32, names map[uint32]string) {
+	if name, ok := names[v]; ok {
+		js.String(name)
+		return
+	}
+	js.Int32(int32(v))
+}
+
+// appendFloat formats the float the same way as protojson does, which in turn
+// follows the formatting of encoding/json.
+func (js *Writer) appendFloat(v float64, bitSize int) {
+	switch {
+	case math.IsNaN(v):
+		js.buf = append(js.buf, `"NaN"`...)
+		return
+	case math.IsInf(v, 1):
+		js.buf = append(js.buf, `"Infinity"`...)
+		return
+	case math.IsInf(v, -1):
+		js.buf = append(js.buf, `"-Infinity"`...)
+		return
+	}
+
+	format := byte('f')
+	if abs := math.Abs(v); abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	js.buf = strconv.AppendFloat(js.buf, v, format, -1, bitSize)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(js.buf)
+		if n >= 4 && js.buf[n-4] == 'e' && js.buf[n-3] == '-' && js.buf[n-2] == '0' {
+			js.buf[n-2] = js.buf[n-1]
+			js.buf = js.buf[:n-1]
+		}
+	}
+}
+
+// appendString writes the quoted and escaped string. Invalid UTF-8 results in
+// an error.
+func (js *Writer) appendString(s string) {
+	js.buf = append(js.buf, '"')
+	for len(s) > 0 {
+		// Copy the run of the characters that don't need escaping as is.
+		i := 0
+		for i < len(s) && s[i] >= ' ' && s[i] != '"' && s[i] != '\\' && s[i] < utf8.RuneSelf {
+			i++
+		}
+		js.buf = append(js.buf, s[:i]...)
+		s = s[i:]
+		if len(s) == 0 {
+			break
+		}
+
+		r, n := utf8.DecodeRuneInString(s)
+		switch {
+		case r == utf8.RuneError && n == 1:
+			if js.err == nil {
+				js.err = errInvalidUTF8
+			}
+			js.buf = append(js.buf, s[0])
+		case r >= utf8.RuneSelf:
+			js.buf = append(js.buf, s[:n]...)
+		case r == '"' || r == '\\':
+			js.buf = append(js.buf, '\\', byte(r))
+		case r == '\b':
+			js.buf = append(js.buf, '\\', 'b')
+		case r == '\f':
+			js.buf = append(js.buf, '\\', 'f')
+		case r == '\n':
+			js.buf = append(js.buf, '\\', 'n')
+		case r == '\r':
+			js.buf = append(js.buf, '\\', 'r')
+		case r == '\t':
+			js.buf = append(js.buf, '\\', 't')
+		default:
+			js.buf = append(js.buf, '\\', 'u')
+			js.buf = append(js.buf, "0000"[1+(bits.Len32(uint32(r))-1)/4:]...)
+			js.buf = strconv.AppendUint(js.buf, uint64(r), 16)
+		}
+		s = s[n:]
+	}
+	js.buf = append(js.buf, '"')
+}
@@ -0,0 +1,100 @@
+package lazyreflect
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	lazyproto "github.com/tigrannajaryan/exp-lazyproto"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
+)
+
+// Conv converts between the Go type V of the field of the generated message and
+// protoreflect.Value.
+type Conv[V any] struct {
+	ToValue   func(v V) protoreflect.Value
+	FromValue func(v protoreflect.Value) V
+}
+
+// The converters of the scalar types.
+var (
+	BoolConv = Conv[bool]{
+		ToValue:   protoreflect.ValueOfBool,
+		FromValue: protoreflect.Value.Bool,
+	}
+	Int32Conv = Conv[int32]{
+		ToValue:   protoreflect.ValueOfInt32,
+		FromValue: func(v protoreflect.Value) int32 { return int32(v.Int()) },
+	}
+	Int64Conv = Conv[int64]{
+		ToValue:   protoreflect.ValueOfInt64,
+		FromValue: protoreflect.Value.Int,
+	}
+	Uint32Conv = Conv[uint32]{
+		ToValue:   protoreflect.ValueOfUint32,
+		FromValue: func(v protoreflect.Value) uint32 { return uint32(v.Uint()) },
+	}
+	Uint64Conv = Conv[uint64]{
+		ToValue:   protoreflect.ValueOfUint64,
+		FromValue: protoreflect.Value.Uint,
+	}
+	FloatConv = Conv[float32]{
+		ToValue:   protoreflect.ValueOfFloat32,
+		FromValue: func(v protoreflect.Value) float32 { return float32(v.Float()) },
+	}
+	DoubleConv = Conv[float64]{
+		ToValue:   protoreflect.ValueOfFloat64,
+		FromValue: protoreflect.Value.Float,
+	}
+	StringConv = Conv[string]{
+		ToValue:   protoreflect.ValueOfString,
+		FromValue: protoreflect.Value.String,
+	}
+	BytesConv = Conv[[]byte]{
+		ToValue:   protoreflect.ValueOfBytes,
+		FromValue: protoreflect.Value.Bytes,
+	}
+)
+
+// EnumConv returns the converter of the enum type E.
+func EnumConv[E ~uint32]() Conv[E] {
+	return Conv[E]{
+		ToValue: func(v E) protoreflect.Value {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v))
+		},
+		FromValue: func(v protoreflect.Value) E {
+			return E(v.Enum())
+		},
+	}
+}
+
+// Message is the constraint satisfied by the pointers to the generated messages.
+type Message interface {
+	protoreflect.ProtoMessage
+	Unmarshal(bytes []byte, opts lazyproto.UnmarshalOpts) error
+	XXX_ProtoMessage() *protomessage.ProtoMessage
+}
+
+// ValueOfMessage returns the value that refers to the message m. m may be nil.
+func ValueOfMessage(m protoreflect.ProtoMessage) protoreflect.Value {
+	return protoreflect.ValueOfMessage(m.ProtoReflect())
+}
+
+// MessageFromValue returns the generated message that v refers to. If v refers
+// to a message of another implementation, e.g. to a dynamicpb message, the
+// message is copied to a new message created by newMsg.
+func MessageFromValue[P Message](v protoreflect.Value, newMsg func() P) P {
+	src := v.Message()
+	if p, ok := src.Interface().(P); ok {
+		return p
+	}
+
+	b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(src.Interface())
+	if err != nil {
+		panic(err)
+	}
+	p := newMsg()
+	if err := p.Unmarshal(b, lazyproto.UnmarshalOpts{}); err != nil {
+		panic(err)
+	}
+	return p
+}
@@ -0,0 +1,20 @@
+package lazyreflect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestConv(t *testing.T) {
+	assert.EqualValues(t, -5, Int32Conv.FromValue(Int32Conv.ToValue(-5)))
+	assert.EqualValues(t, 5, Uint64Conv.FromValue(protoreflect.ValueOfUint64(5)))
+	assert.EqualValues(t, 1.5, FloatConv.FromValue(FloatConv.ToValue(1.5)))
+	assert.Equal(t, []byte("b"), BytesConv.FromValue(BytesConv.ToValue([]byte("b"))))
+
+	type enum uint32
+	conv := EnumConv[enum]()
+	assert.EqualValues(t, 2, conv.ToValue(2).Enum())
+	assert.EqualValues(t, 3, conv.FromValue(protoreflect.ValueOfEnum(3)))
+}
@@ -0,0 +1,141 @@
+// Package lazyreflect implements the protoreflect.Message interface on top of the
+// messages generated by lazyproto. This allows the generated messages to be used
+// with the libraries that operate on protoreflect.Message, e.g. proto.Equal,
+// protojson or validators. The package is used by the generated code.
+package lazyreflect
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	// mux protects files and registry.
+	mux sync.Mutex
+
+	// files are the registered files, keyed by the path of the proto file.
+	files = map[string]*File{}
+
+	// registry contains the descriptors of the files that are already built.
+	// The descriptors are not registered in protoregistry.GlobalFiles, since
+	// the same proto files are often also compiled by protoc-gen-go and linked
+	// into the same binary.
+	registry = &protoregistry.Files{}
+)
+
+// File is a proto file that the code was generated from.
+type File struct {
+	path    string
+	rawDesc []byte
+}
+
+// RegisterFile registers the serialized FileDescriptorProto of the proto file
+// with the specified path. The descriptor is built on first use. The imports of
+// the file are resolved using the registered files first and then using
+// protoregistry.GlobalFiles.
+//
+// If the file with the same path is already registered, the existing file is
+// returned.
+func RegisterFile(path string, rawDesc []byte) *File {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if f, ok := files[path]; ok {
+		return f
+	}
+	f := &File{path: path, rawDesc: rawDesc}
+	files[path] = f
+	return f
+}
+
+// Descriptor returns the descriptor of the file. Panics if the descriptor cannot
+// be built, which happens only if the registered bytes are not a valid
+// FileDescriptorProto.
+func (f *File) Descriptor() protoreflect.FileDescriptor {
+	mux.Lock()
+	defer mux.Unlock()
+
+	fd, err := buildFile(f.path)
+	if err != nil {
+		panic(fmt.Sprintf("lazyreflect: cannot build descriptor of %s: %v", f.path, err))
+	}
+	return fd
+}
+
+// buildFile returns the descriptor of the file with the specified path, building
+// the file and its imports if necessary. mux must be locked.
+func buildFile(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := registry.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+
+	f, ok := files[path]
+	if !ok {
+		// Not generated by lazyproto, perhaps generated by protoc-gen-go.
+		return protoregistry.GlobalFiles.FindFileByPath(path)
+	}
+
+	fdp := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(f.rawDesc, fdp); err != nil {
+		return nil, err
+	}
+
+	// Build the imports first, so that the resolver can find them. The imports
+	// that are not found are replaced by placeholders. Such imports are usually
+	// used for options only, e.g. gogo.proto, and the types declared in them are
+	// not referenced by the generated code.
+	for _, dep := range fdp.GetDependency() {
+		if _, err := buildFile(dep); err != nil && !errors.Is(err, protoregistry.NotFound) {
+			return nil, fmt.Errorf("cannot build import %s: %w", dep, err)
+		}
+	}
+
+	fd, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, resolver{})
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.RegisterFile(fd); err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+// resolver resolves the descriptors using the registry and falls back to
+// protoregistry.GlobalFiles.
+type resolver struct{}
+
+func (resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := registry.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := registry.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// findMessage finds the message with the specified full name declared in the
+// list of messages or in any of the nested messages.
+func findMessage(msgs protoreflect.MessageDescriptors, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	for i := 0; i < msgs.Len(); i++ {
+		md := msgs.Get(i)
+		if md.FullName() == name {
+			return md
+		}
+		if md := findMessage(md.Messages(), name); md != nil {
+			return md
+		}
+	}
+	return nil
+}
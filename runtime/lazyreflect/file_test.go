@@ -0,0 +1,97 @@
+package lazyreflect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func rawFileDesc(t *testing.T, fdp *descriptorpb.FileDescriptorProto) []byte {
+	b, err := proto.Marshal(fdp)
+	require.NoError(t, err)
+	return b
+}
+
+func TestFileDescriptor(t *testing.T) {
+	// The file imports a registered file and a file from protoregistry.GlobalFiles.
+	child := RegisterFile(
+		"lazyreflect_test/child.proto", rawFileDesc(
+			t, &descriptorpb.FileDescriptorProto{
+				Name:       proto.String("lazyreflect_test/child.proto"),
+				Package:    proto.String("lazyreflect_test"),
+				Dependency: []string{"lazyreflect_test/base.proto", "google/protobuf/timestamp.proto"},
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("Child"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{
+								Name:     proto.String("base"),
+								Number:   proto.Int32(1),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+								TypeName: proto.String(".lazyreflect_test.Base"),
+							},
+							{
+								Name:     proto.String("time"),
+								Number:   proto.Int32(2),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+								TypeName: proto.String(".google.protobuf.Timestamp"),
+							},
+						},
+						NestedType: []*descriptorpb.DescriptorProto{{Name: proto.String("Nested")}},
+					},
+				},
+				Syntax: proto.String("proto3"),
+			},
+		),
+	)
+	RegisterFile(
+		"lazyreflect_test/base.proto", rawFileDesc(
+			t, &descriptorpb.FileDescriptorProto{
+				Name:        proto.String("lazyreflect_test/base.proto"),
+				Package:     proto.String("lazyreflect_test"),
+				MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Base")}},
+				Syntax:      proto.String("proto3"),
+			},
+		),
+	)
+
+	fd := child.Descriptor()
+	assert.EqualValues(t, "lazyreflect_test/child.proto", fd.Path())
+	md := findMessage(fd.Messages(), "lazyreflect_test.Child")
+	require.NotNil(t, md)
+	assert.EqualValues(t, "lazyreflect_test.Base", md.Fields().ByName("base").Message().FullName())
+	assert.EqualValues(t, "google.protobuf.Timestamp", md.Fields().ByName("time").Message().FullName())
+	assert.NotNil(t, findMessage(fd.Messages(), "lazyreflect_test.Child.Nested"))
+	assert.Nil(t, findMessage(fd.Messages(), "lazyreflect_test.Other"))
+
+	// The descriptor is built once.
+	assert.Equal(t, fd, child.Descriptor())
+
+	// Registering the same path again returns the existing file.
+	assert.Equal(t, child, RegisterFile("lazyreflect_test/child.proto", nil))
+}
+
+func TestFileDescriptorMissingImport(t *testing.T) {
+	// The imports that are not found are replaced by placeholders.
+	f := RegisterFile(
+		"lazyreflect_test/missing.proto", rawFileDesc(
+			t, &descriptorpb.FileDescriptorProto{
+				Name:       proto.String("lazyreflect_test/missing.proto"),
+				Dependency: []string{"lazyreflect_test/nosuchfile.proto"},
+			},
+		),
+	)
+	fd := f.Descriptor()
+	assert.True(t, fd.Imports().Get(0).IsPlaceholder())
+}
+
+func TestFileDescriptorInvalid(t *testing.T) {
+	f := RegisterFile("lazyreflect_test/invalid.proto", []byte{0xff})
+	assert.Panics(t, func() { f.Descriptor() })
+}
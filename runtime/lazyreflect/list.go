@@ -0,0 +1,141 @@
+package lazyreflect
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
+)
+
+// scalarList implements protoreflect.List for the repeated scalar field with
+// the elements of Go type V.
+type scalarList[V any] struct {
+	elems  *[]V
+	parent *protomessage.ProtoMessage
+	conv   Conv[V]
+}
+
+// NewScalarList returns the list that refers to the elements of the repeated
+// scalar field. The modifications of the list mark the parent message modified.
+func NewScalarList[V any](elems *[]V, parent *protomessage.ProtoMessage, conv Conv[V]) protoreflect.List {
+	return &scalarList[V]{elems: elems, parent: parent, conv: conv}
+}
+
+func (l *scalarList[V]) Len() int {
+	return len(*l.elems)
+}
+
+func (l *scalarList[V]) Get(i int) protoreflect.Value {
+	return l.conv.ToValue((*l.elems)[i])
+}
+
+func (l *scalarList[V]) Set(i int, v protoreflect.Value) {
+	(*l.elems)[i] = l.conv.FromValue(v)
+	l.parent.MarkModified()
+}
+
+func (l *scalarList[V]) Append(v protoreflect.Value) {
+	*l.elems = append(*l.elems, l.conv.FromValue(v))
+	l.parent.MarkModified()
+}
+
+func (l *scalarList[V]) AppendMutable() protoreflect.Value {
+	panic("lazyreflect: AppendMutable called on a list of scalars")
+}
+
+func (l *scalarList[V]) Truncate(n int) {
+	*l.elems = (*l.elems)[:n]
+	l.parent.MarkModified()
+}
+
+func (l *scalarList[V]) NewElement() protoreflect.Value {
+	var v V
+	return l.conv.ToValue(v)
+}
+
+func (l *scalarList[V]) IsValid() bool {
+	return true
+}
+
+// ScalarsFromList returns a new slice with the elements of the list.
+func ScalarsFromList[V any](list protoreflect.List, conv Conv[V]) []V {
+	if list.Len() == 0 {
+		return nil
+	}
+	elems := make([]V, list.Len())
+	for i := range elems {
+		elems[i] = conv.FromValue(list.Get(i))
+	}
+	return elems
+}
+
+// messageList implements protoreflect.List for the repeated message field.
+type messageList[P Message] struct {
+	elems  *[]P
+	parent *protomessage.ProtoMessage
+	newMsg func() P
+}
+
+// NewMessageList returns the list that refers to the elements of the repeated
+// message field. The elements must be already decoded. The modifications of the
+// list mark the parent message modified. The elements that are removed from the
+// list are not returned to the pool.
+func NewMessageList[P Message](elems *[]P, parent *protomessage.ProtoMessage, newMsg func() P) protoreflect.List {
+	return &messageList[P]{elems: elems, parent: parent, newMsg: newMsg}
+}
+
+func (l *messageList[P]) Len() int {
+	return len(*l.elems)
+}
+
+func (l *messageList[P]) Get(i int) protoreflect.Value {
+	return ValueOfMessage((*l.elems)[i])
+}
+
+func (l *messageList[P]) Set(i int, v protoreflect.Value) {
+	elem := MessageFromValue(v, l.newMsg)
+	elem.XXX_ProtoMessage().Parent = l.parent
+	(*l.elems)[i] = elem
+	l.parent.MarkModified()
+}
+
+func (l *messageList[P]) Append(v protoreflect.Value) {
+	l.append(MessageFromValue(v, l.newMsg))
+}
+
+func (l *messageList[P]) AppendMutable() protoreflect.Value {
+	elem := l.newMsg()
+	l.append(elem)
+	return ValueOfMessage(elem)
+}
+
+func (l *messageList[P]) append(elem P) {
+	elem.XXX_ProtoMessage().Parent = l.parent
+	*l.elems = append(*l.elems, elem)
+	l.parent.MarkModified()
+}
+
+func (l *messageList[P]) Truncate(n int) {
+	*l.elems = (*l.elems)[:n]
+	l.parent.MarkModified()
+}
+
+func (l *messageList[P]) NewElement() protoreflect.Value {
+	return ValueOfMessage(l.newMsg())
+}
+
+func (l *messageList[P]) IsValid() bool {
+	return true
+}
+
+// MessagesFromList returns a new slice with the messages of the list. The
+// messages of other implementations are copied to new messages created by newMsg.
+func MessagesFromList[P Message](list protoreflect.List, newMsg func() P) []P {
+	if list.Len() == 0 {
+		return nil
+	}
+	elems := make([]P, list.Len())
+	for i := range elems {
+		elems[i] = MessageFromValue(list.Get(i), newMsg)
+	}
+	return elems
+}
@@ -0,0 +1,173 @@
+package lazyreflect
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MapAccessors are the generated accessors of a map field with the keys of Go
+// type K and the values of Go type V. The accessors decode the map on first
+// access and mark the message modified when the map is changed.
+type MapAccessors[K comparable, V any] struct {
+	Len    func() int
+	Get    func(k K) (V, bool)
+	Range  func(f func(k K, v V) bool)
+	Set    func(k K, v V)
+	Delete func(k K)
+}
+
+// scalarMap implements protoreflect.Map for the map field with scalar values.
+type scalarMap[K comparable, V any] struct {
+	acc     MapAccessors[K, V]
+	keyConv Conv[K]
+	valConv Conv[V]
+}
+
+// NewScalarMap returns the map that accesses the map field with scalar values
+// using the generated accessors.
+func NewScalarMap[K comparable, V any](acc MapAccessors[K, V], keyConv Conv[K], valConv Conv[V]) protoreflect.Map {
+	return &scalarMap[K, V]{acc: acc, keyConv: keyConv, valConv: valConv}
+}
+
+func (m *scalarMap[K, V]) Len() int {
+	return m.acc.Len()
+}
+
+func (m *scalarMap[K, V]) Range(f func(protoreflect.MapKey, protoreflect.Value) bool) {
+	m.acc.Range(
+		func(k K, v V) bool {
+			return f(m.keyConv.ToValue(k).MapKey(), m.valConv.ToValue(v))
+		},
+	)
+}
+
+func (m *scalarMap[K, V]) Has(k protoreflect.MapKey) bool {
+	_, ok := m.acc.Get(m.keyConv.FromValue(k.Value()))
+	return ok
+}
+
+func (m *scalarMap[K, V]) Clear(k protoreflect.MapKey) {
+	m.acc.Delete(m.keyConv.FromValue(k.Value()))
+}
+
+func (m *scalarMap[K, V]) Get(k protoreflect.MapKey) protoreflect.Value {
+	v, ok := m.acc.Get(m.keyConv.FromValue(k.Value()))
+	if !ok {
+		return protoreflect.Value{}
+	}
+	return m.valConv.ToValue(v)
+}
+
+func (m *scalarMap[K, V]) Set(k protoreflect.MapKey, v protoreflect.Value) {
+	m.acc.Set(m.keyConv.FromValue(k.Value()), m.valConv.FromValue(v))
+}
+
+func (m *scalarMap[K, V]) Mutable(protoreflect.MapKey) protoreflect.Value {
+	panic("lazyreflect: Mutable called on a map with scalar values")
+}
+
+func (m *scalarMap[K, V]) NewValue() protoreflect.Value {
+	var v V
+	return m.valConv.ToValue(v)
+}
+
+func (m *scalarMap[K, V]) IsValid() bool {
+	return true
+}
+
+// messageMap implements protoreflect.Map for the map field with message values.
+type messageMap[K comparable, P Message] struct {
+	acc     MapAccessors[K, P]
+	keyConv Conv[K]
+	newMsg  func() P
+}
+
+// NewMessageMap returns the map that accesses the map field with message values
+// using the generated accessors.
+func NewMessageMap[K comparable, P Message](acc MapAccessors[K, P], keyConv Conv[K], newMsg func() P) protoreflect.Map {
+	return &messageMap[K, P]{acc: acc, keyConv: keyConv, newMsg: newMsg}
+}
+
+func (m *messageMap[K, P]) Len() int {
+	return m.acc.Len()
+}
+
+func (m *messageMap[K, P]) Range(f func(protoreflect.MapKey, protoreflect.Value) bool) {
+	m.acc.Range(
+		func(k K, v P) bool {
+			return f(m.keyConv.ToValue(k).MapKey(), ValueOfMessage(v))
+		},
+	)
+}
+
+func (m *messageMap[K, P]) Has(k protoreflect.MapKey) bool {
+	_, ok := m.acc.Get(m.keyConv.FromValue(k.Value()))
+	return ok
+}
+
+func (m *messageMap[K, P]) Clear(k protoreflect.MapKey) {
+	m.acc.Delete(m.keyConv.FromValue(k.Value()))
+}
+
+func (m *messageMap[K, P]) Get(k protoreflect.MapKey) protoreflect.Value {
+	v, ok := m.acc.Get(m.keyConv.FromValue(k.Value()))
+	if !ok {
+		return protoreflect.Value{}
+	}
+	return ValueOfMessage(v)
+}
+
+func (m *messageMap[K, P]) Set(k protoreflect.MapKey, v protoreflect.Value) {
+	m.acc.Set(m.keyConv.FromValue(k.Value()), MessageFromValue(v, m.newMsg))
+}
+
+func (m *messageMap[K, P]) Mutable(k protoreflect.MapKey) protoreflect.Value {
+	key := m.keyConv.FromValue(k.Value())
+	v, ok := m.acc.Get(key)
+	if !ok {
+		v = m.newMsg()
+		m.acc.Set(key, v)
+	}
+	return ValueOfMessage(v)
+}
+
+func (m *messageMap[K, P]) NewValue() protoreflect.Value {
+	return ValueOfMessage(m.newMsg())
+}
+
+func (m *messageMap[K, P]) IsValid() bool {
+	return true
+}
+
+// AssignMap replaces the entries of dst by the entries of src.
+func AssignMap(dst protoreflect.Map, src protoreflect.Map) {
+	// Collect the entries first, src may refer to the same map as dst.
+	type entry struct {
+		k protoreflect.MapKey
+		v protoreflect.Value
+	}
+	entries := make([]entry, 0, src.Len())
+	src.Range(
+		func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries = append(entries, entry{k: k, v: v})
+			return true
+		},
+	)
+	ClearMap(dst)
+	for _, e := range entries {
+		dst.Set(e.k, e.v)
+	}
+}
+
+// ClearMap deletes all entries of the map.
+func ClearMap(m protoreflect.Map) {
+	var keys []protoreflect.MapKey
+	m.Range(
+		func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		},
+	)
+	for _, k := range keys {
+		m.Clear(k)
+	}
+}
@@ -0,0 +1,221 @@
+package lazyreflect
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// FieldInfo contains the functions that access one field of the message of type T.
+// The functions are generated by lazyproto and use the getters and setters of
+// the generated message, so that the nested messages are decoded on access and
+// the message is marked modified when it is changed.
+type FieldInfo[T any] struct {
+	// Has returns true if the field is populated.
+	Has func(m *T) bool
+
+	// Get returns the value of the field. For the fields that are not populated
+	// the default value is returned.
+	Get func(m *T) protoreflect.Value
+
+	// Set sets the value of the field.
+	Set func(m *T, v protoreflect.Value)
+
+	// Clear clears the field, so that it is no longer populated.
+	Clear func(m *T)
+
+	// Mutable returns a mutable reference to the message, list or map value of
+	// the field, allocating the value if it is not populated. Nil for the
+	// scalar fields.
+	Mutable func(m *T) protoreflect.Value
+}
+
+// MessageInfo describes the message of type T. It implements
+// protoreflect.MessageType.
+type MessageInfo[T any] struct {
+	// File is the file in which the message is declared.
+	File *File
+
+	// FullName is the full name of the message.
+	FullName protoreflect.FullName
+
+	// NewMessage creates a new message.
+	NewMessage func() *T
+
+	// UnknownFields returns the wire representation of the unknown fields.
+	UnknownFields func(m *T) []byte
+
+	// SetUnknownFields replaces the unknown fields by the wire representation.
+	SetUnknownFields func(m *T, b []byte)
+
+	// Fields are the accessors of the fields, in the order of the fields in the
+	// message descriptor.
+	Fields []FieldInfo[T]
+
+	once sync.Once
+	desc protoreflect.MessageDescriptor
+}
+
+// Descriptor returns the descriptor of the message.
+func (mi *MessageInfo[T]) Descriptor() protoreflect.MessageDescriptor {
+	mi.once.Do(
+		func() {
+			mi.desc = findMessage(mi.File.Descriptor().Messages(), mi.FullName)
+			if mi.desc == nil {
+				panic(fmt.Sprintf("lazyreflect: message %s not found in %s", mi.FullName, mi.File.path))
+			}
+			if mi.desc.Fields().Len() != len(mi.Fields) {
+				panic(fmt.Sprintf("lazyreflect: descriptor of %s does not match the generated code", mi.FullName))
+			}
+		},
+	)
+	return mi.desc
+}
+
+// MessageOf returns the reflective view of the message. m may be nil, in which
+// case the returned message is read-only and empty.
+func (mi *MessageInfo[T]) MessageOf(m *T) protoreflect.Message {
+	return &message[T]{info: mi, m: m}
+}
+
+// New returns a new mutable message.
+func (mi *MessageInfo[T]) New() protoreflect.Message {
+	return mi.MessageOf(mi.NewMessage())
+}
+
+// Zero returns an empty read-only message.
+func (mi *MessageInfo[T]) Zero() protoreflect.Message {
+	return mi.MessageOf(nil)
+}
+
+// message implements protoreflect.Message for the generated message of type T.
+type message[T any] struct {
+	info *MessageInfo[T]
+	m    *T
+}
+
+func (x *message[T]) Descriptor() protoreflect.MessageDescriptor {
+	return x.info.Descriptor()
+}
+
+func (x *message[T]) Type() protoreflect.MessageType {
+	return x.info
+}
+
+func (x *message[T]) New() protoreflect.Message {
+	return x.info.New()
+}
+
+func (x *message[T]) Interface() protoreflect.ProtoMessage {
+	return any(x.m).(protoreflect.ProtoMessage)
+}
+
+func (x *message[T]) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	if x.m == nil {
+		return
+	}
+	fields := x.Descriptor().Fields()
+	for i, fi := range x.info.Fields {
+		if fi.Has(x.m) && !f(fields.Get(i), fi.Get(x.m)) {
+			return
+		}
+	}
+}
+
+func (x *message[T]) Has(fd protoreflect.FieldDescriptor) bool {
+	fi := x.field(fd)
+	return x.m != nil && fi.Has(x.m)
+}
+
+func (x *message[T]) Clear(fd protoreflect.FieldDescriptor) {
+	fi := x.field(fd)
+	x.checkMutable()
+	fi.Clear(x.m)
+}
+
+func (x *message[T]) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	fi := x.field(fd)
+	if x.m == nil {
+		// Get the default value from an empty message.
+		return fi.Get(new(T))
+	}
+	return fi.Get(x.m)
+}
+
+func (x *message[T]) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	fi := x.field(fd)
+	x.checkMutable()
+	fi.Set(x.m, v)
+}
+
+func (x *message[T]) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	fi := x.field(fd)
+	if fi.Mutable == nil {
+		panic(fmt.Sprintf("lazyreflect: field %s is not a composite type", fd.FullName()))
+	}
+	x.checkMutable()
+	return fi.Mutable(x.m)
+}
+
+func (x *message[T]) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	fi := x.field(fd)
+	if fi.Mutable == nil {
+		return fd.Default()
+	}
+	// Allocate the value in a temporary message. Setting the value to another
+	// message reparents it.
+	return fi.Mutable(new(T))
+}
+
+func (x *message[T]) WhichOneof(od protoreflect.OneofDescriptor) protoreflect.FieldDescriptor {
+	if od.Parent().FullName() != x.info.FullName {
+		panic(fmt.Sprintf("lazyreflect: oneof %s is not a member of %s", od.FullName(), x.info.FullName))
+	}
+	fields := od.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if x.Has(fields.Get(i)) {
+			return fields.Get(i)
+		}
+	}
+	return nil
+}
+
+func (x *message[T]) GetUnknown() protoreflect.RawFields {
+	if x.m == nil {
+		return nil
+	}
+	b := x.info.UnknownFields(x.m)
+	// The bytes may be a view into the original bytes of the message. Make
+	// sure appending to them does not overwrite the original bytes.
+	return b[:len(b):len(b)]
+}
+
+func (x *message[T]) SetUnknown(b protoreflect.RawFields) {
+	x.checkMutable()
+	x.info.SetUnknownFields(x.m, b)
+}
+
+func (x *message[T]) IsValid() bool {
+	return x.m != nil
+}
+
+func (x *message[T]) ProtoMethods() *protoiface.Methods {
+	return nil
+}
+
+// field returns the accessors of the field. Panics if the field does not belong
+// to this message.
+func (x *message[T]) field(fd protoreflect.FieldDescriptor) *FieldInfo[T] {
+	if fd.ContainingMessage().FullName() != x.info.FullName || fd.IsExtension() {
+		panic(fmt.Sprintf("lazyreflect: field %s is not a member of %s", fd.FullName(), x.info.FullName))
+	}
+	return &x.info.Fields[fd.Index()]
+}
+
+func (x *message[T]) checkMutable() {
+	if x.m == nil {
+		panic(fmt.Sprintf("lazyreflect: cannot modify invalid %s message", x.info.FullName))
+	}
+}
@@ -922,6 +922,20 @@ func (cb *Buffer) AsBytesSafe() ([]byte, error) {
 	return append([]byte(nil), v...), nil
 }
 
+// CountPackedVarints returns the number of varints in the packed repeated field
+// bytes. Every varint is terminated by a byte that has the high bit unset, so
+// we only need to count such bytes. An incomplete varint at the end of the bytes
+// is not counted.
+func CountPackedVarints(b []byte) int {
+	count := 0
+	for _, v := range b {
+		if v < 0x80 {
+			count++
+		}
+	}
+	return count
+}
+
 func (cb *Buffer) SkipFieldByWireType(wireType WireType) error {
 	switch wireType {
 	case WireVarint:
@@ -932,6 +946,8 @@ func (cb *Buffer) SkipFieldByWireType(wireType WireType) error {
 		return cb.SkipRawBytes()
 	case WireFixed32:
 		return cb.SkipFixed32()
+	case WireStartGroup:
+		return cb.SkipGroup()
 	default:
 		return ErrBadWireType
 	}
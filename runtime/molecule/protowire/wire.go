@@ -9,6 +9,8 @@
 // use the "google.golang.org/protobuf/proto" package instead.
 package protowire
 
+import "math/bits"
+
 // This file has been modified from the original:
 //
 // * remove items not needed for Molecule (methods, constants)
@@ -159,6 +161,14 @@ func AppendFixed64(b []byte, v uint64) []byte {
 	)
 }
 
+// SizeVarint returns the encoded size of a varint.
+// The size is guaranteed to be within 1 and 10, inclusive.
+func SizeVarint(v uint64) int {
+	// This computes 1 + (bits.Len64(v)-1)/7.
+	// 9/64 is a good enough approximation of 1/7
+	return int(9*uint32(bits.Len64(v))+64) / 64
+}
+
 // EncodeZigZag encodes an int64 as a zig-zag-encoded uint64.
 //	Input:  {…, -3, -2, -1,  0, +1, +2, +3, …}
 //	Output: {…,  5,  3,  1,  0,  2,  4,  6, …}
@@ -0,0 +1,65 @@
+package molecule
+
+import (
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/protowire"
+)
+
+// The functions in this file calculate the number of bytes that the ProtoStream
+// methods write. They are used by the generated Size() methods, which must
+// calculate exactly the same size as the one produced by the Marshal() methods.
+
+// SizeVarint returns the encoded size of a varint.
+func SizeVarint(v uint64) int {
+	return protowire.SizeVarint(v)
+}
+
+// SizeZigZag returns the encoded size of a value of proto type sint32 or sint64.
+func SizeZigZag(v int64) int {
+	return protowire.SizeVarint(protowire.EncodeZigZag(v))
+}
+
+// SizeBytes returns the encoded size of the length-prefixed value of the specified
+// length, excluding the key. It is used for strings, bytes and embedded messages.
+func SizeBytes(length int) int {
+	return protowire.SizeVarint(uint64(length)) + length
+}
+
+// SizeKey returns the encoded size of the key of the field.
+func SizeKey(fieldNumber int) int {
+	return protowire.SizeVarint(uint64(fieldNumber) << 3)
+}
+
+// SizeFixedPacked returns the number of bytes written by the *Packed methods for
+// count values of a fixed size type (including bool) that are elemSize bytes each.
+func SizeFixedPacked(fieldNumber int, count int, elemSize int) int {
+	if count == 0 {
+		return 0
+	}
+	return SizeKey(fieldNumber) + SizeBytes(count*elemSize)
+}
+
+// SizeVarintPacked returns the number of bytes written by Int32Packed, Int64Packed,
+// Uint32Packed or Uint64Packed for the values.
+func SizeVarintPacked[T int32 | int64 | uint32 | uint64](fieldNumber int, values []T) int {
+	if len(values) == 0 {
+		return 0
+	}
+	n := 0
+	for _, value := range values {
+		n += protowire.SizeVarint(uint64(value))
+	}
+	return SizeKey(fieldNumber) + SizeBytes(n)
+}
+
+// SizeZigZagPacked returns the number of bytes written by Sint32Packed or
+// Sint64Packed for the values.
+func SizeZigZagPacked[T int32 | int64](fieldNumber int, values []T) int {
+	if len(values) == 0 {
+		return 0
+	}
+	n := 0
+	for _, value := range values {
+		n += SizeZigZag(int64(value))
+	}
+	return SizeKey(fieldNumber) + SizeBytes(n)
+}
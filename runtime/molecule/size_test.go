@@ -0,0 +1,50 @@
+package molecule
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func written(f func(ps *ProtoStream)) int {
+	ps := NewProtoStream()
+	f(ps)
+	b, _ := ps.BufferBytes()
+	return len(b)
+}
+
+func TestSizeMatchesWritten(t *testing.T) {
+	key := PrepareInt64Field(1)
+	for _, v := range []int64{1, -1, 127, 128, math.MaxInt64, math.MinInt64} {
+		assert.EqualValues(t, written(func(ps *ProtoStream) { ps.Int64Prepared(key, v) }), 1+SizeVarint(uint64(v)))
+		assert.EqualValues(t, written(func(ps *ProtoStream) { ps.Sint64Prepared(key, v) }), 1+SizeZigZag(v))
+	}
+
+	for _, n := range []int{1, 127, 128, 20000} {
+		s := string(make([]byte, n))
+		assert.EqualValues(t, written(func(ps *ProtoStream) { ps.StringPrepared(key, s) }), 1+SizeBytes(n))
+		assert.EqualValues(t, written(func(ps *ProtoStream) { ps.EmbeddedSizePrepared(key, n) }), 1+SizeBytes(n)-n)
+	}
+
+	// Field numbers above 15 need 2 bytes for the key.
+	for _, fieldNumber := range []int{1, 15, 16, 2047, 2048} {
+		int32s := []int32{0, -1, 300}
+		assert.EqualValues(
+			t, written(func(ps *ProtoStream) { ps.Int32Packed(fieldNumber, int32s) }),
+			SizeVarintPacked(fieldNumber, int32s),
+		)
+		assert.EqualValues(
+			t, written(func(ps *ProtoStream) { ps.Sint32Packed(fieldNumber, int32s) }),
+			SizeZigZagPacked(fieldNumber, int32s),
+		)
+		doubles := make([]float64, 20)
+		assert.EqualValues(
+			t, written(func(ps *ProtoStream) { ps.DoublePacked(fieldNumber, doubles) }),
+			SizeFixedPacked(fieldNumber, len(doubles), 8),
+		)
+	}
+	assert.EqualValues(t, 0, SizeVarintPacked[uint64](1, nil))
+	assert.EqualValues(t, 0, SizeZigZagPacked[int64](1, nil))
+	assert.EqualValues(t, 0, SizeFixedPacked(1, 0, 4))
+}
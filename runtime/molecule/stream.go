@@ -1,9 +1,14 @@
+// Package molecule implements the ProtoStream that the code generated by lazyproto
+// uses to marshal messages.
 package molecule
 
 import (
+	"errors"
 	"math"
+	"net"
 
-	"github.com/tigrannajaryan/exp-lazyproto/internal/molecule/src/protowire"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule/protowire"
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
 )
 
 const (
@@ -30,6 +35,26 @@ type ProtoStream struct {
 	// encodings.  It is large enough to fit two max-size varints (10 bytes
 	// each) without reallocation
 	scratchArray [20]byte
+
+	// cacheBytes is set by SetCacheBytes. If it is set, the modified messages
+	// that are written to the stream are remembered in the marshaled list.
+	cacheBytes bool
+	marshaled  []marshaledMessage
+
+	// vectored is set by SetVectored. In vectored mode the large values passed to
+	// Raw are referenced by vectoredBuffers instead of being copied to outputBuffer.
+	// outputBuffer[chunkStart:] are the bytes written after the last referenced
+	// value and refLen is the total length of the referenced values.
+	vectored        bool
+	vectoredBuffers net.Buffers
+	chunkStart      int
+	refLen          int
+}
+
+// marshaledMessage is a modified message that was written to outputBuffer[start:end].
+type marshaledMessage struct {
+	msg        *protomessage.ProtoMessage
+	start, end int
 }
 
 // NewProtoStream creates a new ProtoStream writing to the given Writer.  If the
@@ -43,14 +68,151 @@ func NewProtoStream() *ProtoStream {
 }
 
 func (ps *ProtoStream) BufferBytes() ([]byte, error) {
+	if ps.vectored {
+		return nil, errVectored
+	}
 	return ps.outputBuffer, nil
 }
 
+var errVectored = errors.New("the bytes of the stream in vectored mode must be obtained using Buffers()")
+
 // Reset sets the Writer to which this ProtoStream streams.  If the writer is nil,
 // then the protostream cannot be used until Reset is called with a non-nil value.
 func (ps *ProtoStream) Reset() {
 	ps.outputBuffer = ps.outputBuffer[:0]
 	ps.scratchBuffer = ps.scratchBuffer[:0]
+	ps.resetMarshaled()
+	ps.resetVectored()
+}
+
+// Len returns the number of bytes written to the stream since the last Reset.
+func (ps *ProtoStream) Len() int {
+	return ps.refLen + len(ps.outputBuffer)
+}
+
+// SetCacheBytes enables or disables caching of the encoded bytes. It disables the
+// vectored mode, the modes can't be used together. When enabled,
+// the stream remembers where each modified message is written, so that
+// DetachBuffer can point the messages at their freshly encoded bytes. This makes
+// the messages unmodified, and subsequent Marshal calls copy their bytes as is
+// instead of encoding the fields again. This is useful when the same modified
+// message is marshaled several times.
+func (ps *ProtoStream) SetCacheBytes(enable bool) {
+	ps.cacheBytes = enable
+	ps.resetMarshaled()
+	if enable {
+		ps.SetVectored(false)
+	}
+}
+
+// EndModified is called by the generated Marshal after the fields of the modified
+// message m are written to the stream, starting at the position start.
+// The size of the written message is cached in m, so that the next Marshal can
+// write its length prefix upfront instead of moving the bytes of the message.
+func (ps *ProtoStream) EndModified(m *protomessage.ProtoMessage, start int) {
+	m.SetCachedSize(ps.Len() - start)
+	if ps.cacheBytes {
+		ps.marshaled = append(
+			ps.marshaled, marshaledMessage{msg: m, start: start, end: ps.Len()},
+		)
+	}
+}
+
+// UpfrontSizes reports whether the length prefixes of all embedded messages must be
+// written before the messages. This is the case in vectored mode and when caching
+// the encoded bytes, where the written bytes can't be moved by EndEmbeddedPrepared.
+// The generated Marshal then calls Size() first, which calculates and caches the
+// sizes of all nested messages.
+func (ps *ProtoStream) UpfrontSizes() bool {
+	return ps.vectored || ps.cacheBytes
+}
+
+// DetachBuffer returns the bytes written to the stream since the last Reset and
+// transfers the ownership of the bytes to the caller: the stream will write to a
+// new buffer from now on, so the returned bytes are never overwritten by it.
+//
+// If caching of the encoded bytes is enabled by SetCacheBytes, the modified messages
+// that were written to the stream are pointed at their regions of the returned
+// bytes and become unmodified. DetachBuffer must be called right after the successful
+// Marshal calls, before any of the written messages is modified. If Marshal fails,
+// Reset must be called instead. The returned bytes must not be modified while
+// they are referenced by the messages.
+func (ps *ProtoStream) DetachBuffer() []byte {
+	b := ps.outputBuffer[:len(ps.outputBuffer):len(ps.outputBuffer)]
+	for _, mm := range ps.marshaled {
+		mm.msg.SetMarshaledBytes(b[mm.start:mm.end])
+	}
+	ps.resetMarshaled()
+	ps.outputBuffer = nil
+	return b
+}
+
+func (ps *ProtoStream) resetMarshaled() {
+	// Don't keep the messages alive.
+	for i := range ps.marshaled {
+		ps.marshaled[i] = marshaledMessage{}
+	}
+	ps.marshaled = ps.marshaled[:0]
+}
+
+// VectoredMinRawSize is the minimum length of a value passed to Raw that is
+// referenced instead of being copied in vectored mode. Shorter values are cheaper
+// to copy than to write as a separate buffer.
+const VectoredMinRawSize = 256
+
+// SetVectored enables or disables the vectored mode. It disables caching of the
+// encoded bytes, the modes can't be used together.
+//
+// In vectored mode the bytes of the unmodified messages, which Marshal writes using
+// Raw, are not copied to the stream's buffer. The stream references them instead and
+// Buffers returns the list of referenced and written byte slices, which can be
+// written using writev, e.g. by net.Buffers.WriteTo. BufferBytes, DetachBuffer,
+// BeginEmbedded and Embedded can't be used in vectored mode.
+func (ps *ProtoStream) SetVectored(enable bool) {
+	ps.resetVectored()
+	ps.vectored = enable
+	if enable {
+		ps.cacheBytes = false
+		ps.resetMarshaled()
+	}
+}
+
+// Buffers returns the bytes written to the stream in vectored mode since the last
+// Reset. The concatenation of the returned slices is the same as the bytes that
+// BufferBytes returns in normal mode.
+//
+// The returned slices reference the stream's buffer, which is valid until the next
+// Reset, and the bytes that the unmodified messages were unmarshalled from, which
+// must not be modified while the slices are used. Note that net.Buffers.WriteTo
+// consumes the slices, so Buffers must be called again to write them again.
+func (ps *ProtoStream) Buffers() net.Buffers {
+	ps.appendVectoredChunk()
+	return ps.vectoredBuffers
+}
+
+func (ps *ProtoStream) appendVectoredRef(value []byte) {
+	ps.appendVectoredChunk()
+	ps.vectoredBuffers = append(ps.vectoredBuffers, value)
+	ps.refLen += len(value)
+}
+
+// appendVectoredChunk appends the bytes written since the last referenced value.
+func (ps *ProtoStream) appendVectoredChunk() {
+	end := len(ps.outputBuffer)
+	if end > ps.chunkStart {
+		ps.vectoredBuffers = append(ps.vectoredBuffers, ps.outputBuffer[ps.chunkStart:end:end])
+		ps.chunkStart = end
+	}
+}
+
+func (ps *ProtoStream) resetVectored() {
+	// Don't keep the referenced bytes alive.
+	for i := range ps.vectoredBuffers {
+		ps.vectoredBuffers[i] = nil
+	}
+	ps.vectoredBuffers = ps.vectoredBuffers[:0]
+	ps.chunkStart = 0
+	ps.refLen = 0
 }
 
 // Double writes a value of proto type double to the stream.
@@ -102,6 +264,15 @@ func (ps *ProtoStream) Float(fieldNumber int, value float32) error {
 	return nil
 }
 
+func (ps *ProtoStream) FloatPrepared(fieldKey PreparedKey, value float32) {
+	if value == 0 {
+		return
+	}
+
+	ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey))
+	ps.outputBuffer = protowire.AppendFixed32(ps.outputBuffer, math.Float32bits(value))
+}
+
 // FloatPacked writes a slice of values of proto type float to the stream,
 // in packed form.
 func (ps *ProtoStream) FloatPacked(fieldNumber int, values []float32) {
@@ -129,6 +300,14 @@ func (ps *ProtoStream) Int32(fieldNumber int, value int32) {
 	return
 }
 
+func (ps *ProtoStream) Int32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey))
+	ps.outputBuffer = protowire.AppendVarint(ps.outputBuffer, uint64(value))
+}
+
 // Int32Packed writes a slice of values of proto type int32 to the stream,
 // in packed form.
 func (ps *ProtoStream) Int32Packed(fieldNumber int, values []int32) {
@@ -300,6 +479,14 @@ func (ps *ProtoStream) Sint64(fieldNumber int, value int64) {
 	ps.writeScratch()
 }
 
+func (ps *ProtoStream) Sint64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey))
+	ps.outputBuffer = protowire.AppendVarint(ps.outputBuffer, zigzag64(uint64(value)))
+}
+
 // Sint64Packed writes a slice of values of proto type sint64 to the stream,
 // in packed form.
 func (ps *ProtoStream) Sint64Packed(fieldNumber int, values []int64) {
@@ -389,6 +576,16 @@ func (ps *ProtoStream) Sfixed32(fieldNumber int, value int32) {
 	ps.writeScratch()
 }
 
+// SFixed32Prepared writes a value of proto type sfixed32 to the stream.
+func (ps *ProtoStream) SFixed32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+
+	ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey))
+	ps.outputBuffer = protowire.AppendFixed32(ps.outputBuffer, uint32(value))
+}
+
 // Sfixed32Packed writes a slice of values of proto type sfixed32 to the stream,
 // in packed form.
 func (ps *ProtoStream) Sfixed32Packed(fieldNumber int, values []int32) {
@@ -463,6 +660,23 @@ func (ps *ProtoStream) BoolPrepared(fieldKey PreparedKey, value bool) {
 	ps.outputBuffer = protowire.AppendVarint(ps.outputBuffer, bit)
 }
 
+// BoolPacked writes a slice of values of proto type bool to the stream,
+// in packed form.
+func (ps *ProtoStream) BoolPacked(fieldNumber int, values []bool) {
+	if len(values) == 0 {
+		return
+	}
+	ps.scratchBuffer = ps.scratchBuffer[:0]
+	for _, value := range values {
+		var bit byte
+		if value {
+			bit = 1
+		}
+		ps.scratchBuffer = append(ps.scratchBuffer, bit)
+	}
+	ps.writeScratchAsPacked(fieldNumber)
+}
+
 // String writes a string to the stream.
 func (ps *ProtoStream) String(fieldNumber int, value string) {
 	if len(value) == 0 {
@@ -543,6 +757,18 @@ func PrepareDoubleField(fieldNumber int) PreparedKey {
 	return PrepareField(fieldNumber, protowire.Fixed64Type)
 }
 
+func PrepareFloatField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed32Type)
+}
+
+func PrepareInt32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareSint64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
 // String writes a string to the stream.
 func (ps *ProtoStream) StringPrepared(key PreparedKey, value string) {
 	vlen := len(value)
@@ -591,9 +817,40 @@ func (ps *ProtoStream) Raw(value []byte) {
 	if len(value) == 0 {
 		return
 	}
+	if ps.vectored && len(value) >= VectoredMinRawSize {
+		ps.appendVectoredRef(value)
+		return
+	}
 	ps.writeAll(value)
 }
 
+// ZeroPrepared writes the key followed by the zero value of the key's wire type.
+// The *Prepared methods skip zero values, ZeroPrepared is used to write the fields
+// that have explicit presence and are set to a zero value.
+func (ps *ProtoStream) ZeroPrepared(fieldKey PreparedKey) {
+	ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey))
+	switch protowire.Type(fieldKey & 0x7) {
+	case protowire.Fixed32Type:
+		ps.outputBuffer = append(ps.outputBuffer, 0, 0, 0, 0)
+	case protowire.Fixed64Type:
+		ps.outputBuffer = append(ps.outputBuffer, 0, 0, 0, 0, 0, 0, 0, 0)
+	default:
+		// Zero varint and zero length of bytes are both encoded as a single 0 byte.
+		ps.outputBuffer = append(ps.outputBuffer, 0)
+	}
+}
+
+// BeginGroup writes the "start group" key of the field. The fields of the group
+// must be written next, followed by EndGroup call.
+func (ps *ProtoStream) BeginGroup(fieldNumber int) {
+	ps.encodeKeyToOutput(fieldNumber, protowire.StartGroupType)
+}
+
+// EndGroup writes the "end group" key of the field.
+func (ps *ProtoStream) EndGroup(fieldNumber int) {
+	ps.encodeKeyToOutput(fieldNumber, protowire.EndGroupType)
+}
+
 type EmbeddedToken int
 
 func (ps *ProtoStream) ReserveCapacity(capacity int) {
@@ -604,6 +861,36 @@ func (ps *ProtoStream) ReserveCapacity(capacity int) {
 	}
 }
 
+// Grow ensures that at least n more bytes can be written to the stream without
+// reallocating the output buffer. It does nothing in vectored mode, where most of
+// the bytes are typically referenced instead of being written to the buffer.
+func (ps *ProtoStream) Grow(n int) {
+	if ps.vectored {
+		return
+	}
+	ps.ReserveCapacity(len(ps.outputBuffer) + n)
+}
+
+// EmbeddedSizePrepared writes the key and the length prefix of the embedded
+// message of the specified size. The embedded message must be written next.
+// Unlike BeginEmbedded/EndEmbeddedPrepared it never needs to move the bytes of
+// the embedded message, but the size must be known upfront.
+func (ps *ProtoStream) EmbeddedSizePrepared(fieldKey PreparedKey, size int) {
+	if size < 1<<7 {
+		// The most common case is kept small enough to be inlined.
+		ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey), byte(size))
+		return
+	}
+	ps.embeddedLongSizePrepared(fieldKey, size)
+}
+
+func (ps *ProtoStream) embeddedLongSizePrepared(fieldKey PreparedKey, size int) {
+	ps.outputBuffer = append(ps.outputBuffer, byte(fieldKey))
+	ps.outputBuffer = protowire.AppendVarint(ps.outputBuffer, uint64(size))
+}
+
+// BeginEmbedded starts an embedded message, which is ended by EndEmbedded or
+// EndEmbeddedPrepared. It can't be used in vectored mode.
 func (ps *ProtoStream) BeginEmbedded() EmbeddedToken {
 	curPos := len(ps.outputBuffer)
 
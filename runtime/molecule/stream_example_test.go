@@ -6,7 +6,7 @@ import (
 	"fmt"
 )
 
-func ExampleNew() {
+func ExampleNewProtoStream() {
 	/* Encoding the following:
 	 *
 	 * message SearchRequest {
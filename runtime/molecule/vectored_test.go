@@ -0,0 +1,77 @@
+package molecule
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRaw(ps *ProtoStream, small, large []byte) {
+	key := PrepareEmbeddedField(1)
+	ps.EmbeddedSizePrepared(key, len(small))
+	ps.Raw(small)
+	ps.EmbeddedSizePrepared(key, len(large))
+	ps.Raw(large)
+	ps.Raw(large)
+	ps.Int64Prepared(PrepareInt64Field(2), 5)
+}
+
+func TestVectored(t *testing.T) {
+	small := []byte("small")
+	large := bytes.Repeat([]byte{1}, VectoredMinRawSize)
+
+	ps := NewProtoStream()
+	writeRaw(ps, small, large)
+	expected, err := ps.BufferBytes()
+	require.NoError(t, err)
+
+	ps = NewProtoStream()
+	ps.SetVectored(true)
+	for i := 0; i < 2; i++ {
+		ps.Reset()
+		writeRaw(ps, small, large)
+		assert.EqualValues(t, len(expected), ps.Len())
+
+		_, err = ps.BufferBytes()
+		assert.Error(t, err)
+
+		// The small value is copied, the large values are referenced.
+		buffers := ps.Buffers()
+		require.Len(t, buffers, 4)
+		assert.Same(t, &large[0], &buffers[1][0])
+		assert.Same(t, &large[0], &buffers[2][0])
+		assert.EqualValues(t, expected, bytes.Join(buffers, nil))
+
+		// Buffers can be called again.
+		assert.EqualValues(t, buffers, ps.Buffers())
+	}
+
+	ps.SetVectored(false)
+	ps.Reset()
+	writeRaw(ps, small, large)
+	b, err := ps.BufferBytes()
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, b)
+}
+
+func TestVectoredDisablesCacheBytes(t *testing.T) {
+	ps := NewProtoStream()
+	ps.SetCacheBytes(true)
+	ps.SetVectored(true)
+	assert.False(t, ps.cacheBytes)
+	ps.SetCacheBytes(true)
+	assert.False(t, ps.vectored)
+}
+
+func TestUpfrontSizes(t *testing.T) {
+	ps := NewProtoStream()
+	assert.False(t, ps.UpfrontSizes())
+	ps.SetVectored(true)
+	assert.True(t, ps.UpfrontSizes())
+	ps.SetCacheBytes(true)
+	assert.True(t, ps.UpfrontSizes())
+	ps.SetCacheBytes(false)
+	assert.False(t, ps.UpfrontSizes())
+}
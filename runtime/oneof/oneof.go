@@ -1,3 +1,5 @@
+// Package oneof implements the compact representation of oneof fields that is
+// used by the code generated by lazyproto.
 package oneof
 
 import (
@@ -35,6 +37,34 @@ func NewInt64(v int64, fieldIdx int) OneOf {
 	}
 }
 
+func NewInt32(v int32, fieldIdx int) OneOf {
+	return OneOf{
+		lenAndFieldIdx: int64(fieldIdx),
+		capOrVal:       int64(v),
+	}
+}
+
+func NewUint64(v uint64, fieldIdx int) OneOf {
+	return OneOf{
+		lenAndFieldIdx: int64(fieldIdx),
+		capOrVal:       int64(v),
+	}
+}
+
+func NewUint32(v uint32, fieldIdx int) OneOf {
+	return OneOf{
+		lenAndFieldIdx: int64(fieldIdx),
+		capOrVal:       int64(v),
+	}
+}
+
+func NewFloat(v float32, fieldIdx int) OneOf {
+	return OneOf{
+		lenAndFieldIdx: int64(fieldIdx),
+		capOrVal:       int64(*(*int32)(unsafe.Pointer(&v))),
+	}
+}
+
 func NewDouble(v float64, fieldIdx int) OneOf {
 	return OneOf{
 		lenAndFieldIdx: int64(fieldIdx),
@@ -96,6 +126,23 @@ func (v *OneOf) Int64Val() int64 {
 	return v.capOrVal
 }
 
+func (v *OneOf) Int32Val() int32 {
+	return int32(v.capOrVal)
+}
+
+func (v *OneOf) Uint64Val() uint64 {
+	return uint64(v.capOrVal)
+}
+
+func (v *OneOf) Uint32Val() uint32 {
+	return uint32(v.capOrVal)
+}
+
+func (v *OneOf) FloatVal() float32 {
+	i := int32(v.capOrVal)
+	return *(*float32)(unsafe.Pointer(&i))
+}
+
 func (v *OneOf) DoubleVal() float64 {
 	return *(*float64)(unsafe.Pointer(&v.capOrVal))
 }
@@ -0,0 +1,68 @@
+// Package protoconv implements the conversion between the messages generated by
+// lazyproto and the messages of the same types generated by other protobuf
+// implementations, such as google.golang.org/protobuf (protoc-gen-go) and
+// github.com/gogo/protobuf (protoc-gen-gogo). The messages are converted via the
+// wire format. The package is used by the generated FromProto and ToProto methods.
+package protoconv
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// Message is a message generated by another protobuf implementation. It must be
+// either a protoreflect.ProtoMessage, e.g. a message generated by protoc-gen-go or
+// a dynamicpb message, or a protoiface.MessageV1, e.g. a message generated by
+// protoc-gen-gogo. The messages of both kinds are accepted, so the type is any.
+type Message = any
+
+// gogoMessage is implemented by the messages generated by protoc-gen-gogo with the
+// marshaler and unmarshaler plugins enabled.
+type gogoMessage interface {
+	protoiface.MessageV1
+	Marshal() ([]byte, error)
+	Unmarshal(b []byte) error
+}
+
+// Marshal returns the wire representation of the message. The required proto2
+// fields are not checked, the same as for the messages generated by lazyproto.
+func Marshal(m Message) ([]byte, error) {
+	if gm, ok := m.(gogoMessage); ok {
+		return gm.Marshal()
+	}
+	pm, err := protoMessageOf(m)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{AllowPartial: true}.Marshal(pm)
+}
+
+// Unmarshal replaces the content of the message by the message decoded from the
+// wire bytes. The message does not reference b after Unmarshal returns.
+func Unmarshal(b []byte, m Message) error {
+	if gm, ok := m.(gogoMessage); ok {
+		gm.Reset()
+		return gm.Unmarshal(b)
+	}
+	pm, err := protoMessageOf(m)
+	if err != nil {
+		return err
+	}
+	return proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(b, pm)
+}
+
+// protoMessageOf returns the protoreflect.ProtoMessage view of the message.
+func protoMessageOf(m Message) (protoreflect.ProtoMessage, error) {
+	switch v := m.(type) {
+	case protoreflect.ProtoMessage:
+		return v, nil
+	case protoiface.MessageV1:
+		return protoimpl.X.ProtoMessageV2Of(v), nil
+	default:
+		return nil, fmt.Errorf("protoconv: %T is not a protobuf message", m)
+	}
+}
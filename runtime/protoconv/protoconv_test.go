@@ -0,0 +1,35 @@
+package protoconv
+
+import (
+	"testing"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestConvert(t *testing.T) {
+	google := &timestamppb.Timestamp{Seconds: 10, Nanos: 20}
+	b, err := Marshal(google)
+	require.NoError(t, err)
+
+	// Unmarshal replaces the content.
+	gogo := &gogotypes.Timestamp{Seconds: 1, Nanos: 2}
+	require.NoError(t, Unmarshal(b, gogo))
+	assert.EqualValues(t, 10, gogo.Seconds)
+	assert.EqualValues(t, 20, gogo.Nanos)
+
+	gogo.Nanos = 0
+	b, err = Marshal(gogo)
+	require.NoError(t, err)
+	require.NoError(t, Unmarshal(b, google))
+	assert.True(t, proto.Equal(&timestamppb.Timestamp{Seconds: 10}, google))
+}
+
+func TestConvertNotMessage(t *testing.T) {
+	_, err := Marshal(struct{}{})
+	assert.Error(t, err)
+	assert.Error(t, Unmarshal(nil, &struct{}{}))
+}
@@ -0,0 +1,10 @@
+package protomessage
+
+import "unsafe"
+
+// EnumValues returns the values of the repeated enum field as uint32 values without
+// copying them. The generated enum types are uint32, which is the type that the
+// enums are marshaled as.
+func EnumValues[T ~uint32](values []T) []uint32 {
+	return unsafe.Slice((*uint32)(unsafe.Pointer(unsafe.SliceData(values))), len(values))
+}
@@ -0,0 +1,14 @@
+package protomessage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnum uint32
+
+func TestEnumValues(t *testing.T) {
+	assert.Empty(t, EnumValues[testEnum](nil))
+	assert.EqualValues(t, []uint32{2, 0, 1}, EnumValues([]testEnum{2, 0, 1}))
+}
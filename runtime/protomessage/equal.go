@@ -0,0 +1,12 @@
+package protomessage
+
+// EqualFloat64 returns true if a and b are equal according to Protobuf semantics,
+// i.e. they are compared using == operator except that NaNs are equal to each other.
+func EqualFloat64(a, b float64) bool {
+	return a == b || (a != a && b != b)
+}
+
+// EqualFloat32 is the float32 version of EqualFloat64.
+func EqualFloat32(a, b float32) bool {
+	return a == b || (a != a && b != b)
+}
@@ -0,0 +1,39 @@
+package protomessage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualFloat(t *testing.T) {
+	assert.True(t, EqualFloat64(1.5, 1.5))
+	assert.False(t, EqualFloat64(1.5, 2.5))
+	assert.True(t, EqualFloat64(math.NaN(), math.NaN()))
+	assert.False(t, EqualFloat64(math.NaN(), 0))
+
+	nan32 := float32(math.NaN())
+	assert.True(t, EqualFloat32(1.5, 1.5))
+	assert.True(t, EqualFloat32(nan32, nan32))
+	assert.False(t, EqualFloat32(0, nan32))
+}
+
+func TestUnknownFieldsEqual(t *testing.T) {
+	src := []byte{1, 2, 3, 4, 5, 6}
+
+	var u1, u2 UnknownFields
+	assert.True(t, u1.Equal(&u2))
+
+	u1.Add(src[0:3])
+	assert.False(t, u1.Equal(&u2))
+
+	u2.Add(src[0:1])
+	u2.Add(src[2:3])
+	assert.False(t, u1.Equal(&u2))
+
+	u2.Reset()
+	u2.Add(src[0:2])
+	u2.Add(src[2:3])
+	assert.True(t, u1.Equal(&u2))
+}
@@ -0,0 +1,183 @@
+// Package protomessage contains the state that is embedded in every message
+// generated by lazyproto and the helpers that the generated code uses to handle
+// the wire bytes of the messages. The package is used by the generated code and
+// is not intended for direct use.
+package protomessage
+
+type ProtoMessage struct {
+	// Bytes are a view into the original bytes from which this message was
+	// unmarshalled.
+	Bytes BytesView
+
+	// Parent is the parent message of this message (or nill if no parent).
+	Parent *ProtoMessage
+
+	// DiscardUnknown is set on the message that was unmarshalled with the
+	// DiscardUnknown option. Unknown fields of this message and of all its
+	// nested messages are not preserved.
+	DiscardUnknown bool
+
+	// rare is the state that most messages don't have, so that it doesn't take
+	// space in every message.
+	rare *rareState
+
+	// cachedSize is the marshalled size of the modified message plus one, or 0
+	// if the size is not cached. The size of the unmodified message is Bytes.Len.
+	cachedSize int
+}
+
+// rareState is the state of the message that is rarely set.
+type rareState struct {
+	// decodeErr is the first error of the lazy decoding of this message or of its
+	// nested messages.
+	decodeErr error
+
+	// bytesOwner is released when the message stops referencing its bytes.
+	bytesOwner BytesOwner
+}
+
+// BytesOwner owns the bytes that a message is unmarshalled from, e.g. a buffer
+// that is returned to its pool when all references to it are released.
+type BytesOwner interface {
+	// Ref adds a reference to the bytes.
+	Ref()
+	// Free releases a reference to the bytes.
+	Free()
+}
+
+func (m *ProtoMessage) rareState() *rareState {
+	if m.rare == nil {
+		m.rare = &rareState{}
+	}
+	return m.rare
+}
+
+// SetBytesOwner makes the message responsible for releasing the owner of the bytes
+// that the message is unmarshalled from. The owner is released by Reset, i.e. when
+// the message is freed or unmarshalled again. The previous owner, if any, is
+// released immediately.
+func (m *ProtoMessage) SetBytesOwner(owner BytesOwner) {
+	rare := m.rareState()
+	if rare.bytesOwner != nil {
+		rare.bytesOwner.Free()
+	}
+	rare.bytesOwner = owner
+}
+
+// ShareBytesOwner adds a reference to the owner of the bytes of src and makes the
+// message responsible for releasing it, so that the bytes stay alive while the
+// message uses them. The owner is found on src or on its closest parent that has
+// one. It is called by the generated Clone, since the clone shares the bytes with
+// src.
+func (m *ProtoMessage) ShareBytesOwner(src *ProtoMessage) {
+	for p := src; p != nil; p = p.Parent {
+		if p.rare != nil && p.rare.bytesOwner != nil {
+			p.rare.bytesOwner.Ref()
+			m.SetBytesOwner(p.rare.bytesOwner)
+			return
+		}
+	}
+}
+
+// Reset clears the state of the message for reuse and releases the owner of its
+// bytes, if any. It is called by the generated code when the message is freed or
+// unmarshalled again.
+func (m *ProtoMessage) Reset() {
+	if m.rare != nil && m.rare.bytesOwner != nil {
+		m.rare.bytesOwner.Free()
+	}
+	*m = ProtoMessage{}
+}
+
+// IsDiscardUnknown returns true if unknown fields of this message must be discarded,
+// i.e. if this message or any of its parents has DiscardUnknown set.
+func (m *ProtoMessage) IsDiscardUnknown() bool {
+	for p := m; p != nil; p = p.Parent {
+		if p.DiscardUnknown {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDecodeErr records the error of the lazy decoding of this message. The error
+// is also recorded on the parents up to the root, so that it can be found from any
+// of them. The first recorded error of a message is kept.
+func (m *ProtoMessage) SetDecodeErr(err error) {
+	for p := m; p != nil; p = p.Parent {
+		if p.DecodeErr() == nil {
+			p.rareState().decodeErr = err
+		}
+	}
+}
+
+// DecodeErr returns the first error of the lazy decoding of this message or of its
+// nested messages, or nil if there was no error.
+func (m *ProtoMessage) DecodeErr() error {
+	if m.rare == nil {
+		return nil
+	}
+	return m.rare.decodeErr
+}
+
+func (m *ProtoMessage) IsModified() bool {
+	// Bytes are set to nil when the message is modified (i.e. marshaling will do
+	// full field-by-field encoding) or if the message was created a new (was
+	// not unmarshalled).
+	return m.Bytes.Data == nil
+}
+
+// MarkModified marks this message as modified, so that future marshalling operations
+// will no longer try to re-use the original bytes. The cached sizes of the message
+// and of its parents are invalidated.
+func (m *ProtoMessage) MarkModified() {
+	if m.Bytes.Data != nil || m.cachedSize != 0 {
+		m.markModified()
+	}
+}
+
+func (m *ProtoMessage) markModified() {
+	// Reset the pointer to original bytes.
+	m.Bytes.Data = nil
+	m.Bytes.Len = 0
+	m.cachedSize = 0
+
+	// Traverse up the parents chain and mark all parents as modified too.
+	// The size is cached for the entire subtree at once, so if the parent is
+	// modified and has no cached size, then neither do its parents.
+	parent := m.Parent
+	for parent != nil {
+		if parent.IsModified() && parent.cachedSize == 0 {
+			break
+		}
+		parent.Bytes.Data = nil
+		parent.Bytes.Len = 0
+		parent.cachedSize = 0
+		parent = parent.Parent
+	}
+}
+
+// KnownSize returns the marshalled size of the message if it is known without
+// calculation, i.e. if the message is not modified or if its size was stored by
+// SetCachedSize after the last modification.
+func (m *ProtoMessage) KnownSize() (size int, ok bool) {
+	if m.Bytes.Data != nil {
+		return m.Bytes.Len, true
+	}
+	return m.cachedSize - 1, m.cachedSize != 0
+}
+
+// SetCachedSize stores the marshalled size of the modified message. It is used
+// by the generated Size() method to avoid recalculating the size of unchanged
+// nested messages.
+func (m *ProtoMessage) SetCachedSize(size int) {
+	m.cachedSize = size + 1
+}
+
+// SetMarshaledBytes points the modified message at the bytes which Marshal has
+// just written for it, making the message unmodified. b must not be modified
+// while the message references it.
+func (m *ProtoMessage) SetMarshaledBytes(b []byte) {
+	m.Bytes = BytesViewFromBytes(b)
+	m.cachedSize = 0
+}
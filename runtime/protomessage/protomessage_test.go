@@ -0,0 +1,138 @@
+package protomessage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownSize(t *testing.T) {
+	// The size of the unmodified message is the size of its bytes.
+	m := ProtoMessage{Bytes: BytesViewFromBytes([]byte{1, 2, 3})}
+	size, ok := m.KnownSize()
+	assert.True(t, ok)
+	assert.EqualValues(t, 3, size)
+
+	m.MarkModified()
+	_, ok = m.KnownSize()
+	assert.False(t, ok)
+
+	// Zero is a valid size.
+	m.SetCachedSize(0)
+	size, ok = m.KnownSize()
+	assert.True(t, ok)
+	assert.EqualValues(t, 0, size)
+
+	m.SetCachedSize(10)
+	size, ok = m.KnownSize()
+	assert.True(t, ok)
+	assert.EqualValues(t, 10, size)
+
+	m.MarkModified()
+	_, ok = m.KnownSize()
+	assert.False(t, ok)
+}
+
+func TestMarkModifiedInvalidatesParents(t *testing.T) {
+	b := []byte{1, 2, 3}
+
+	// The root is modified and has the cached size, the child is unmodified.
+	root := &ProtoMessage{}
+	root.SetCachedSize(5)
+	middle := &ProtoMessage{Parent: root}
+	middle.SetCachedSize(3)
+	child := &ProtoMessage{Parent: middle, Bytes: BytesViewFromBytes(b)}
+
+	child.MarkModified()
+	assert.True(t, child.IsModified())
+	for _, m := range []*ProtoMessage{child, middle, root} {
+		_, ok := m.KnownSize()
+		assert.False(t, ok)
+	}
+
+	// Only the parents that have the cached size are invalidated again.
+	middle.SetCachedSize(3)
+	child.SetCachedSize(1)
+	child.MarkModified()
+	_, ok := middle.KnownSize()
+	assert.False(t, ok)
+}
+
+func TestSetDecodeErr(t *testing.T) {
+	root := &ProtoMessage{}
+	child := &ProtoMessage{Parent: root}
+	sibling := &ProtoMessage{Parent: root}
+	grandchild := &ProtoMessage{Parent: child}
+
+	assert.NoError(t, root.DecodeErr())
+
+	// The error is recorded on the message and on all its parents.
+	err1 := errors.New("err1")
+	grandchild.SetDecodeErr(err1)
+	assert.Equal(t, err1, grandchild.DecodeErr())
+	assert.Equal(t, err1, child.DecodeErr())
+	assert.Equal(t, err1, root.DecodeErr())
+	assert.NoError(t, sibling.DecodeErr())
+
+	// The first error is kept.
+	err2 := errors.New("err2")
+	sibling.SetDecodeErr(err2)
+	assert.Equal(t, err2, sibling.DecodeErr())
+	assert.Equal(t, err1, root.DecodeErr())
+}
+
+type countingOwner struct {
+	refs  int
+	frees int
+}
+
+func (o *countingOwner) Ref() {
+	o.refs++
+}
+
+func (o *countingOwner) Free() {
+	o.frees++
+}
+
+func TestBytesOwner(t *testing.T) {
+	var m ProtoMessage
+	m.SetDecodeErr(errors.New("decode error"))
+
+	// The previous owner is released when replaced.
+	var o1, o2 countingOwner
+	m.SetBytesOwner(&o1)
+	m.SetBytesOwner(&o2)
+	assert.EqualValues(t, 1, o1.frees)
+	assert.EqualValues(t, 0, o2.frees)
+
+	// Reset releases the owner and clears the rest of the state.
+	m.Reset()
+	assert.EqualValues(t, 1, o1.frees)
+	assert.EqualValues(t, 1, o2.frees)
+	assert.NoError(t, m.DecodeErr())
+
+	m.Reset()
+	assert.EqualValues(t, 1, o2.frees)
+}
+
+func TestShareBytesOwner(t *testing.T) {
+	var owner countingOwner
+	var root ProtoMessage
+	root.SetBytesOwner(&owner)
+	child := ProtoMessage{Parent: &root}
+
+	// The owner of the parent is shared with the clone of the child.
+	var clone ProtoMessage
+	clone.ShareBytesOwner(&child)
+	assert.EqualValues(t, 1, owner.refs)
+
+	root.Reset()
+	clone.Reset()
+	assert.EqualValues(t, 2, owner.frees)
+
+	// Nothing is shared if there is no owner.
+	var other ProtoMessage
+	other.ShareBytesOwner(&child)
+	assert.Nil(t, other.rare)
+}
@@ -0,0 +1,69 @@
+package protomessage
+
+// ScalarSlice is a repeated field of scalar values, i.e. of numbers, booleans,
+// enums, strings or bytes. The methods that modify the slice mark the message that
+// contains the repeated field as modified.
+type ScalarSlice[T any] struct {
+	elems  *[]T
+	parent *ProtoMessage
+}
+
+// NewScalarSlice returns the ScalarSlice of the elements of the repeated field
+// of the parent message. It is used by the generated getters.
+func NewScalarSlice[T any](elems *[]T, parent *ProtoMessage) ScalarSlice[T] {
+	return ScalarSlice[T]{elems: elems, parent: parent}
+}
+
+// Len returns the number of the elements.
+func (s ScalarSlice[T]) Len() int {
+	return len(*s.elems)
+}
+
+// At returns the element at index i.
+func (s ScalarSlice[T]) At(i int) T {
+	return (*s.elems)[i]
+}
+
+// Range calls f for each element. If f returns false the iteration stops.
+func (s ScalarSlice[T]) Range(f func(i int, elem T) bool) {
+	for i, elem := range *s.elems {
+		if !f(i, elem) {
+			break
+		}
+	}
+}
+
+// AppendTo appends the elements to dst and returns the resulting slice. The
+// returned slice doesn't reference the elements of the repeated field, so it can
+// be modified without affecting the message.
+func (s ScalarSlice[T]) AppendTo(dst []T) []T {
+	return append(dst, *s.elems...)
+}
+
+// Set sets the element at index i.
+func (s ScalarSlice[T]) Set(i int, elem T) {
+	(*s.elems)[i] = elem
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// Append appends the elements to the end of the slice.
+func (s ScalarSlice[T]) Append(elems ...T) {
+	*s.elems = append(*s.elems, elems...)
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
+
+// RemoveAt removes the element at index i, shifting the subsequent elements.
+func (s ScalarSlice[T]) RemoveAt(i int) {
+	elems := *s.elems
+	copy(elems[i:], elems[i+1:])
+	var zero T
+	elems[len(elems)-1] = zero
+	*s.elems = elems[:len(elems)-1]
+
+	// Mark the parent message modified, if not already.
+	s.parent.MarkModified()
+}
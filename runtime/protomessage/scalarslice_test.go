@@ -0,0 +1,49 @@
+package protomessage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalarSlice(t *testing.T) {
+	data := []byte{1, 2, 3}
+	parent := ProtoMessage{Bytes: BytesViewFromBytes(data)}
+	m := ProtoMessage{Bytes: BytesViewFromBytes(data[1:]), Parent: &parent}
+	elems := []int32{1, 2, 3}
+	s := NewScalarSlice(&elems, &m)
+
+	assert.EqualValues(t, 3, s.Len())
+	assert.EqualValues(t, 2, s.At(1))
+	var ranged []int32
+	s.Range(
+		func(i int, elem int32) bool {
+			ranged = append(ranged, elem)
+			return i < 1
+		},
+	)
+	assert.EqualValues(t, []int32{1, 2}, ranged)
+
+	// The copy doesn't reference the elements.
+	c := s.AppendTo(nil)
+	c[0] = 10
+	assert.EqualValues(t, 1, s.At(0))
+
+	// Reading doesn't modify the message.
+	assert.False(t, m.IsModified())
+
+	s.Set(0, 10)
+	assert.EqualValues(t, []int32{10, 2, 3}, elems)
+	assert.True(t, m.IsModified())
+	assert.True(t, parent.IsModified())
+
+	m = ProtoMessage{Bytes: BytesViewFromBytes(data)}
+	s.Append(4, 5)
+	assert.EqualValues(t, []int32{10, 2, 3, 4, 5}, elems)
+	assert.True(t, m.IsModified())
+
+	m = ProtoMessage{Bytes: BytesViewFromBytes(data)}
+	s.RemoveAt(1)
+	assert.EqualValues(t, []int32{10, 3, 4, 5}, elems)
+	assert.True(t, m.IsModified())
+}
@@ -0,0 +1,89 @@
+package protomessage
+
+import (
+	"bytes"
+	"unsafe"
+)
+
+// UnknownFields records the wire representation of the fields that are not known
+// to the schema that the message was generated from. The fields are recorded as
+// views into the original bytes, so that they can be re-emitted when a modified
+// message is marshalled.
+type UnknownFields struct {
+	views []BytesView
+}
+
+// Add records the wire bytes of an unknown field (key and value). If the bytes
+// immediately follow the previously recorded bytes the views are merged.
+func (u *UnknownFields) Add(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	v := BytesViewFromBytes(b)
+	if n := len(u.views); n > 0 {
+		last := &u.views[n-1]
+		if unsafe.Add(last.Data, last.Len) == v.Data {
+			// Contiguous with the last view, extend it.
+			last.Len += v.Len
+			return
+		}
+	}
+	u.views = append(u.views, v)
+}
+
+// Len returns the number of recorded byte ranges.
+func (u *UnknownFields) Len() int {
+	return len(u.views)
+}
+
+// At returns the bytes of the byte range at index i.
+func (u *UnknownFields) At(i int) []byte {
+	return BytesFromBytesView(u.views[i])
+}
+
+// Bytes returns the wire representation of all unknown fields. If all unknown
+// fields are in one contiguous range of the original bytes no copying is done,
+// otherwise a new slice is allocated. Returns nil if there are no unknown fields.
+func (u *UnknownFields) Bytes() []byte {
+	switch len(u.views) {
+	case 0:
+		return nil
+	case 1:
+		return BytesFromBytesView(u.views[0])
+	}
+
+	size := 0
+	for _, v := range u.views {
+		size += v.Len
+	}
+	b := make([]byte, 0, size)
+	for _, v := range u.views {
+		b = append(b, BytesFromBytesView(v)...)
+	}
+	return b
+}
+
+// Reset forgets all recorded unknown fields but keeps the allocated memory
+// for reuse.
+func (u *UnknownFields) Reset() {
+	// Don't keep the referenced bytes alive.
+	for i := range u.views {
+		u.views[i] = BytesView{}
+	}
+	u.views = u.views[:0]
+}
+
+// CopyFrom makes u record the same unknown fields as src. The recorded bytes
+// are shared, not copied.
+func (u *UnknownFields) CopyFrom(src *UnknownFields) {
+	u.Reset()
+	u.views = append(u.views, src.views...)
+}
+
+// Equal returns true if u and other contain the same unknown fields bytes.
+func (u *UnknownFields) Equal(other *UnknownFields) bool {
+	if len(u.views) == 0 && len(other.views) == 0 {
+		return true
+	}
+	return bytes.Equal(u.Bytes(), other.Bytes())
+}
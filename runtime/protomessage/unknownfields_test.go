@@ -0,0 +1,58 @@
+package protomessage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownFieldsEmpty(t *testing.T) {
+	var u UnknownFields
+	assert.EqualValues(t, 0, u.Len())
+	assert.Nil(t, u.Bytes())
+
+	u.Add(nil)
+	assert.EqualValues(t, 0, u.Len())
+}
+
+func TestUnknownFieldsContiguous(t *testing.T) {
+	src := []byte{1, 2, 3, 4, 5, 6}
+
+	var u UnknownFields
+	u.Add(src[0:2])
+	u.Add(src[2:3])
+	assert.EqualValues(t, 1, u.Len())
+	assert.EqualValues(t, []byte{1, 2, 3}, u.Bytes())
+
+	u.Add(src[4:6])
+	assert.EqualValues(t, 2, u.Len())
+	assert.EqualValues(t, []byte{1, 2, 3}, u.At(0))
+	assert.EqualValues(t, []byte{5, 6}, u.At(1))
+	assert.EqualValues(t, []byte{1, 2, 3, 5, 6}, u.Bytes())
+
+	u.Reset()
+	assert.EqualValues(t, 0, u.Len())
+	assert.Nil(t, u.Bytes())
+}
+
+func TestUnknownFieldsResetReleasesBytes(t *testing.T) {
+	src := []byte{1, 2, 3, 4, 5, 6}
+
+	var u UnknownFields
+	u.Add(src[0:2])
+	u.Add(src[4:6])
+	views := u.views
+
+	// The views are cleared, so that the bytes are not kept alive by the reused
+	// memory.
+	u.Reset()
+	assert.EqualValues(t, []BytesView{{}, {}}, views[:2])
+
+	u.Add(src[0:2])
+	u.Add(src[4:6])
+	var short UnknownFields
+	short.Add(src[2:3])
+	u.CopyFrom(&short)
+	assert.EqualValues(t, []byte{3}, u.Bytes())
+	assert.EqualValues(t, BytesView{}, views[1])
+}
@@ -0,0 +1,43 @@
+package protomessage
+
+// The generated code asserts at compile time that it is compatible with the version
+// of the runtime packages it is compiled with, so that mismatched versions fail to
+// compile instead of misbehaving at run time. The generated code contains:
+//
+//	const (
+//		_ = protomessage.EnforceVersion(GenVersion - protomessage.MinVersion)
+//		_ = protomessage.EnforceVersion(protomessage.MaxVersion - GenVersion)
+//	)
+//
+// where GenVersion is the value of GenVersion at the time of generation.
+const (
+	// GenVersion is the version of the code that is currently generated.
+	// Increment it when the generated code starts using new runtime API.
+	GenVersion = 12
+
+	// MinVersion is the oldest version of the generated code that is supported
+	// by the runtime. Raise it to GenVersion whenever the runtime API or its
+	// contract that the code generated by older versions relies on is changed or
+	// removed, including the methods of lazyproto.Message: the generated code
+	// asserts that the messages implement it, and the older code must fail with
+	// the EnforceVersion error instead.
+	//
+	// The versions that changed the contract:
+	//   - 2 added MarshalJSON and UnmarshalJSON to lazyproto.Message.
+	//   - 3 added MarshalText and UnmarshalText to lazyproto.Message.
+	//   - 4 added ProtoReflect to lazyproto.Message.
+	//   - 6 added Size to lazyproto.Message.
+	//   - 7 added DecodeErr to lazyproto.Message.
+	//   - 10 started releasing the owner of the bytes in ProtoMessage.Reset, which
+	//     the older generated code doesn't call when the messages are freed.
+	//   - 11 started sharing the owner of the bytes with the clones of the messages.
+	MinVersion = 11
+
+	// MaxVersion is the newest version of the generated code that is supported
+	// by the runtime.
+	MaxVersion = GenVersion
+)
+
+// EnforceVersion is used by the generated code to assert the compatibility with
+// the runtime. Conversion of a negative constant to EnforceVersion does not compile.
+type EnforceVersion uint
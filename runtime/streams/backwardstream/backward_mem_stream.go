@@ -0,0 +1,698 @@
+// Package backwardstream implements an experimental marshaling stream that writes
+// the messages from the end to the beginning.
+package backwardstream
+
+import (
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const firstBufSize = 4096
+
+type BackwardMemStream struct {
+	curBuf      []byte
+	lastWritten int
+	nextBufSize int
+
+	readyBufs    [][]byte
+	readyBufsLen int
+
+	// joined is reused by BufferBytes to join the buffers.
+	joined []byte
+}
+
+func NewBackwardMemStream() *BackwardMemStream {
+	s := &BackwardMemStream{}
+	s.curBuf = make([]byte, firstBufSize)
+	s.lastWritten = firstBufSize
+	s.nextBufSize = firstBufSize * 2
+	return s
+}
+
+// Reset discards the written bytes. The most recently allocated buffer is reused.
+func (s *BackwardMemStream) Reset() {
+	for i := range s.readyBufs {
+		s.readyBufs[i] = nil
+	}
+	s.readyBufs = s.readyBufs[:0]
+	s.readyBufsLen = 0
+	s.lastWritten = len(s.curBuf)
+}
+
+// WriteTo writes the bytes written to the stream to out, in forward order.
+func (s *BackwardMemStream) WriteTo(out io.Writer) (int64, error) {
+	var total int64
+	n, err := out.Write(s.curBuf[s.lastWritten:])
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+	for i := len(s.readyBufs) - 1; i >= 0; i-- {
+		n, err := out.Write(s.readyBufs[i])
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// BufferBytes returns the bytes written to the stream, in forward order. The bytes
+// are valid until the next write to the stream or Reset.
+func (s *BackwardMemStream) BufferBytes() ([]byte, error) {
+	if len(s.readyBufs) == 0 {
+		// Everything is in the current buffer, no need to copy.
+		return s.curBuf[s.lastWritten:], nil
+	}
+
+	s.joined = s.joined[:0]
+	s.joined = append(s.joined, s.curBuf[s.lastWritten:]...)
+	for i := len(s.readyBufs) - 1; i >= 0; i-- {
+		s.joined = append(s.joined, s.readyBufs[i]...)
+	}
+	return s.joined, nil
+}
+
+func (s *BackwardMemStream) Len() int {
+	return s.readyBufsLen + len(s.curBuf) - s.lastWritten
+}
+
+// PreparedKey is the encoded key of a field. Only the keys of the fields with
+// numbers up to 15 can be prepared, their keys are encoded in 1 byte.
+type PreparedKey byte
+
+func PrepareField(fieldNumber int, wireType protowire.Type) PreparedKey {
+	b := protowire.AppendVarint([]byte{}, uint64(fieldNumber)<<3+uint64(wireType))
+	if len(b) > 1 {
+		panic("fieldNumber too high, max field number 15 is supported by PrepareField")
+	}
+	return PreparedKey(b[0])
+}
+
+func PrepareStringField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.BytesType)
+}
+
+func PrepareBytesField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.BytesType)
+}
+
+func PrepareEmbeddedField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.BytesType)
+}
+
+func PrepareBoolField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareInt32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareInt64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareUint32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareUint64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareSint32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareSint64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareFixed32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed32Type)
+}
+
+func PrepareFixed64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed64Type)
+}
+
+func PrepareFloatField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed32Type)
+}
+
+func PrepareDoubleField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed64Type)
+}
+
+// The methods below write the fields in backward order: the value first and then
+// the key. The fields of a message must be written in reverse order too.
+
+// DoublePrepared writes a value of proto type double to the stream.
+func (s *BackwardMemStream) DoublePrepared(fieldKey PreparedKey, value float64) {
+	if value == 0 {
+		return
+	}
+	s.writeFixed64(math.Float64bits(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// FloatPrepared writes a value of proto type float to the stream.
+func (s *BackwardMemStream) FloatPrepared(fieldKey PreparedKey, value float32) {
+	if value == 0 {
+		return
+	}
+	s.writeFixed32(math.Float32bits(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// Int32Prepared writes a value of proto type int32 to the stream.
+func (s *BackwardMemStream) Int32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	s.writeVarint(uint64(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// Int64Prepared writes a value of proto type int64 to the stream.
+func (s *BackwardMemStream) Int64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	s.writeVarint(uint64(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// Uint32Prepared writes a value of proto type uint32 to the stream.
+func (s *BackwardMemStream) Uint32Prepared(fieldKey PreparedKey, value uint32) {
+	if value == 0 {
+		return
+	}
+	s.writeVarint(uint64(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// Uint64Prepared writes a value of proto type uint64 to the stream.
+func (s *BackwardMemStream) Uint64Prepared(fieldKey PreparedKey, value uint64) {
+	if value == 0 {
+		return
+	}
+	s.writeVarint(value)
+	s.writeByte(byte(fieldKey))
+}
+
+// Sint32Prepared writes a value of proto type sint32 to the stream.
+func (s *BackwardMemStream) Sint32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	s.writeVarint(protowire.EncodeZigZag(int64(value)))
+	s.writeByte(byte(fieldKey))
+}
+
+// Sint64Prepared writes a value of proto type sint64 to the stream.
+func (s *BackwardMemStream) Sint64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	s.writeVarint(protowire.EncodeZigZag(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// Fixed32Prepared writes a value of proto type fixed32 to the stream.
+func (s *BackwardMemStream) Fixed32Prepared(fieldKey PreparedKey, value uint32) {
+	if value == 0 {
+		return
+	}
+	s.writeFixed32(value)
+	s.writeByte(byte(fieldKey))
+}
+
+// Fixed64Prepared writes a value of proto type fixed64 to the stream.
+func (s *BackwardMemStream) Fixed64Prepared(fieldKey PreparedKey, value uint64) {
+	if value == 0 {
+		return
+	}
+	s.writeFixed64(value)
+	s.writeByte(byte(fieldKey))
+}
+
+// SFixed32Prepared writes a value of proto type sfixed32 to the stream.
+func (s *BackwardMemStream) SFixed32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	s.writeFixed32(uint32(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// SFixed64Prepared writes a value of proto type sfixed64 to the stream.
+func (s *BackwardMemStream) SFixed64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	s.writeFixed64(uint64(value))
+	s.writeByte(byte(fieldKey))
+}
+
+// BoolPrepared writes a value of proto type bool to the stream.
+func (s *BackwardMemStream) BoolPrepared(fieldKey PreparedKey, value bool) {
+	if !value {
+		return
+	}
+	s.writeByte(1)
+	s.writeByte(byte(fieldKey))
+}
+
+// StringPrepared writes a string to the stream.
+func (s *BackwardMemStream) StringPrepared(key PreparedKey, value string) {
+	vlen := len(value)
+	if vlen == 0 {
+		return
+	}
+	s.writeString(value)
+	s.writeVarint(uint64(vlen))
+	s.writeByte(byte(key))
+}
+
+// BytesPrepared writes a value of proto type bytes to the stream.
+func (s *BackwardMemStream) BytesPrepared(key PreparedKey, value []byte) {
+	vlen := len(value)
+	if vlen == 0 {
+		return
+	}
+	s.Raw(value)
+	s.writeVarint(uint64(vlen))
+	s.writeByte(byte(key))
+}
+
+// ZeroPrepared writes the key followed by the zero value of the key's wire type.
+// It is used to write the fields that have explicit presence and are set to a
+// zero value.
+func (s *BackwardMemStream) ZeroPrepared(fieldKey PreparedKey) {
+	switch protowire.Type(fieldKey & 0x7) {
+	case protowire.Fixed32Type:
+		s.writeFixed32(0)
+	case protowire.Fixed64Type:
+		s.writeFixed64(0)
+	default:
+		// Zero varint and zero length of bytes are both encoded as a single 0 byte.
+		s.writeByte(0)
+	}
+	s.writeByte(byte(fieldKey))
+}
+
+// EndGroup writes the "end group" key of the field. Since the stream is written
+// backward EndGroup must be called first, then the fields of the group must be
+// written, followed by BeginGroup call.
+func (s *BackwardMemStream) EndGroup(fieldNumber int) {
+	s.writeKey(fieldNumber, protowire.EndGroupType)
+}
+
+// BeginGroup writes the "start group" key of the field.
+func (s *BackwardMemStream) BeginGroup(fieldNumber int) {
+	s.writeKey(fieldNumber, protowire.StartGroupType)
+}
+
+// DoublePacked writes a slice of values of proto type double to the stream,
+// in packed form.
+func (s *BackwardMemStream) DoublePacked(fieldNumber int, values []float64) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeFixed64(math.Float64bits(values[i]))
+	}
+	s.writePackedPrefix(fieldNumber, 8*len(values))
+}
+
+// FloatPacked writes a slice of values of proto type float to the stream,
+// in packed form.
+func (s *BackwardMemStream) FloatPacked(fieldNumber int, values []float32) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeFixed32(math.Float32bits(values[i]))
+	}
+	s.writePackedPrefix(fieldNumber, 4*len(values))
+}
+
+// Int32Packed writes a slice of values of proto type int32 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Int32Packed(fieldNumber int, values []int32) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Int64Packed writes a slice of values of proto type int64 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Int64Packed(fieldNumber int, values []int64) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Uint32Packed writes a slice of values of proto type uint32 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Uint32Packed(fieldNumber int, values []uint32) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Uint64Packed writes a slice of values of proto type uint64 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Uint64Packed(fieldNumber int, values []uint64) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Sint32Packed writes a slice of values of proto type sint32 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Sint32Packed(fieldNumber int, values []int32) {
+	if len(values) == 0 {
+		return
+	}
+	end := s.Len()
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeVarint(protowire.EncodeZigZag(int64(values[i])))
+	}
+	s.writePackedPrefix(fieldNumber, s.Len()-end)
+}
+
+// Sint64Packed writes a slice of values of proto type sint64 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Sint64Packed(fieldNumber int, values []int64) {
+	if len(values) == 0 {
+		return
+	}
+	end := s.Len()
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeVarint(protowire.EncodeZigZag(values[i]))
+	}
+	s.writePackedPrefix(fieldNumber, s.Len()-end)
+}
+
+// Fixed32Packed writes a slice of values of proto type fixed32 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Fixed32Packed(fieldNumber int, values []uint32) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeFixed32(values[i])
+	}
+	s.writePackedPrefix(fieldNumber, 4*len(values))
+}
+
+// Fixed64Packed writes a slice of values of proto type fixed64 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Fixed64Packed(fieldNumber int, values []uint64) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeFixed64(values[i])
+	}
+	s.writePackedPrefix(fieldNumber, 8*len(values))
+}
+
+// Sfixed32Packed writes a slice of values of proto type sfixed32 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Sfixed32Packed(fieldNumber int, values []int32) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeFixed32(uint32(values[i]))
+	}
+	s.writePackedPrefix(fieldNumber, 4*len(values))
+}
+
+// Sfixed64Packed writes a slice of values of proto type sfixed64 to the stream,
+// in packed form.
+func (s *BackwardMemStream) Sfixed64Packed(fieldNumber int, values []int64) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeFixed64(uint64(values[i]))
+	}
+	s.writePackedPrefix(fieldNumber, 8*len(values))
+}
+
+// BoolPacked writes a slice of values of proto type bool to the stream,
+// in packed form.
+func (s *BackwardMemStream) BoolPacked(fieldNumber int, values []bool) {
+	if len(values) == 0 {
+		return
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i] {
+			s.writeByte(1)
+		} else {
+			s.writeByte(0)
+		}
+	}
+	s.writePackedPrefix(fieldNumber, len(values))
+}
+
+func writeVarintPacked[T int32 | int64 | uint32 | uint64](
+	s *BackwardMemStream, fieldNumber int, values []T,
+) {
+	if len(values) == 0 {
+		return
+	}
+	end := s.Len()
+	for i := len(values) - 1; i >= 0; i-- {
+		s.writeVarint(uint64(values[i]))
+	}
+	s.writePackedPrefix(fieldNumber, s.Len()-end)
+}
+
+// writePackedPrefix writes the length prefix and the key of a packed field, after
+// the values are written.
+func (s *BackwardMemStream) writePackedPrefix(fieldNumber int, size int) {
+	s.writeVarint(uint64(size))
+	s.writeKey(fieldNumber, protowire.BytesType)
+}
+
+func (s *BackwardMemStream) writeKey(fieldNumber int, wireType protowire.Type) {
+	s.writeVarint(uint64(fieldNumber)<<3 + uint64(wireType))
+}
+
+type EmbeddedToken int
+
+// BeginEmbedded must be called before writing the fields of an embedded message.
+// Since the stream is written backward, the length prefix of the message is
+// written after the message by EndEmbedded and doesn't need to be known in advance.
+func (s *BackwardMemStream) BeginEmbedded() EmbeddedToken {
+	return EmbeddedToken(s.Len())
+}
+
+// EndEmbedded writes the length prefix and the key of the embedded message.
+func (s *BackwardMemStream) EndEmbedded(beginToken EmbeddedToken, fieldKey PreparedKey) {
+	embeddedSize := s.Len() - int(beginToken)
+
+	// Write in backward order.
+	s.writeVarint(uint64(embeddedSize))
+	s.writeByte(byte(fieldKey))
+}
+
+func (s *BackwardMemStream) writeByte(b byte) {
+	if s.lastWritten == 0 {
+		s.allocNewBuf()
+	}
+	s.lastWritten--
+	s.curBuf[s.lastWritten] = b
+}
+
+func (s *BackwardMemStream) appendReadyBuf(b []byte) {
+	s.readyBufs = append(s.readyBufs, b)
+	s.readyBufsLen += len(b)
+}
+
+// Raw writes the byte sequence as is. Used to write prepared embedded byte sequences.
+func (s *BackwardMemStream) Raw(b []byte) {
+	if len(b) >= firstBufSize {
+		// Lots of bytes, just make it a ready buffer itself.
+		// First we need to push current accumulated buffer:
+		s.appendReadyBuf(s.curBuf[s.lastWritten:])
+
+		if s.lastWritten > 0 {
+			// Free space remains in the current buffer. Make it available
+			// for future writes.
+			s.curBuf = s.curBuf[:s.lastWritten:s.lastWritten]
+		} else {
+			// No more free same in the current buffer, just allocate a new one.
+			s.curBuf = make([]byte, firstBufSize)
+			s.lastWritten = firstBufSize
+		}
+		// Now add the new bytes.
+		s.appendReadyBuf(b)
+		return
+	}
+
+	bytesFit := s.lastWritten
+	if bytesFit > len(b) {
+		bytesFit = len(b)
+	}
+	s.lastWritten -= bytesFit
+	copy(s.curBuf[s.lastWritten:], b[len(b)-bytesFit:])
+
+	bytesRemaining := len(b) - bytesFit
+	if bytesFit < 0 {
+		panic("bad")
+	}
+
+	if s.lastWritten == 0 {
+		s.allocNewBuf()
+	}
+
+	if bytesRemaining > 0 {
+		s.lastWritten -= bytesRemaining
+		copy(s.curBuf[s.lastWritten:], b[:bytesRemaining])
+	}
+}
+
+// writeString copies the string to the stream.
+func (s *BackwardMemStream) writeString(v string) {
+	for len(v) > 0 {
+		if s.lastWritten == 0 {
+			s.allocNewBuf()
+		}
+		n := s.lastWritten
+		if n > len(v) {
+			n = len(v)
+		}
+		s.lastWritten -= n
+		copy(s.curBuf[s.lastWritten:], v[len(v)-n:])
+		v = v[:len(v)-n]
+	}
+}
+
+func (s *BackwardMemStream) writeFixed32(v uint32) {
+	if s.lastWritten < 4 {
+		s.allocNewBuf()
+	}
+	s.curBuf[s.lastWritten-4] = byte(v >> 0)
+	s.curBuf[s.lastWritten-3] = byte(v >> 8)
+	s.curBuf[s.lastWritten-2] = byte(v >> 16)
+	s.curBuf[s.lastWritten-1] = byte(v >> 24)
+	s.lastWritten -= 4
+}
+
+func (s *BackwardMemStream) allocNewBuf() {
+	s.appendReadyBuf(s.curBuf[s.lastWritten:])
+
+	// Double the buf size on every new allocation to have constant amortized cost.
+	s.curBuf = make([]byte, s.nextBufSize)
+	s.lastWritten = s.nextBufSize
+	s.nextBufSize *= 2
+}
+
+func (s *BackwardMemStream) writeFixed64(v uint64) {
+	if s.lastWritten < 8 {
+		s.allocNewBuf()
+	}
+	s.curBuf[s.lastWritten-8] = byte(v >> 0)
+	s.curBuf[s.lastWritten-7] = byte(v >> 8)
+	s.curBuf[s.lastWritten-6] = byte(v >> 16)
+	s.curBuf[s.lastWritten-5] = byte(v >> 24)
+	s.curBuf[s.lastWritten-4] = byte(v >> 32)
+	s.curBuf[s.lastWritten-3] = byte(v >> 40)
+	s.curBuf[s.lastWritten-2] = byte(v >> 48)
+	s.curBuf[s.lastWritten-1] = byte(v >> 56)
+	s.lastWritten -= 8
+}
+
+func (s *BackwardMemStream) writeVarint(v uint64) {
+	if s.lastWritten < 10 {
+		s.allocNewBuf()
+	}
+
+	switch {
+	case v < 1<<7:
+		s.curBuf[s.lastWritten-1] = byte(v)
+		s.lastWritten -= 1
+
+	case v < 1<<14:
+		s.curBuf[s.lastWritten-2] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 7)
+		s.lastWritten -= 2
+
+	case v < 1<<21:
+		s.curBuf[s.lastWritten-3] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 14)
+		s.lastWritten -= 3
+
+	case v < 1<<28:
+		s.curBuf[s.lastWritten-4] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 21)
+		s.lastWritten -= 4
+
+	case v < 1<<35:
+		s.curBuf[s.lastWritten-5] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-4] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>21)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 28)
+		s.lastWritten -= 5
+
+	case v < 1<<42:
+		s.curBuf[s.lastWritten-6] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-5] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-4] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>21)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>28)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 35)
+		s.lastWritten -= 6
+
+	case v < 1<<49:
+		s.curBuf[s.lastWritten-7] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-6] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-5] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-4] = byte((v>>21)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>28)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>35)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 42)
+		s.lastWritten -= 7
+
+	case v < 1<<56:
+		s.curBuf[s.lastWritten-8] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-7] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-6] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-5] = byte((v>>21)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-4] = byte((v>>28)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>35)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>42)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 49)
+		s.lastWritten -= 8
+
+	case v < 1<<63:
+		s.curBuf[s.lastWritten-9] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-8] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-7] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-6] = byte((v>>21)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-5] = byte((v>>28)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-4] = byte((v>>35)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>42)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>49)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = byte(v >> 56)
+		s.lastWritten -= 9
+
+	default:
+		s.curBuf[s.lastWritten-10] = byte((v>>0)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-9] = byte((v>>7)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-8] = byte((v>>14)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-7] = byte((v>>21)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-6] = byte((v>>28)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-5] = byte((v>>35)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-4] = byte((v>>42)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-3] = byte((v>>49)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-2] = byte((v>>56)&0x7f | 0x80)
+		s.curBuf[s.lastWritten-1] = 1
+		s.lastWritten -= 10
+	}
+}
@@ -24,7 +24,7 @@ func TestBackwardMemStreamByte(t *testing.T) {
 				}
 				var destBytes []byte
 				destBuf := bytes.NewBuffer(destBytes)
-				err := s.WriteTo(destBuf)
+				_, err := s.WriteTo(destBuf)
 				assert.NoError(t, err)
 
 				destBytes = destBuf.Bytes()
@@ -67,7 +67,7 @@ func TestBackwardMemStreamBytes(t *testing.T) {
 				}
 				var destBytes []byte
 				destBuf := bytes.NewBuffer(destBytes)
-				err := s.WriteTo(destBuf)
+				_, err := s.WriteTo(destBuf)
 				assert.NoError(t, err)
 
 				destBytes = destBuf.Bytes()
@@ -104,7 +104,7 @@ func TestBackwardMemStreamVarint(t *testing.T) {
 
 				var destBytes []byte
 				destBuf := bytes.NewBuffer(destBytes)
-				err := s.WriteTo(destBuf)
+				_, err := s.WriteTo(destBuf)
 				assert.NoError(t, err)
 
 				destBytes = destBuf.Bytes()
@@ -0,0 +1,110 @@
+package backwardstream
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+func TestWritersMatchMolecule(t *testing.T) {
+	longString := strings.Repeat("s", 3*firstBufSize)
+	longBytes := make([]byte, 2*firstBufSize)
+
+	expected := molecule.NewProtoStream()
+	expected.DoublePrepared(molecule.PrepareDoubleField(1), math.Pi)
+	expected.FloatPrepared(molecule.PrepareFloatField(2), -1.5)
+	expected.Int32Prepared(molecule.PrepareInt32Field(3), -1)
+	expected.Int64Prepared(molecule.PrepareInt64Field(4), math.MaxInt64)
+	expected.Uint32Prepared(molecule.PrepareUint32Field(5), 300)
+	expected.Uint64Prepared(molecule.PrepareUint64Field(6), math.MaxUint64)
+	expected.Sint32Prepared(molecule.PrepareSint32Field(7), math.MinInt32)
+	expected.Sint64Prepared(molecule.PrepareSint64Field(8), -2)
+	expected.Fixed32Prepared(molecule.PrepareFixed32Field(9), 7)
+	expected.Fixed64Prepared(molecule.PrepareFixed64Field(10), 8)
+	expected.SFixed32Prepared(molecule.PrepareFixed32Field(11), -9)
+	expected.SFixed64Prepared(molecule.PrepareFixed64Field(12), -10)
+	expected.BoolPrepared(molecule.PrepareBoolField(13), true)
+	expected.StringPrepared(molecule.PrepareStringField(14), longString)
+	expected.BytesPrepared(molecule.PrepareBytesField(15), longBytes)
+	expected.ZeroPrepared(molecule.PrepareDoubleField(1))
+	expected.ZeroPrepared(molecule.PrepareFloatField(2))
+	expected.ZeroPrepared(molecule.PrepareStringField(14))
+	expected.BeginGroup(16)
+	expected.EndGroup(16)
+	expected.DoublePacked(17, []float64{1, 0, -1})
+	expected.FloatPacked(18, []float32{1, 0})
+	expected.Int32Packed(19, []int32{-1, 0, 1})
+	expected.Int64Packed(20, []int64{math.MinInt64, 300})
+	expected.Uint32Packed(21, []uint32{1, 1 << 31})
+	expected.Uint64Packed(22, []uint64{math.MaxUint64})
+	expected.Sint32Packed(23, []int32{-1, math.MaxInt32})
+	expected.Sint64Packed(24, []int64{math.MinInt64})
+	expected.Fixed32Packed(25, []uint32{1, 2})
+	expected.Fixed64Packed(26, []uint64{3})
+	expected.Sfixed32Packed(27, []int32{-4})
+	expected.Sfixed64Packed(28, []int64{-5})
+	expected.BoolPacked(29, []bool{true, false})
+	expected.Int32Packed(30, nil)
+	embedded := expected.BeginEmbedded()
+	expected.StringPrepared(molecule.PrepareStringField(1), "embedded")
+	expected.EndEmbeddedPrepared(embedded, molecule.PrepareEmbeddedField(15))
+
+	// The same fields written in reverse order.
+	s := NewBackwardMemStream()
+	token := s.BeginEmbedded()
+	s.StringPrepared(PrepareStringField(1), "embedded")
+	s.EndEmbedded(token, PrepareEmbeddedField(15))
+	s.Int32Packed(30, nil)
+	s.BoolPacked(29, []bool{true, false})
+	s.Sfixed64Packed(28, []int64{-5})
+	s.Sfixed32Packed(27, []int32{-4})
+	s.Fixed64Packed(26, []uint64{3})
+	s.Fixed32Packed(25, []uint32{1, 2})
+	s.Sint64Packed(24, []int64{math.MinInt64})
+	s.Sint32Packed(23, []int32{-1, math.MaxInt32})
+	s.Uint64Packed(22, []uint64{math.MaxUint64})
+	s.Uint32Packed(21, []uint32{1, 1 << 31})
+	s.Int64Packed(20, []int64{math.MinInt64, 300})
+	s.Int32Packed(19, []int32{-1, 0, 1})
+	s.FloatPacked(18, []float32{1, 0})
+	s.DoublePacked(17, []float64{1, 0, -1})
+	s.EndGroup(16)
+	s.BeginGroup(16)
+	s.ZeroPrepared(PrepareStringField(14))
+	s.ZeroPrepared(PrepareFloatField(2))
+	s.ZeroPrepared(PrepareDoubleField(1))
+	s.BytesPrepared(PrepareBytesField(15), longBytes)
+	s.StringPrepared(PrepareStringField(14), longString)
+	s.BoolPrepared(PrepareBoolField(13), true)
+	s.SFixed64Prepared(PrepareFixed64Field(12), -10)
+	s.SFixed32Prepared(PrepareFixed32Field(11), -9)
+	s.Fixed64Prepared(PrepareFixed64Field(10), 8)
+	s.Fixed32Prepared(PrepareFixed32Field(9), 7)
+	s.Sint64Prepared(PrepareSint64Field(8), -2)
+	s.Sint32Prepared(PrepareSint32Field(7), math.MinInt32)
+	s.Uint64Prepared(PrepareUint64Field(6), math.MaxUint64)
+	s.Uint32Prepared(PrepareUint32Field(5), 300)
+	s.Int64Prepared(PrepareInt64Field(4), math.MaxInt64)
+	s.Int32Prepared(PrepareInt32Field(3), -1)
+	s.FloatPrepared(PrepareFloatField(2), -1.5)
+	s.DoublePrepared(PrepareDoubleField(1), math.Pi)
+
+	expectedBytes, err := expected.BufferBytes()
+	require.NoError(t, err)
+	b, err := s.BufferBytes()
+	require.NoError(t, err)
+	assert.EqualValues(t, expectedBytes, b)
+	assert.EqualValues(t, len(expectedBytes), s.Len())
+
+	// The stream can be reused after Reset.
+	s.Reset()
+	s.Uint32Prepared(PrepareUint32Field(5), 300)
+	b, err = s.BufferBytes()
+	require.NoError(t, err)
+	assert.EqualValues(t, []byte{5 << 3, 0xac, 0x02}, b)
+}
@@ -0,0 +1,625 @@
+// Package sizedstream implements an experimental forward marshaling stream that
+// requires the sizes of the embedded messages to be known in advance.
+package sizedstream
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ProtoStream writes to a preallocated buffer without checking its capacity on
+// every write. The space must be reserved by Grow before writing, which requires
+// the size of the written data to be known in advance. Raw is the only method
+// that grows the buffer by itself.
+type ProtoStream struct {
+	buf          []byte
+	ofs          int
+	scratchArray [20]byte
+}
+
+const defaultBufferSize = 1024 * 200
+
+func NewProtoStream() *ProtoStream {
+	s := &ProtoStream{}
+	s.buf = make([]byte, defaultBufferSize)
+	return s
+}
+
+func (s *ProtoStream) Reset() {
+	s.ofs = 0
+}
+
+func (s *ProtoStream) BufferBytes() ([]byte, error) {
+	return s.buf[:s.ofs], nil
+}
+
+func (s *ProtoStream) Len() int {
+	return s.ofs
+}
+
+// Grow ensures that at least n more bytes can be written to the stream.
+func (s *ProtoStream) Grow(n int) {
+	if len(s.buf)-s.ofs >= n {
+		return
+	}
+	newSize := 2 * len(s.buf)
+	if newSize < s.ofs+n {
+		newSize = s.ofs + n
+	}
+	buf := make([]byte, newSize)
+	copy(buf, s.buf[:s.ofs])
+	s.buf = buf
+}
+
+// PreparedKey is the encoded key of a field. Only the keys of the fields with
+// numbers up to 15 can be prepared, their keys are encoded in 1 byte.
+type PreparedKey byte
+
+func PrepareField(fieldNumber int, wireType protowire.Type) PreparedKey {
+	b := protowire.AppendVarint([]byte{}, uint64(fieldNumber)<<3+uint64(wireType))
+	if len(b) > 1 {
+		panic("fieldNumber too high, max field number 15 is supported by PrepareField")
+	}
+	return PreparedKey(b[0])
+}
+
+func PrepareStringField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.BytesType)
+}
+
+func PrepareBytesField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.BytesType)
+}
+
+func PrepareEmbeddedField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.BytesType)
+}
+
+func PrepareBoolField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareInt32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareInt64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareUint32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareUint64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareSint32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareSint64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.VarintType)
+}
+
+func PrepareFixed32Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed32Type)
+}
+
+func PrepareFixed64Field(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed64Type)
+}
+
+func PrepareFloatField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed32Type)
+}
+
+func PrepareDoubleField(fieldNumber int) PreparedKey {
+	return PrepareField(fieldNumber, protowire.Fixed64Type)
+}
+
+// DoublePrepared writes a value of proto type double to the stream.
+func (s *ProtoStream) DoublePrepared(fieldKey PreparedKey, value float64) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeFixed64(math.Float64bits(value))
+}
+
+// FloatPrepared writes a value of proto type float to the stream.
+func (s *ProtoStream) FloatPrepared(fieldKey PreparedKey, value float32) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeFixed32(math.Float32bits(value))
+}
+
+// Int32Prepared writes a value of proto type int32 to the stream.
+func (s *ProtoStream) Int32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(uint64(value))
+}
+
+// Int64Prepared writes a value of proto type int64 to the stream.
+func (s *ProtoStream) Int64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(uint64(value))
+}
+
+// Uint32Prepared writes a value of proto type uint32 to the stream.
+func (s *ProtoStream) Uint32Prepared(fieldKey PreparedKey, value uint32) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(uint64(value))
+}
+
+// Uint64Prepared writes a value of proto type uint64 to the stream.
+func (s *ProtoStream) Uint64Prepared(fieldKey PreparedKey, value uint64) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(value)
+}
+
+// Sint32Prepared writes a value of proto type sint32 to the stream.
+func (s *ProtoStream) Sint32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(protowire.EncodeZigZag(int64(value)))
+}
+
+// Sint64Prepared writes a value of proto type sint64 to the stream.
+func (s *ProtoStream) Sint64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(protowire.EncodeZigZag(value))
+}
+
+// Fixed32Prepared writes a value of proto type fixed32 to the stream.
+func (s *ProtoStream) Fixed32Prepared(fieldKey PreparedKey, value uint32) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeFixed32(value)
+}
+
+// Fixed64Prepared writes a value of proto type fixed64 to the stream.
+func (s *ProtoStream) Fixed64Prepared(fieldKey PreparedKey, value uint64) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeFixed64(value)
+}
+
+// SFixed32Prepared writes a value of proto type sfixed32 to the stream.
+func (s *ProtoStream) SFixed32Prepared(fieldKey PreparedKey, value int32) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeFixed32(uint32(value))
+}
+
+// SFixed64Prepared writes a value of proto type sfixed64 to the stream.
+func (s *ProtoStream) SFixed64Prepared(fieldKey PreparedKey, value int64) {
+	if value == 0 {
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeFixed64(uint64(value))
+}
+
+// BoolPrepared writes a value of proto type bool to the stream.
+func (s *ProtoStream) BoolPrepared(fieldKey PreparedKey, value bool) {
+	if !value {
+		return
+	}
+	s.buf[s.ofs] = byte(fieldKey)
+	s.buf[s.ofs+1] = 1
+	s.ofs += 2
+}
+
+// StringPrepared writes a string to the stream.
+func (s *ProtoStream) StringPrepared(key PreparedKey, value string) {
+	vlen := len(value)
+	if vlen == 0 {
+		return
+	}
+
+	if vlen < 1<<7 {
+		s.buf[s.ofs] = byte(key)
+		s.buf[s.ofs+1] = byte(vlen)
+		s.ofs += 2
+	} else {
+		s.writeByte(byte(key))
+		s.writeVarint(uint64(vlen))
+	}
+	copy(s.buf[s.ofs:], value)
+	s.ofs += len(value)
+}
+
+// BytesPrepared writes a value of proto type bytes to the stream.
+func (s *ProtoStream) BytesPrepared(key PreparedKey, value []byte) {
+	vlen := len(value)
+	if vlen == 0 {
+		return
+	}
+	s.writeByte(byte(key))
+	s.writeVarint(uint64(vlen))
+	copy(s.buf[s.ofs:], value)
+	s.ofs += len(value)
+}
+
+// ZeroPrepared writes the key followed by the zero value of the key's wire type.
+// It is used to write the fields that have explicit presence and are set to a
+// zero value.
+func (s *ProtoStream) ZeroPrepared(fieldKey PreparedKey) {
+	s.writeByte(byte(fieldKey))
+	switch protowire.Type(fieldKey & 0x7) {
+	case protowire.Fixed32Type:
+		s.writeFixed32(0)
+	case protowire.Fixed64Type:
+		s.writeFixed64(0)
+	default:
+		// Zero varint and zero length of bytes are both encoded as a single 0 byte.
+		s.writeByte(0)
+	}
+}
+
+// EmbeddedSizePrepared writes the key and the length prefix of an embedded
+// message of the specified size. The message must be written next.
+func (s *ProtoStream) EmbeddedSizePrepared(fieldKey PreparedKey, size int) {
+	if size < 1<<7 {
+		s.buf[s.ofs] = byte(fieldKey)
+		s.buf[s.ofs+1] = byte(size)
+		s.ofs += 2
+		return
+	}
+	s.writeByte(byte(fieldKey))
+	s.writeVarint(uint64(size))
+}
+
+// BeginGroup writes the "start group" key of the field. The fields of the group
+// must be written next, followed by EndGroup call.
+func (s *ProtoStream) BeginGroup(fieldNumber int) {
+	s.writeKey(fieldNumber, protowire.StartGroupType)
+}
+
+// EndGroup writes the "end group" key of the field.
+func (s *ProtoStream) EndGroup(fieldNumber int) {
+	s.writeKey(fieldNumber, protowire.EndGroupType)
+}
+
+// DoublePacked writes a slice of values of proto type double to the stream,
+// in packed form.
+func (s *ProtoStream) DoublePacked(fieldNumber int, values []float64) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, 8*len(values))
+	for _, value := range values {
+		s.writeFixed64(math.Float64bits(value))
+	}
+}
+
+// FloatPacked writes a slice of values of proto type float to the stream,
+// in packed form.
+func (s *ProtoStream) FloatPacked(fieldNumber int, values []float32) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, 4*len(values))
+	for _, value := range values {
+		s.writeFixed32(math.Float32bits(value))
+	}
+}
+
+// Int32Packed writes a slice of values of proto type int32 to the stream,
+// in packed form.
+func (s *ProtoStream) Int32Packed(fieldNumber int, values []int32) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Int64Packed writes a slice of values of proto type int64 to the stream,
+// in packed form.
+func (s *ProtoStream) Int64Packed(fieldNumber int, values []int64) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Uint32Packed writes a slice of values of proto type uint32 to the stream,
+// in packed form.
+func (s *ProtoStream) Uint32Packed(fieldNumber int, values []uint32) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Uint64Packed writes a slice of values of proto type uint64 to the stream,
+// in packed form.
+func (s *ProtoStream) Uint64Packed(fieldNumber int, values []uint64) {
+	writeVarintPacked(s, fieldNumber, values)
+}
+
+// Sint32Packed writes a slice of values of proto type sint32 to the stream,
+// in packed form.
+func (s *ProtoStream) Sint32Packed(fieldNumber int, values []int32) {
+	writeZigZagPacked(s, fieldNumber, values)
+}
+
+// Sint64Packed writes a slice of values of proto type sint64 to the stream,
+// in packed form.
+func (s *ProtoStream) Sint64Packed(fieldNumber int, values []int64) {
+	writeZigZagPacked(s, fieldNumber, values)
+}
+
+// Fixed32Packed writes a slice of values of proto type fixed32 to the stream,
+// in packed form.
+func (s *ProtoStream) Fixed32Packed(fieldNumber int, values []uint32) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, 4*len(values))
+	for _, value := range values {
+		s.writeFixed32(value)
+	}
+}
+
+// Fixed64Packed writes a slice of values of proto type fixed64 to the stream,
+// in packed form.
+func (s *ProtoStream) Fixed64Packed(fieldNumber int, values []uint64) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, 8*len(values))
+	for _, value := range values {
+		s.writeFixed64(value)
+	}
+}
+
+// Sfixed32Packed writes a slice of values of proto type sfixed32 to the stream,
+// in packed form.
+func (s *ProtoStream) Sfixed32Packed(fieldNumber int, values []int32) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, 4*len(values))
+	for _, value := range values {
+		s.writeFixed32(uint32(value))
+	}
+}
+
+// Sfixed64Packed writes a slice of values of proto type sfixed64 to the stream,
+// in packed form.
+func (s *ProtoStream) Sfixed64Packed(fieldNumber int, values []int64) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, 8*len(values))
+	for _, value := range values {
+		s.writeFixed64(uint64(value))
+	}
+}
+
+// BoolPacked writes a slice of values of proto type bool to the stream,
+// in packed form.
+func (s *ProtoStream) BoolPacked(fieldNumber int, values []bool) {
+	if len(values) == 0 {
+		return
+	}
+	s.writePackedPrefix(fieldNumber, len(values))
+	for _, value := range values {
+		if value {
+			s.writeByte(1)
+		} else {
+			s.writeByte(0)
+		}
+	}
+}
+
+func writeVarintPacked[T int32 | int64 | uint32 | uint64](
+	s *ProtoStream, fieldNumber int, values []T,
+) {
+	if len(values) == 0 {
+		return
+	}
+	size := 0
+	for _, value := range values {
+		size += protowire.SizeVarint(uint64(value))
+	}
+	s.writePackedPrefix(fieldNumber, size)
+	for _, value := range values {
+		s.writeVarint(uint64(value))
+	}
+}
+
+func writeZigZagPacked[T int32 | int64](s *ProtoStream, fieldNumber int, values []T) {
+	if len(values) == 0 {
+		return
+	}
+	size := 0
+	for _, value := range values {
+		size += protowire.SizeVarint(protowire.EncodeZigZag(int64(value)))
+	}
+	s.writePackedPrefix(fieldNumber, size)
+	for _, value := range values {
+		s.writeVarint(protowire.EncodeZigZag(int64(value)))
+	}
+}
+
+// writePackedPrefix writes the key and the length prefix of a packed field.
+func (s *ProtoStream) writePackedPrefix(fieldNumber int, size int) {
+	s.writeKey(fieldNumber, protowire.BytesType)
+	s.writeVarint(uint64(size))
+}
+
+func (s *ProtoStream) writeKey(fieldNumber int, wireType protowire.Type) {
+	s.writeVarint(uint64(fieldNumber)<<3 + uint64(wireType))
+}
+
+type EmbeddedToken int
+
+func (s *ProtoStream) BeginEmbedded() EmbeddedToken {
+	ofs := s.ofs
+	s.ofs += 2
+	return EmbeddedToken(ofs)
+}
+
+func (s *ProtoStream) EndEmbeddedPrepared(
+	startPos EmbeddedToken, fieldKey PreparedKey,
+) {
+	embeddedSize := s.Len() - int(startPos) - 2
+
+	if uint64(embeddedSize) < 1<<7 {
+		s.buf[startPos] = byte(fieldKey)
+		s.buf[startPos+1] = byte(embeddedSize)
+		return
+	}
+
+	scratchBuffer := s.scratchArray[:0]
+	scratchBuffer = append(scratchBuffer, byte(fieldKey))
+	scratchBuffer = protowire.AppendVarint(scratchBuffer, uint64(embeddedSize))
+
+	shift := len(scratchBuffer) - 2
+	copy(s.buf[int(startPos)+2+shift:], s.buf[startPos+2:s.ofs])
+	copy(s.buf[startPos:], scratchBuffer)
+	s.ofs += shift
+}
+
+func (s *ProtoStream) writeByte(b byte) {
+	s.buf[s.ofs] = b
+	s.ofs++
+}
+
+// Raw writes the byte sequence as is. Used to write prepared embedded byte sequences.
+// Unlike other methods it grows the buffer if needed.
+func (s *ProtoStream) Raw(b []byte) {
+	s.Grow(len(b))
+	copy(s.buf[s.ofs:], b)
+	s.ofs += len(b)
+}
+
+func (s *ProtoStream) writeFixed32(v uint32) {
+	s.buf[s.ofs] = byte(v >> 0)
+	s.buf[s.ofs+1] = byte(v >> 8)
+	s.buf[s.ofs+2] = byte(v >> 16)
+	s.buf[s.ofs+3] = byte(v >> 24)
+	s.ofs += 4
+}
+
+func (s *ProtoStream) writeFixed64(v uint64) {
+	s.buf[s.ofs] = byte(v >> 0)
+	s.buf[s.ofs+1] = byte(v >> 8)
+	s.buf[s.ofs+2] = byte(v >> 16)
+	s.buf[s.ofs+3] = byte(v >> 24)
+	s.buf[s.ofs+4] = byte(v >> 32)
+	s.buf[s.ofs+5] = byte(v >> 40)
+	s.buf[s.ofs+6] = byte(v >> 48)
+	s.buf[s.ofs+7] = byte(v >> 56)
+	s.ofs += 8
+}
+
+func (s *ProtoStream) writeVarint(v uint64) {
+	switch {
+	case v < 1<<7:
+		s.buf[s.ofs] = byte(v)
+		s.ofs += 1
+
+	case v < 1<<14:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte(v >> 7)
+		s.ofs += 2
+
+	case v < 1<<21:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte(v >> 14)
+		s.ofs += 3
+
+	case v < 1<<28:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte(v >> 21)
+		s.ofs += 4
+
+	case v < 1<<35:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte((v>>21)&0x7f | 0x80)
+		s.buf[s.ofs+4] = byte(v >> 28)
+		s.ofs += 5
+
+	case v < 1<<42:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte((v>>21)&0x7f | 0x80)
+		s.buf[s.ofs+4] = byte((v>>28)&0x7f | 0x80)
+		s.buf[s.ofs+5] = byte(v >> 35)
+		s.ofs += 6
+
+	case v < 1<<49:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte((v>>21)&0x7f | 0x80)
+		s.buf[s.ofs+4] = byte((v>>28)&0x7f | 0x80)
+		s.buf[s.ofs+5] = byte((v>>35)&0x7f | 0x80)
+		s.buf[s.ofs+6] = byte(v >> 42)
+		s.ofs += 7
+
+	case v < 1<<56:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte((v>>21)&0x7f | 0x80)
+		s.buf[s.ofs+4] = byte((v>>28)&0x7f | 0x80)
+		s.buf[s.ofs+5] = byte((v>>35)&0x7f | 0x80)
+		s.buf[s.ofs+6] = byte((v>>42)&0x7f | 0x80)
+		s.buf[s.ofs+7] = byte(v >> 49)
+		s.ofs += 8
+
+	case v < 1<<63:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte((v>>21)&0x7f | 0x80)
+		s.buf[s.ofs+4] = byte((v>>28)&0x7f | 0x80)
+		s.buf[s.ofs+5] = byte((v>>35)&0x7f | 0x80)
+		s.buf[s.ofs+6] = byte((v>>42)&0x7f | 0x80)
+		s.buf[s.ofs+7] = byte((v>>49)&0x7f | 0x80)
+		s.buf[s.ofs+8] = byte(v >> 56)
+		s.ofs += 9
+
+	default:
+		s.buf[s.ofs] = byte((v>>0)&0x7f | 0x80)
+		s.buf[s.ofs+1] = byte((v>>7)&0x7f | 0x80)
+		s.buf[s.ofs+2] = byte((v>>14)&0x7f | 0x80)
+		s.buf[s.ofs+3] = byte((v>>21)&0x7f | 0x80)
+		s.buf[s.ofs+4] = byte((v>>28)&0x7f | 0x80)
+		s.buf[s.ofs+5] = byte((v>>35)&0x7f | 0x80)
+		s.buf[s.ofs+6] = byte((v>>42)&0x7f | 0x80)
+		s.buf[s.ofs+7] = byte((v>>49)&0x7f | 0x80)
+		s.buf[s.ofs+8] = byte((v>>56)&0x7f | 0x80)
+		s.buf[s.ofs+9] = 1
+		s.ofs += 10
+	}
+}
@@ -0,0 +1,105 @@
+package sizedstream
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/molecule"
+)
+
+func TestWritersMatchMolecule(t *testing.T) {
+	expected := molecule.NewProtoStream()
+	expected.DoublePrepared(molecule.PrepareDoubleField(1), math.Pi)
+	expected.FloatPrepared(molecule.PrepareFloatField(2), -1.5)
+	expected.Int32Prepared(molecule.PrepareInt32Field(3), -1)
+	expected.Int64Prepared(molecule.PrepareInt64Field(4), math.MaxInt64)
+	expected.Uint32Prepared(molecule.PrepareUint32Field(5), 300)
+	expected.Uint64Prepared(molecule.PrepareUint64Field(6), math.MaxUint64)
+	expected.Sint32Prepared(molecule.PrepareSint32Field(7), math.MinInt32)
+	expected.Sint64Prepared(molecule.PrepareSint64Field(8), -2)
+	expected.Fixed32Prepared(molecule.PrepareFixed32Field(9), 7)
+	expected.Fixed64Prepared(molecule.PrepareFixed64Field(10), 8)
+	expected.SFixed32Prepared(molecule.PrepareFixed32Field(11), -9)
+	expected.SFixed64Prepared(molecule.PrepareFixed64Field(12), -10)
+	expected.BoolPrepared(molecule.PrepareBoolField(13), true)
+	expected.StringPrepared(molecule.PrepareStringField(14), strings.Repeat("s", 200))
+	expected.BytesPrepared(molecule.PrepareBytesField(15), []byte{1, 2})
+	expected.ZeroPrepared(molecule.PrepareDoubleField(1))
+	expected.ZeroPrepared(molecule.PrepareFloatField(2))
+	expected.ZeroPrepared(molecule.PrepareStringField(14))
+	expected.EmbeddedSizePrepared(molecule.PrepareEmbeddedField(15), 1000)
+	expected.BeginGroup(16)
+	expected.EndGroup(16)
+	expected.DoublePacked(17, []float64{1, 0, -1})
+	expected.FloatPacked(18, []float32{1, 0})
+	expected.Int32Packed(19, []int32{-1, 0, 1})
+	expected.Int64Packed(20, []int64{math.MinInt64, 300})
+	expected.Uint32Packed(21, []uint32{1, 1 << 31})
+	expected.Uint64Packed(22, []uint64{math.MaxUint64})
+	expected.Sint32Packed(23, []int32{-1, math.MaxInt32})
+	expected.Sint64Packed(24, []int64{math.MinInt64})
+	expected.Fixed32Packed(25, []uint32{1, 2})
+	expected.Fixed64Packed(26, []uint64{3})
+	expected.Sfixed32Packed(27, []int32{-4})
+	expected.Sfixed64Packed(28, []int64{-5})
+	expected.BoolPacked(29, []bool{true, false})
+	expected.Int32Packed(30, nil)
+
+	s := NewProtoStream()
+	s.DoublePrepared(PrepareDoubleField(1), math.Pi)
+	s.FloatPrepared(PrepareFloatField(2), -1.5)
+	s.Int32Prepared(PrepareInt32Field(3), -1)
+	s.Int64Prepared(PrepareInt64Field(4), math.MaxInt64)
+	s.Uint32Prepared(PrepareUint32Field(5), 300)
+	s.Uint64Prepared(PrepareUint64Field(6), math.MaxUint64)
+	s.Sint32Prepared(PrepareSint32Field(7), math.MinInt32)
+	s.Sint64Prepared(PrepareSint64Field(8), -2)
+	s.Fixed32Prepared(PrepareFixed32Field(9), 7)
+	s.Fixed64Prepared(PrepareFixed64Field(10), 8)
+	s.SFixed32Prepared(PrepareFixed32Field(11), -9)
+	s.SFixed64Prepared(PrepareFixed64Field(12), -10)
+	s.BoolPrepared(PrepareBoolField(13), true)
+	s.StringPrepared(PrepareStringField(14), strings.Repeat("s", 200))
+	s.BytesPrepared(PrepareBytesField(15), []byte{1, 2})
+	s.ZeroPrepared(PrepareDoubleField(1))
+	s.ZeroPrepared(PrepareFloatField(2))
+	s.ZeroPrepared(PrepareStringField(14))
+	s.EmbeddedSizePrepared(PrepareEmbeddedField(15), 1000)
+	s.BeginGroup(16)
+	s.EndGroup(16)
+	s.DoublePacked(17, []float64{1, 0, -1})
+	s.FloatPacked(18, []float32{1, 0})
+	s.Int32Packed(19, []int32{-1, 0, 1})
+	s.Int64Packed(20, []int64{math.MinInt64, 300})
+	s.Uint32Packed(21, []uint32{1, 1 << 31})
+	s.Uint64Packed(22, []uint64{math.MaxUint64})
+	s.Sint32Packed(23, []int32{-1, math.MaxInt32})
+	s.Sint64Packed(24, []int64{math.MinInt64})
+	s.Fixed32Packed(25, []uint32{1, 2})
+	s.Fixed64Packed(26, []uint64{3})
+	s.Sfixed32Packed(27, []int32{-4})
+	s.Sfixed64Packed(28, []int64{-5})
+	s.BoolPacked(29, []bool{true, false})
+	s.Int32Packed(30, nil)
+
+	expectedBytes, err := expected.BufferBytes()
+	assert.NoError(t, err)
+	b, err := s.BufferBytes()
+	assert.NoError(t, err)
+	assert.EqualValues(t, expectedBytes, b)
+}
+
+func TestGrow(t *testing.T) {
+	s := NewProtoStream()
+	s.Raw(make([]byte, defaultBufferSize-1))
+	s.Grow(10)
+	s.Fixed64Prepared(PrepareFixed64Field(1), 1)
+	s.Raw(make([]byte, 3*defaultBufferSize))
+	b, err := s.BufferBytes()
+	assert.NoError(t, err)
+	assert.Len(t, b, 4*defaultBufferSize+8)
+	assert.EqualValues(t, []byte{9, 1, 0, 0, 0, 0, 0, 0, 0}, b[defaultBufferSize-1:defaultBufferSize+8])
+}
@@ -0,0 +1,565 @@
+package textstream
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Reader reads the fields of messages in text format from a byte slice. The
+// fields of a message are read by calling NextField() until it returns false.
+// The value of the field is read after the separator is read using Colon().
+// Embedded messages are read by calling BeginMessage() and then reading the
+// fields of the embedded message.
+//
+// Repeated fields may be written either as a list in square brackets or as one
+// field per value. Both forms are read by calling BeginList() and then
+// NextRepeated() until it returns false.
+type Reader struct {
+	buf []byte
+	pos int
+
+	// closeDelims is the stack of the closing delimiters of the embedded messages
+	// being read.
+	closeDelims []byte
+}
+
+// NewReader creates a Reader that reads from b.
+func NewReader(b []byte) *Reader {
+	return &Reader{buf: b}
+}
+
+// Reset makes the Reader read from b.
+func (r *Reader) Reset(b []byte) {
+	r.buf = b
+	r.pos = 0
+	r.closeDelims = r.closeDelims[:0]
+}
+
+func (r *Reader) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid text format at offset %d: %s", r.pos, fmt.Sprintf(format, a...))
+}
+
+// skipWhitespace skips the whitespace and the comments.
+func (r *Reader) skipWhitespace() {
+	for r.pos < len(r.buf) {
+		switch r.buf[r.pos] {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			r.pos++
+		case '#':
+			for r.pos < len(r.buf) && r.buf[r.pos] != '\n' {
+				r.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next non-whitespace byte or 0 if the end of input is reached.
+func (r *Reader) peek() byte {
+	r.skipWhitespace()
+	if r.pos < len(r.buf) {
+		return r.buf[r.pos]
+	}
+	return 0
+}
+
+func (r *Reader) unexpected() error {
+	if r.pos >= len(r.buf) {
+		return r.errorf("unexpected end of input")
+	}
+	return r.errorf("unexpected character %q", r.buf[r.pos])
+}
+
+// End checks that there is nothing but whitespace and comments after the last
+// read field.
+func (r *Reader) End() error {
+	if r.peek() != 0 {
+		return r.unexpected()
+	}
+	return nil
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// readIdent reads an identifier. Returns an empty string if there is no
+// identifier at the current position.
+func (r *Reader) readIdent() string {
+	if !isIdentStart(r.peek()) {
+		return ""
+	}
+	start := r.pos
+	for r.pos < len(r.buf) && isIdentChar(r.buf[r.pos]) {
+		r.pos++
+	}
+	return string(r.buf[start:r.pos])
+}
+
+// NextField reads the name of the next field of the message. Returns false if
+// the end of the message is reached. The value of the field must be read before
+// calling NextField() again.
+func (r *Reader) NextField() (name string, ok bool, err error) {
+	c := r.peek()
+	if c == ',' || c == ';' {
+		// Optional separator after the previous field.
+		r.pos++
+		c = r.peek()
+	}
+
+	if len(r.closeDelims) == 0 {
+		if c == 0 {
+			// End of the top-level message.
+			return "", false, nil
+		}
+	} else if c == r.closeDelims[len(r.closeDelims)-1] {
+		r.pos++
+		r.closeDelims = r.closeDelims[:len(r.closeDelims)-1]
+		return "", false, nil
+	}
+
+	if c == '[' {
+		return "", false, r.errorf("extensions and Any messages are not supported")
+	}
+	name = r.readIdent()
+	if name == "" {
+		return "", false, r.unexpected()
+	}
+	return name, true, nil
+}
+
+// Colon reads the separator between the name and the value of the field. The
+// separator is optional before embedded messages.
+func (r *Reader) Colon(optional bool) error {
+	if r.peek() == ':' {
+		r.pos++
+		return nil
+	}
+	if optional {
+		return nil
+	}
+	return r.unexpected()
+}
+
+// BeginList reads the start of the list of the values of a repeated field, if the
+// values are written as a list. Returns false if there is no list, i.e. the field
+// contains a single value.
+func (r *Reader) BeginList() bool {
+	if r.peek() == '[' {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+// NextRepeated prepares the reading of the next value of a repeated field. list
+// is the result of BeginList(), first is true for the first call. Returns false
+// if there are no more values.
+func (r *Reader) NextRepeated(list bool, first bool) (bool, error) {
+	if !list {
+		return first, nil
+	}
+	c := r.peek()
+	if c == ']' {
+		r.pos++
+		return false, nil
+	}
+	if !first {
+		if c != ',' {
+			return false, r.unexpected()
+		}
+		r.pos++
+	}
+	return true, nil
+}
+
+// BeginMessage reads the start of an embedded message. The fields of the message
+// can be then read using NextField().
+func (r *Reader) BeginMessage() error {
+	switch r.peek() {
+	case '{':
+		r.closeDelims = append(r.closeDelims, '}')
+	case '<':
+		r.closeDelims = append(r.closeDelims, '>')
+	default:
+		return r.unexpected()
+	}
+	r.pos++
+	return nil
+}
+
+// ReadBool reads a bool value.
+func (r *Reader) ReadBool() (bool, error) {
+	start := r.pos
+	switch r.readIdent() {
+	case "true", "True", "t":
+		return true, nil
+	case "false", "False", "f":
+		return false, nil
+	case "":
+		v, err := r.readUint(32)
+		if err == nil && v <= 1 {
+			return v == 1, nil
+		}
+	}
+	r.pos = start
+	return false, r.errorf("invalid bool value")
+}
+
+// ReadInt32 reads a 32-bit signed integer.
+func (r *Reader) ReadInt32() (int32, error) {
+	v, err := r.readInt(32)
+	return int32(v), err
+}
+
+// ReadInt64 reads a 64-bit signed integer.
+func (r *Reader) ReadInt64() (int64, error) {
+	return r.readInt(64)
+}
+
+// ReadUint32 reads a 32-bit unsigned integer.
+func (r *Reader) ReadUint32() (uint32, error) {
+	v, err := r.readUint(32)
+	return uint32(v), err
+}
+
+// ReadUint64 reads a 64-bit unsigned integer.
+func (r *Reader) ReadUint64() (uint64, error) {
+	return r.readUint(64)
+}
+
+// ReadFloat reads a float value.
+func (r *Reader) ReadFloat() (float32, error) {
+	v, err := r.readFloat(32)
+	return float32(v), err
+}
+
+// ReadDouble reads a double value.
+func (r *Reader) ReadDouble() (float64, error) {
+	return r.readFloat(64)
+}
+
+// ReadEnum reads an enum value, which may be specified by the name or by the
+// number.
+func (r *Reader) ReadEnum(values map[string]uint32) (uint32, error) {
+	start := r.pos
+	if name := r.readIdent(); name != "" {
+		if v, ok := values[name]; ok {
+			return v, nil
+		}
+		r.pos = start
+		return 0, r.errorf("unknown enum value %q", name)
+	}
+	v, err := r.readInt(32)
+	return uint32(int32(v)), err
+}
+
+// ReadString reads a string value. Adjacent string literals are concatenated.
+// The string must be valid UTF-8.
+func (r *Reader) ReadString() (string, error) {
+	start := r.pos
+	s, err := r.readString()
+	if err != nil {
+		return "", err
+	}
+	if !utf8.ValidString(s) {
+		r.pos = start
+		return "", r.errorf("string field contains invalid UTF-8")
+	}
+	return s, nil
+}
+
+// ReadBytes reads a bytes value. Adjacent string literals are concatenated.
+func (r *Reader) ReadBytes() ([]byte, error) {
+	s, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// number is a numeric literal.
+type number struct {
+	// text of the literal without the sign and the float suffix.
+	text string
+	neg  bool
+	// isFloat is true if the literal contains a fraction, an exponent or the
+	// float suffix.
+	isFloat bool
+}
+
+// readNumber reads a numeric literal.
+func (r *Reader) readNumber() (n number, err error) {
+	start := r.pos
+	if r.peek() == '-' {
+		n.neg = true
+		r.pos++
+		// Whitespace and comments are allowed after the minus sign.
+		r.skipWhitespace()
+	}
+
+	begin := r.pos
+	if r.pos+1 < len(r.buf) && r.buf[r.pos] == '0' && (r.buf[r.pos+1] == 'x' || r.buf[r.pos+1] == 'X') {
+		r.pos += 2
+		for r.pos < len(r.buf) && strings.IndexByte("0123456789abcdefABCDEF", r.buf[r.pos]) >= 0 {
+			r.pos++
+		}
+		if r.pos == begin+2 {
+			r.pos = start
+			return n, r.errorf("invalid number")
+		}
+	} else {
+		digits := 0
+		for r.pos < len(r.buf) && r.buf[r.pos] >= '0' && r.buf[r.pos] <= '9' {
+			r.pos++
+			digits++
+		}
+		if r.pos < len(r.buf) && r.buf[r.pos] == '.' {
+			n.isFloat = true
+			r.pos++
+			for r.pos < len(r.buf) && r.buf[r.pos] >= '0' && r.buf[r.pos] <= '9' {
+				r.pos++
+				digits++
+			}
+		}
+		if digits == 0 {
+			r.pos = start
+			return n, r.unexpected()
+		}
+		if r.pos < len(r.buf) && (r.buf[r.pos] == 'e' || r.buf[r.pos] == 'E') {
+			n.isFloat = true
+			r.pos++
+			if r.pos < len(r.buf) && (r.buf[r.pos] == '+' || r.buf[r.pos] == '-') {
+				r.pos++
+			}
+			expStart := r.pos
+			for r.pos < len(r.buf) && r.buf[r.pos] >= '0' && r.buf[r.pos] <= '9' {
+				r.pos++
+			}
+			if r.pos == expStart {
+				r.pos = start
+				return n, r.errorf("invalid number")
+			}
+		}
+	}
+	n.text = string(r.buf[begin:r.pos])
+
+	if r.pos < len(r.buf) && (r.buf[r.pos] == 'f' || r.buf[r.pos] == 'F') {
+		n.isFloat = true
+		r.pos++
+	}
+	if r.pos < len(r.buf) && (isIdentChar(r.buf[r.pos]) || r.buf[r.pos] == '.') {
+		r.pos = start
+		return n, r.errorf("invalid number")
+	}
+	return n, nil
+}
+
+// parseUint parses the absolute value of the integer literal.
+func (n number) parseUint() (uint64, bool) {
+	if n.isFloat {
+		return 0, false
+	}
+	text, base := n.text, 10
+	switch {
+	case strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X"):
+		text, base = text[2:], 16
+	case len(text) > 1 && text[0] == '0':
+		base = 8
+	}
+	v, err := strconv.ParseUint(text, base, 64)
+	return v, err == nil
+}
+
+func (r *Reader) readInt(bitSize int) (int64, error) {
+	start := r.pos
+	n, err := r.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := n.parseUint()
+	limit := uint64(1)<<(bitSize-1) - 1
+	if n.neg {
+		limit++
+	}
+	if !ok || v > limit {
+		r.pos = start
+		return 0, r.errorf("invalid int%d value", bitSize)
+	}
+	if n.neg {
+		return -int64(v), nil
+	}
+	return int64(v), nil
+}
+
+func (r *Reader) readUint(bitSize int) (uint64, error) {
+	start := r.pos
+	n, err := r.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := n.parseUint()
+	if !ok || n.neg || v > math.MaxUint64>>(64-bitSize) {
+		r.pos = start
+		return 0, r.errorf("invalid uint%d value", bitSize)
+	}
+	return v, nil
+}
+
+func (r *Reader) readFloat(bitSize int) (float64, error) {
+	start := r.pos
+	neg := false
+	if r.peek() == '-' {
+		neg = true
+		r.pos++
+		r.skipWhitespace()
+	}
+	if ident := r.readIdent(); ident != "" {
+		var s string
+		switch strings.ToLower(ident) {
+		case "inf", "infinity":
+			s = "inf"
+		case "nan":
+			s = "nan"
+		default:
+			r.pos = start
+			return 0, r.errorf("invalid float value")
+		}
+		if neg {
+			s = "-" + s
+		}
+		v, _ := strconv.ParseFloat(s, bitSize)
+		return v, nil
+	}
+
+	r.pos = start
+	n, err := r.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	text := n.text
+	if !n.isFloat && len(text) > 1 && text[0] == '0' {
+		// Hex and octal integers are not valid floats.
+		r.pos = start
+		return 0, r.errorf("invalid float value")
+	}
+	if n.neg {
+		text = "-" + text
+	}
+	v, err := strconv.ParseFloat(text, bitSize)
+	if err != nil {
+		r.pos = start
+		return 0, r.errorf("invalid float value")
+	}
+	return v, nil
+}
+
+// readString reads one or more adjacent string literals and returns the
+// concatenated unescaped content.
+func (r *Reader) readString() (string, error) {
+	var sb strings.Builder
+	quote := r.peek()
+	if quote != '"' && quote != '\'' {
+		return "", r.unexpected()
+	}
+	for quote == '"' || quote == '\'' {
+		r.pos++
+		for {
+			if r.pos >= len(r.buf) || r.buf[r.pos] == '\n' {
+				return "", r.errorf("unterminated string")
+			}
+			c := r.buf[r.pos]
+			if c == quote {
+				r.pos++
+				break
+			}
+			if c != '\\' {
+				sb.WriteByte(c)
+				r.pos++
+				continue
+			}
+			if err := r.readEscape(&sb); err != nil {
+				return "", err
+			}
+		}
+		quote = r.peek()
+	}
+	return sb.String(), nil
+}
+
+// readEscape reads the escape sequence that starts at the current position.
+func (r *Reader) readEscape(sb *strings.Builder) error {
+	if r.pos+1 >= len(r.buf) {
+		return r.errorf("unterminated string")
+	}
+	c := r.buf[r.pos+1]
+	r.pos += 2
+	switch c {
+	case 'a':
+		sb.WriteByte('\a')
+	case 'b':
+		sb.WriteByte('\b')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'r':
+		sb.WriteByte('\r')
+	case 't':
+		sb.WriteByte('\t')
+	case 'v':
+		sb.WriteByte('\v')
+	case '"', '\'', '\\', '?':
+		sb.WriteByte(c)
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		// Up to 3 octal digits.
+		end := r.pos - 1
+		for end < len(r.buf) && end < r.pos+2 && r.buf[end] >= '0' && r.buf[end] <= '7' {
+			end++
+		}
+		v, err := strconv.ParseUint(string(r.buf[r.pos-1:end]), 8, 16)
+		if err != nil || v > 0xff {
+			return r.errorf("invalid octal escape")
+		}
+		sb.WriteByte(byte(v))
+		r.pos = end
+	case 'x', 'X':
+		// Up to 2 hex digits.
+		end := r.pos
+		for end < len(r.buf) && end < r.pos+2 &&
+			strings.IndexByte("0123456789abcdefABCDEF", r.buf[end]) >= 0 {
+			end++
+		}
+		v, err := strconv.ParseUint(string(r.buf[r.pos:end]), 16, 8)
+		if err != nil {
+			return r.errorf("invalid hex escape")
+		}
+		sb.WriteByte(byte(v))
+		r.pos = end
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if r.pos+size > len(r.buf) {
+			return r.errorf("invalid unicode escape")
+		}
+		v, err := strconv.ParseUint(string(r.buf[r.pos:r.pos+size]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(v)) {
+			return r.errorf("invalid unicode escape")
+		}
+		sb.WriteRune(rune(v))
+		r.pos += size
+	default:
+		return r.errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package textstream
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSample(tw *Writer) {
+	tw.Name("a")
+	tw.Int32(-1)
+	tw.Name("b")
+	tw.BeginMessage()
+	tw.Name("c")
+	tw.Uint64(math.MaxUint64)
+	tw.Name("d")
+	tw.BeginMessage()
+	tw.EndMessage()
+	tw.EndMessage()
+	tw.Name("e")
+	tw.Enum(1, map[uint32]string{1: "ONE"})
+	tw.Name("f")
+	tw.Enum(math.MaxUint32, map[uint32]string{1: "ONE"})
+	tw.End()
+}
+
+func TestWriterMultiline(t *testing.T) {
+	tw := NewWriter(nil, "  ")
+	writeSample(tw)
+	b, err := tw.BufferBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "a: -1\nb: {\n  c: 18446744073709551615\n  d: {}\n}\ne: ONE\nf: -1\n", string(b))
+}
+
+func TestWriterSingleLine(t *testing.T) {
+	tw := NewWriter(nil, "")
+	writeSample(tw)
+	b, err := tw.BufferBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "a:-1 b:{c:18446744073709551615 d:{}} e:ONE f:-1", string(b))
+}
+
+func TestWriterValues(t *testing.T) {
+	tests := []struct {
+		write    func(tw *Writer)
+		expected string
+	}{
+		{write: func(tw *Writer) { tw.Double(1e21) }, expected: `1e+21`},
+		{write: func(tw *Writer) { tw.Double(math.Copysign(0, -1)) }, expected: `-0`},
+		{write: func(tw *Writer) { tw.Float(0.1) }, expected: `0.1`},
+		{write: func(tw *Writer) { tw.Double(math.NaN()) }, expected: `nan`},
+		{write: func(tw *Writer) { tw.Float(float32(math.Inf(-1))) }, expected: `-inf`},
+		{write: func(tw *Writer) { tw.Bool(true) }, expected: `true`},
+		{
+			write:    func(tw *Writer) { tw.String("a\"\\\n\t\x01\x7f'é\u0085") },
+			expected: `"a\"\\\n\t\x01\x7f'é\u0085"`,
+		},
+		{write: func(tw *Writer) { tw.Bytes([]byte{0, 0xff, 'a'}) }, expected: `"\x00\xffa"`},
+	}
+	for _, test := range tests {
+		tw := NewWriter(nil, "")
+		test.write(tw)
+		b, err := tw.BufferBytes()
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, string(b))
+	}
+
+	tw := NewWriter(nil, "")
+	tw.String("\xff")
+	_, err := tw.BufferBytes()
+	assert.Error(t, err)
+}
+
+func TestReader(t *testing.T) {
+	r := NewReader(
+		[]byte(`a: [1, -0x10, 010] # comment
+b < s: 'x' "yé\101"; f: -Inf > c: 1.5f, e: ONE e: 2`),
+	)
+
+	name, ok, err := r.NextField()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "a", name)
+	require.NoError(t, r.Colon(false))
+	list := r.BeginList()
+	assert.True(t, list)
+	var ints []int64
+	for first := true; ; first = false {
+		ok, err := r.NextRepeated(list, first)
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		v, err := r.ReadInt64()
+		require.NoError(t, err)
+		ints = append(ints, v)
+	}
+	assert.Equal(t, []int64{1, -16, 8}, ints)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+	require.NoError(t, r.Colon(true))
+	require.NoError(t, r.BeginMessage())
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "s", name)
+	require.NoError(t, r.Colon(false))
+	s, err := r.ReadString()
+	require.NoError(t, err)
+	assert.Equal(t, "xyéA", s)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "f", name)
+	require.NoError(t, r.Colon(false))
+	f, err := r.ReadDouble()
+	require.NoError(t, err)
+	assert.True(t, math.IsInf(f, -1))
+
+	_, ok, err = r.NextField()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	name, _, err = r.NextField()
+	require.NoError(t, err)
+	assert.Equal(t, "c", name)
+	require.NoError(t, r.Colon(false))
+	ff, err := r.ReadFloat()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1.5, ff)
+
+	values := map[string]uint32{"ONE": 1}
+	for _, expected := range []uint32{1, 2} {
+		name, _, err = r.NextField()
+		require.NoError(t, err)
+		assert.Equal(t, "e", name)
+		require.NoError(t, r.Colon(false))
+		e, err := r.ReadEnum(values)
+		require.NoError(t, err)
+		assert.EqualValues(t, expected, e)
+	}
+
+	_, ok, err = r.NextField()
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, r.End())
+}
+
+func TestReaderNumberErrors(t *testing.T) {
+	for _, s := range []string{`2147483648`, `1.5`, `"1"`, `1e5`, `0x`, `1a`} {
+		_, err := NewReader([]byte(s)).ReadInt32()
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{`-1`, `18446744073709551616`} {
+		_, err := NewReader([]byte(s)).ReadUint64()
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{`0x10`, `abc`, `1e`} {
+		_, err := NewReader([]byte(s)).ReadDouble()
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{`2`, `yes`} {
+		_, err := NewReader([]byte(s)).ReadBool()
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,278 @@
+// Package textstream implements the reading and writing of the protobuf text
+// format. The output is compatible with the output of
+// google.golang.org/protobuf/encoding/prototext. The package is used by the code
+// generated by lazyproto.
+package textstream
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"strconv"
+	"unicode/utf8"
+)
+
+var errInvalidUTF8 = errors.New("string field contains invalid UTF-8")
+
+// flushThreshold is the size of the buffered output after which the Writer
+// writes the buffer to the underlying io.Writer.
+const flushThreshold = 4096
+
+// The kinds of the last written token. Determine the whitespace that is written
+// before the next token.
+const (
+	tokenNone = iota
+	tokenName
+	tokenScalar
+	tokenMessageOpen
+	tokenMessageClose
+)
+
+// Writer writes the fields of messages in text format. The whitespace is the
+// same as the whitespace written by prototext.
+//
+// The write methods don't return errors. The first error that happens is
+// remembered and is returned by Flush() or BufferBytes().
+type Writer struct {
+	w   io.Writer
+	buf []byte
+
+	// indent is empty for single line output.
+	indent string
+	// indents is the current indentation of the multiline output.
+	indents []byte
+	// lastToken is the kind of the last written token.
+	lastToken int
+
+	err error
+}
+
+// NewWriter creates a Writer that writes to w. If w is nil the output is
+// accumulated in the buffer and can be obtained using BufferBytes().
+//
+// If indent is empty the output is a single line, the same as the output of
+// prototext.Marshal(). Otherwise every field is written on a separate line, the
+// same as the output of prototext.MarshalOptions{Multiline: true, Indent: indent}.
+func NewWriter(w io.Writer, indent string) *Writer {
+	return &Writer{w: w, indent: indent}
+}
+
+// Reset discards the buffered output and the error and makes the Writer write to w.
+// The buffer is reused.
+func (tw *Writer) Reset(w io.Writer) {
+	tw.w = w
+	tw.buf = tw.buf[:0]
+	tw.indents = tw.indents[:0]
+	tw.lastToken = tokenNone
+	tw.err = nil
+}
+
+// BufferBytes returns the accumulated output. The returned slice is valid until
+// the next write or Reset.
+func (tw *Writer) BufferBytes() ([]byte, error) {
+	return tw.buf, tw.err
+}
+
+// Flush writes the buffered output to the underlying io.Writer.
+func (tw *Writer) Flush() error {
+	if tw.err != nil || tw.w == nil || len(tw.buf) == 0 {
+		return tw.err
+	}
+	_, tw.err = tw.w.Write(tw.buf)
+	tw.buf = tw.buf[:0]
+	return tw.err
+}
+
+func (tw *Writer) maybeFlush() {
+	if tw.w != nil && len(tw.buf) >= flushThreshold {
+		_ = tw.Flush()
+	}
+}
+
+// End must be called after all fields of the top-level message are written.
+// Terminates the last line of the multiline output.
+func (tw *Writer) End() {
+	if tw.indent != "" && tw.lastToken != tokenNone {
+		tw.buf = append(tw.buf, '\n')
+	}
+}
+
+// prepareNext writes the whitespace that precedes the next token.
+func (tw *Writer) prepareNext(next int) {
+	last := tw.lastToken
+	tw.lastToken = next
+
+	if tw.indent == "" {
+		if (last == tokenScalar || last == tokenMessageClose) && next == tokenName {
+			tw.buf = append(tw.buf, ' ')
+		}
+		return
+	}
+
+	switch {
+	case last == tokenName:
+		tw.buf = append(tw.buf, ' ')
+
+	case last == tokenMessageOpen && next != tokenMessageClose:
+		tw.indents = append(tw.indents, tw.indent...)
+		tw.buf = append(tw.buf, '\n')
+		tw.buf = append(tw.buf, tw.indents...)
+
+	case last == tokenScalar || last == tokenMessageClose:
+		if next == tokenMessageClose {
+			tw.indents = tw.indents[:len(tw.indents)-len(tw.indent)]
+		}
+		tw.buf = append(tw.buf, '\n')
+		tw.buf = append(tw.buf, tw.indents...)
+	}
+}
+
+// Name writes the name of a field. Must be followed by the value.
+func (tw *Writer) Name(name string) {
+	tw.prepareNext(tokenName)
+	tw.buf = append(tw.buf, name...)
+	tw.buf = append(tw.buf, ':')
+}
+
+// BeginMessage writes the start of an embedded message.
+func (tw *Writer) BeginMessage() {
+	tw.prepareNext(tokenMessageOpen)
+	tw.buf = append(tw.buf, '{')
+}
+
+// EndMessage writes the end of an embedded message.
+func (tw *Writer) EndMessage() {
+	tw.prepareNext(tokenMessageClose)
+	tw.buf = append(tw.buf, '}')
+	tw.maybeFlush()
+}
+
+// Bool writes a bool value.
+func (tw *Writer) Bool(v bool) {
+	tw.prepareNext(tokenScalar)
+	tw.buf = strconv.AppendBool(tw.buf, v)
+}
+
+// Int32 writes a 32-bit signed integer.
+func (tw *Writer) Int32(v int32) {
+	tw.Int64(int64(v))
+}
+
+// Uint32 writes a 32-bit unsigned integer.
+func (tw *Writer) Uint32(v uint32) {
+	tw.Uint64(uint64(v))
+}
+
+// Int64 writes a 64-bit signed integer.
+func (tw *Writer) Int64(v int64) {
+	tw.prepareNext(tokenScalar)
+	tw.buf = strconv.AppendInt(tw.buf, v, 10)
+}
+
+// Uint64 writes a 64-bit unsigned integer.
+func (tw *Writer) Uint64(v uint64) {
+	tw.prepareNext(tokenScalar)
+	tw.buf = strconv.AppendUint(tw.buf, v, 10)
+}
+
+// Float writes a float value.
+func (tw *Writer) Float(v float32) {
+	tw.prepareNext(tokenScalar)
+	tw.appendFloat(float64(v), 32)
+}
+
+// Double writes a double value.
+func (tw *Writer) Double(v float64) {
+	tw.prepareNext(tokenScalar)
+	tw.appendFloat(v, 64)
+}
+
+// String writes a string value. The string must be valid UTF-8.
+func (tw *Writer) String(v string) {
+	tw.prepareNext(tokenScalar)
+	if !utf8.ValidString(v) && tw.err == nil {
+		tw.err = errInvalidUTF8
+	}
+	tw.appendString(v)
+}
+
+// Bytes writes a bytes value as a string literal.
+func (tw *Writer) Bytes(v []byte) {
+	tw.prepareNext(tokenScalar)
+	tw.appendString(string(v))
+}
+
+// Enum writes the enum value as the name of the value. If the value is not
+// found in names, it is written as a number.
+func (tw *Writer) Enum(v uint32, names map[uint32]string) {
+	if name, ok := names[v]; ok {
+		tw.prepareNext(tokenScalar)
+		tw.buf = append(tw.buf, name...)
+		return
+	}
+	tw.Int64(int64(int32(v)))
+}
+
+func (tw *Writer) appendFloat(v float64, bitSize int) {
+	switch {
+	case math.IsNaN(v):
+		tw.buf = append(tw.buf, "nan"...)
+	case math.IsInf(v, 1):
+		tw.buf = append(tw.buf, "inf"...)
+	case math.IsInf(v, -1):
+		tw.buf = append(tw.buf, "-inf"...)
+	default:
+		tw.buf = strconv.AppendFloat(tw.buf, v, 'g', -1, bitSize)
+	}
+}
+
+// appendString writes the quoted and escaped string, the same way as prototext
+// does. The bytes that are not valid UTF-8 are escaped using hex escapes.
+func (tw *Writer) appendString(s string) {
+	tw.buf = append(tw.buf, '"')
+	for len(s) > 0 {
+		// Copy the run of the characters that don't need escaping as is.
+		i := 0
+		for i < len(s) && s[i] >= ' ' && s[i] != '"' && s[i] != '\\' && s[i] < 0x7f {
+			i++
+		}
+		tw.buf = append(tw.buf, s[:i]...)
+		s = s[i:]
+		if len(s) == 0 {
+			break
+		}
+
+		r, n := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError && n == 1 {
+			r = rune(s[0])
+		} else if r >= utf8.RuneSelf && r > 0x9f {
+			tw.buf = append(tw.buf, s[:n]...)
+			s = s[n:]
+			continue
+		}
+		s = s[n:]
+
+		switch {
+		case r == '"' || r == '\\':
+			tw.buf = append(tw.buf, '\\', byte(r))
+		case r == '\n':
+			tw.buf = append(tw.buf, '\\', 'n')
+		case r == '\r':
+			tw.buf = append(tw.buf, '\\', 'r')
+		case r == '\t':
+			tw.buf = append(tw.buf, '\\', 't')
+		case n == 1:
+			// Control characters and invalid UTF-8.
+			tw.buf = append(tw.buf, '\\', 'x')
+			tw.buf = append(tw.buf, "00"[1+(bits.Len32(uint32(r))-1)/4:]...)
+			tw.buf = strconv.AppendUint(tw.buf, uint64(r), 16)
+		default:
+			// C1 control characters.
+			tw.buf = append(tw.buf, '\\', 'u')
+			tw.buf = append(tw.buf, "0000"[1+(bits.Len32(uint32(r))-1)/4:]...)
+			tw.buf = strconv.AppendUint(tw.buf, uint64(r), 16)
+		}
+	}
+	tw.buf = append(tw.buf, '"')
+}
@@ -0,0 +1,53 @@
+package lazyproto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigrannajaryan/exp-lazyproto/runtime/protomessage"
+)
+
+// messageMethods are the methods of Message and the versions of the generated code
+// that added them. The generated code asserts that the messages implement Message,
+// so the code generated by older versions does not compile with the current
+// runtime. When a method is added, add it here with the incremented
+// protomessage.GenVersion and raise protomessage.MinVersion to it.
+var messageMethods = map[string]int{
+	"CloneMessage":  1,
+	"DecodeErr":     7,
+	"Free":          1,
+	"IsModified":    1,
+	"Marshal":       1,
+	"MarshalJSON":   2,
+	"MarshalText":   3,
+	"ProtoReflect":  4,
+	"Size":          6,
+	"UnknownFields": 1,
+	"Unmarshal":     1,
+	"UnmarshalJSON": 2,
+	"UnmarshalText": 3,
+}
+
+func TestMessageMethodsVersion(t *testing.T) {
+	typ := reflect.TypeOf((*Message)(nil)).Elem()
+	require.Equal(
+		t, len(messageMethods), typ.NumMethod(),
+		"Message changed, update messageMethods and raise protomessage.MinVersion",
+	)
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+		version, ok := messageMethods[name]
+		require.True(
+			t, ok, "Message method %s is added, add it to messageMethods and raise "+
+				"protomessage.MinVersion", name,
+		)
+		assert.GreaterOrEqual(
+			t, protomessage.MinVersion, version,
+			"Message method %s requires protomessage.MinVersion %d", name, version,
+		)
+	}
+	assert.LessOrEqual(t, protomessage.MinVersion, protomessage.GenVersion)
+}